@@ -5,6 +5,7 @@ import (
 	"io"
 	"mime/multipart"
 	"net/http"
+	"net/http/cookiejar"
 	"strings"
 	"testing"
 )
@@ -155,11 +156,12 @@ paths:
 	writer.WriteField("format", "openapi")
 	writer.Close()
 
-	resp, err := http.Post(
-		getTestURL("/api/convert"),
-		writer.FormDataContentType(),
-		body,
-	)
+	client, csrfToken := newCSRFClient(t)
+	req, _ := http.NewRequest(http.MethodPost, getTestURL("/api/convert"), body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("X-CSRF-Token", csrfToken)
+
+	resp, err := client.Do(req)
 	if err != nil {
 		t.Fatalf("failed to post to convert endpoint: %v", err)
 	}
@@ -193,3 +195,82 @@ func TestStaticAssetsServed(t *testing.T) {
 		t.Errorf("static route returned server error: %d", resp.StatusCode)
 	}
 }
+
+// newCSRFClient loads a page like a browser would, returning a client holding
+// the CSRF cookie and the token to echo back.
+func newCSRFClient(t *testing.T) (*http.Client, string) {
+	t.Helper()
+	jar, _ := cookiejar.New(nil)
+	client := &http.Client{Jar: jar}
+
+	resp, err := client.Get(getTestURL("/convert"))
+	if err != nil {
+		t.Fatalf("failed to get convert page: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	token := resp.Header.Get("X-CSRF-Token")
+	if token == "" || !strings.Contains(string(body), token) {
+		t.Fatal("expected CSRF token in response header and page")
+	}
+	return client, token
+}
+
+func TestFormEndpointsRequireCSRFToken(t *testing.T) {
+	resp, err := http.Post(getTestURL("/api/convert"), "application/x-www-form-urlencoded", strings.NewReader("content=hello"))
+	if err != nil {
+		t.Fatalf("failed to post to convert endpoint: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("expected status 403 without CSRF token, got %d", resp.StatusCode)
+	}
+
+	client, _ := newCSRFClient(t)
+	req, _ := http.NewRequest(http.MethodPost, getTestURL("/api/convert"), strings.NewReader("content=hello"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-CSRF-Token", "forged")
+	resp, err = client.Do(req)
+	if err != nil {
+		t.Fatalf("failed to post to convert endpoint: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("expected status 403 for mismatched CSRF token, got %d", resp.StatusCode)
+	}
+
+	// Without auth the token-only write endpoints fall back to CSRF checks
+	resp, err = http.Post(getTestURL("/api/skills"), "application/x-www-form-urlencoded", strings.NewReader("content=hello"))
+	if err != nil {
+		t.Fatalf("failed to post to skills endpoint: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("expected status 403 without CSRF token when auth is off, got %d", resp.StatusCode)
+	}
+}
+
+func TestBearerClientsSkipCSRF(t *testing.T) {
+	_, secret, err := testApp.TokenService.CreateToken("e2e", "", []string{"publish"})
+	if err != nil {
+		t.Fatalf("failed to create token: %v", err)
+	}
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	writer.WriteField("content", "hello")
+	writer.Close()
+
+	req, _ := http.NewRequest(http.MethodPost, getTestURL("/api/convert"), body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("Authorization", "Bearer "+secret)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to post to convert endpoint: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected bearer client to skip CSRF, got %d", resp.StatusCode)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"log/slog"
 	"os"
 
+	"github.com/sanixdarker/skill-md/internal/auth"
 	"github.com/sanixdarker/skill-md/internal/converter"
 	"github.com/sanixdarker/skill-md/internal/merger"
 	"github.com/sanixdarker/skill-md/internal/registry"
@@ -25,14 +26,17 @@ type Config struct {
 	BitbucketUsername string
 	BitbucketPassword string
 	CodebergToken     string
+	// RequireAuth enforces API tokens and skill ownership on write endpoints.
+	RequireAuth bool
 }
 
 // DefaultConfig returns the default configuration.
 func DefaultConfig() *Config {
 	return &Config{
-		Port:   8080,
-		DBPath: "./skill-md.db",
-		Debug:  false,
+		Port:        8080,
+		DBPath:      "./skill-md.db",
+		Debug:       false,
+		RequireAuth: true,
 	}
 }
 
@@ -45,6 +49,7 @@ type App struct {
 	Merger           *merger.Merger
 	RegistryService  *registry.Service
 	FederatedSource  *sources.FederatedSource
+	TokenService     *auth.Service
 }
 
 // New creates a new application instance.
@@ -79,6 +84,9 @@ func New(cfg *Config) (*App, error) {
 	repo := registry.NewRepository(db)
 	registryService := registry.NewService(repo)
 
+	// Initialize API token service
+	tokenService := auth.NewService(auth.NewRepository(db))
+
 	// Initialize federated source
 	federatedSource := sources.NewFederatedSource(logger)
 
@@ -110,6 +118,7 @@ func New(cfg *Config) (*App, error) {
 		Merger:           mergerInstance,
 		RegistryService:  registryService,
 		FederatedSource:  federatedSource,
+		TokenService:     tokenService,
 	}, nil
 }
 
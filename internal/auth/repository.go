@@ -0,0 +1,138 @@
+package auth
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Repository handles database operations for API tokens.
+type Repository struct {
+	db *sql.DB
+}
+
+// NewRepository creates a new Repository.
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Create inserts a new token with the given secret hash.
+func (r *Repository) Create(t *Token, hash string) error {
+	_, err := r.db.Exec(`
+		INSERT INTO api_tokens (id, owner, name, prefix, token_hash, scopes, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, t.ID, t.Owner, t.Name, t.Prefix, hash, strings.Join(t.Scopes, ","), t.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create token: %w", err)
+	}
+	return nil
+}
+
+// GetByHash retrieves a token by its secret hash.
+func (r *Repository) GetByHash(hash string) (*Token, error) {
+	return r.scanOne(r.db.QueryRow(`
+		SELECT id, owner, name, prefix, scopes, created_at, last_used_at, revoked_at
+		FROM api_tokens WHERE token_hash = ?
+	`, hash))
+}
+
+// FindByIDOrPrefix retrieves the tokens matching an ID or a displayed
+// prefix. Prefixes are not unique, so more than one token may match.
+func (r *Repository) FindByIDOrPrefix(idOrPrefix string) ([]*Token, error) {
+	rows, err := r.db.Query(`
+		SELECT id, owner, name, prefix, scopes, created_at, last_used_at, revoked_at
+		FROM api_tokens WHERE id = ? OR prefix = ?
+	`, idOrPrefix, idOrPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+	defer rows.Close()
+
+	var tokens []*Token
+	for rows.Next() {
+		t, err := scanToken(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan token: %w", err)
+		}
+		tokens = append(tokens, t)
+	}
+
+	return tokens, rows.Err()
+}
+
+// List retrieves tokens, optionally filtered by owner.
+func (r *Repository) List(owner string) ([]*Token, error) {
+	query := `
+		SELECT id, owner, name, prefix, scopes, created_at, last_used_at, revoked_at
+		FROM api_tokens`
+	var args []interface{}
+	if owner != "" {
+		query += " WHERE owner = ?"
+		args = append(args, owner)
+	}
+	query += " ORDER BY created_at DESC"
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tokens: %w", err)
+	}
+	defer rows.Close()
+
+	var tokens []*Token
+	for rows.Next() {
+		t, err := scanToken(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan token: %w", err)
+		}
+		tokens = append(tokens, t)
+	}
+
+	return tokens, nil
+}
+
+// Revoke marks a token as revoked.
+func (r *Repository) Revoke(id string) error {
+	_, err := r.db.Exec("UPDATE api_tokens SET revoked_at = ? WHERE id = ? AND revoked_at IS NULL", time.Now(), id)
+	return err
+}
+
+// TouchLastUsed records that a token was used.
+func (r *Repository) TouchLastUsed(id string) error {
+	_, err := r.db.Exec("UPDATE api_tokens SET last_used_at = ? WHERE id = ?", time.Now(), id)
+	return err
+}
+
+func (r *Repository) scanOne(row *sql.Row) (*Token, error) {
+	t, err := scanToken(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+	return t, nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanToken(s scanner) (*Token, error) {
+	t := &Token{}
+	var scopes string
+	var lastUsed, revoked sql.NullTime
+	if err := s.Scan(&t.ID, &t.Owner, &t.Name, &t.Prefix, &scopes, &t.CreatedAt, &lastUsed, &revoked); err != nil {
+		return nil, err
+	}
+	if scopes != "" {
+		t.Scopes = strings.Split(scopes, ",")
+	}
+	if lastUsed.Valid {
+		t.LastUsedAt = &lastUsed.Time
+	}
+	if revoked.Valid {
+		t.RevokedAt = &revoked.Time
+	}
+	return t, nil
+}
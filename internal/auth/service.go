@@ -0,0 +1,175 @@
+// Package auth provides API tokens for the registry's write endpoints.
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Token scopes.
+const (
+	ScopeRead    = "read"
+	ScopePublish = "publish"
+	ScopeDelete  = "delete"
+	// ScopeAdmin grants every scope and access to skills owned by others.
+	ScopeAdmin = "admin"
+)
+
+// secretPrefix marks skill-md API tokens so they are easy to recognise in logs and secret scanners.
+const secretPrefix = "smd_"
+
+// validScopes lists the scopes a token may be granted.
+var validScopes = map[string]bool{
+	ScopeRead:    true,
+	ScopePublish: true,
+	ScopeDelete:  true,
+	ScopeAdmin:   true,
+}
+
+// ErrInvalidToken is returned when a secret does not match an active token.
+var ErrInvalidToken = errors.New("invalid or revoked token")
+
+// ErrAmbiguousToken is returned when a prefix matches more than one token.
+var ErrAmbiguousToken = errors.New("ambiguous token prefix")
+
+// Token is an API token. The secret itself is only known at creation time.
+type Token struct {
+	ID         string     `json:"id"`
+	Owner      string     `json:"owner"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+// IsAdmin reports whether the token has the admin scope.
+func (t *Token) IsAdmin() bool {
+	for _, s := range t.Scopes {
+		if s == ScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+// HasScope reports whether the token grants scope. Admin tokens grant all scopes.
+func (t *Token) HasScope(scope string) bool {
+	for _, s := range t.Scopes {
+		if s == scope || s == ScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+// Service manages API tokens.
+type Service struct {
+	repo *Repository
+}
+
+// NewService creates a new Service.
+func NewService(repo *Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// CreateToken creates a token for owner and returns it with its secret.
+// The secret is not stored and cannot be recovered later.
+func (s *Service) CreateToken(owner, name string, scopes []string) (*Token, string, error) {
+	owner = strings.TrimSpace(owner)
+	if owner == "" {
+		return nil, "", errors.New("token owner is required")
+	}
+	if len(scopes) == 0 {
+		return nil, "", errors.New("at least one scope is required")
+	}
+	for _, scope := range scopes {
+		if !validScopes[scope] {
+			return nil, "", fmt.Errorf("unknown scope: %s", scope)
+		}
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	secret := secretPrefix + hex.EncodeToString(raw)
+
+	if name == "" {
+		name = "default"
+	}
+	t := &Token{
+		ID:        uuid.New().String(),
+		Owner:     owner,
+		Name:      name,
+		Prefix:    secret[:len(secretPrefix)+8],
+		Scopes:    scopes,
+		CreatedAt: time.Now(),
+	}
+
+	if err := s.repo.Create(t, hashSecret(secret)); err != nil {
+		return nil, "", err
+	}
+
+	return t, secret, nil
+}
+
+// Authenticate returns the active token matching secret.
+func (s *Service) Authenticate(secret string) (*Token, error) {
+	if !strings.HasPrefix(secret, secretPrefix) {
+		return nil, ErrInvalidToken
+	}
+
+	t, err := s.repo.GetByHash(hashSecret(secret))
+	if err != nil {
+		return nil, err
+	}
+	if t == nil || t.RevokedAt != nil {
+		return nil, ErrInvalidToken
+	}
+
+	// Usage tracking is best effort
+	s.repo.TouchLastUsed(t.ID)
+
+	return t, nil
+}
+
+// RevokeToken revokes a token by ID or prefix. A prefix shared by several
+// tokens is rejected with ErrAmbiguousToken rather than guessing.
+func (s *Service) RevokeToken(idOrPrefix string) (*Token, error) {
+	tokens, err := s.repo.FindByIDOrPrefix(idOrPrefix)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("token not found: %s", idOrPrefix)
+	}
+	if len(tokens) > 1 {
+		return nil, fmt.Errorf("%w: %s matches %d tokens, revoke by ID instead", ErrAmbiguousToken, idOrPrefix, len(tokens))
+	}
+	t := tokens[0]
+
+	if err := s.repo.Revoke(t.ID); err != nil {
+		return nil, fmt.Errorf("failed to revoke token: %w", err)
+	}
+
+	return t, nil
+}
+
+// ListTokens lists tokens, optionally only those belonging to owner.
+func (s *Service) ListTokens(owner string) ([]*Token, error) {
+	return s.repo.List(owner)
+}
+
+func hashSecret(secret string) string {
+	h := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(h[:])
+}
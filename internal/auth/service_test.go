@@ -0,0 +1,108 @@
+package auth
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sanixdarker/skill-md/internal/storage"
+)
+
+func newTestService(t *testing.T) *Service {
+	t.Helper()
+	db, err := storage.NewDB(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("failed to open db: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := storage.Migrate(db); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	return NewService(NewRepository(db))
+}
+
+func TestService_CreateAndAuthenticate(t *testing.T) {
+	svc := newTestService(t)
+
+	token, secret, err := svc.CreateToken("alice", "ci", []string{ScopePublish})
+	if err != nil {
+		t.Fatalf("failed to create token: %v", err)
+	}
+	if !strings.HasPrefix(secret, "smd_") || !strings.HasPrefix(secret, token.Prefix) {
+		t.Errorf("unexpected secret %q for prefix %q", secret, token.Prefix)
+	}
+
+	got, err := svc.Authenticate(secret)
+	if err != nil {
+		t.Fatalf("failed to authenticate: %v", err)
+	}
+	if got.Owner != "alice" || !got.HasScope(ScopePublish) || got.HasScope(ScopeDelete) {
+		t.Errorf("unexpected token: %+v", got)
+	}
+
+	if _, err := svc.Authenticate("smd_" + strings.Repeat("0", 64)); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("expected ErrInvalidToken for unknown secret, got %v", err)
+	}
+}
+
+func TestService_CreateToken_Validation(t *testing.T) {
+	svc := newTestService(t)
+
+	if _, _, err := svc.CreateToken("", "", []string{ScopeRead}); err == nil {
+		t.Error("expected error for missing owner")
+	}
+	if _, _, err := svc.CreateToken("alice", "", []string{"superuser"}); err == nil {
+		t.Error("expected error for unknown scope")
+	}
+}
+
+func TestService_RevokeToken(t *testing.T) {
+	svc := newTestService(t)
+
+	token, secret, err := svc.CreateToken("alice", "", []string{ScopeAdmin})
+	if err != nil {
+		t.Fatalf("failed to create token: %v", err)
+	}
+	if _, err := svc.RevokeToken(token.Prefix); err != nil {
+		t.Fatalf("failed to revoke token: %v", err)
+	}
+	if _, err := svc.Authenticate(secret); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("expected revoked token to be rejected, got %v", err)
+	}
+}
+
+func TestService_RevokeToken_AmbiguousPrefix(t *testing.T) {
+	svc := newTestService(t)
+
+	first, _, err := svc.CreateToken("alice", "", []string{ScopePublish})
+	if err != nil {
+		t.Fatalf("failed to create token: %v", err)
+	}
+	// Prefixes are short enough to collide; simulate it directly
+	second := &Token{ID: "second", Owner: "bob", Name: "default", Prefix: first.Prefix, Scopes: []string{ScopePublish}, CreatedAt: first.CreatedAt}
+	if err := svc.repo.Create(second, "other-hash"); err != nil {
+		t.Fatalf("failed to create token: %v", err)
+	}
+
+	if _, err := svc.RevokeToken(first.Prefix); !errors.Is(err, ErrAmbiguousToken) {
+		t.Fatalf("expected ambiguous prefix error, got %v", err)
+	}
+	tokens, _ := svc.ListTokens("")
+	for _, tok := range tokens {
+		if tok.RevokedAt != nil {
+			t.Errorf("expected no token to be revoked, %s was", tok.ID)
+		}
+	}
+
+	if _, err := svc.RevokeToken(second.ID); err != nil {
+		t.Errorf("expected revoke by ID to succeed, got %v", err)
+	}
+}
+
+func TestToken_AdminGrantsAllScopes(t *testing.T) {
+	token := &Token{Scopes: []string{ScopeAdmin}}
+	if !token.IsAdmin() || !token.HasScope(ScopeDelete) {
+		t.Error("expected admin token to grant every scope")
+	}
+}
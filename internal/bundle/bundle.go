@@ -0,0 +1,489 @@
+// Package bundle lays a skill out as an Agent Skills folder: a short
+// SKILL.md with the frontmatter and overview, reference/ files for each
+// endpoint and schema, and examples/ with generated code samples. SKILL.md
+// links every other file, so agents can load the details on demand.
+package bundle
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/sanixdarker/skill-md/internal/converter/shared"
+	"github.com/sanixdarker/skill-md/pkg/skill"
+)
+
+// SkillFile is the name of the main file of a skill folder.
+const SkillFile = "SKILL.md"
+
+// referenceGroups are the top-level sections whose subsections move to
+// reference files.
+var referenceGroups = map[string]bool{
+	"endpoints": true, "operations": true, "methods": true, "resources": true,
+	"queries": true, "mutations": true, "subscriptions": true, "channels": true,
+	"services": true, "data models": true, "models": true, "schemas": true,
+	"types": true, "data types": true, "data structures": true,
+	"messages": true, "enums": true,
+}
+
+// exampleSections are top-level sections moved to examples/ as a whole.
+var exampleSections = map[string]bool{"code examples": true, "examples": true}
+
+// mainSections stay in SKILL.md; other top-level sections move to
+// reference/ as a whole.
+var mainSections = map[string]bool{
+	"quick start": true, "overview": true, "authentication": true,
+	"security": true, "tool definitions": true,
+}
+
+// exampleLanguages are the languages of the generated samples, in order.
+var exampleLanguages = []struct{ lang, title string }{
+	{"curl", "cURL"},
+	{"python", "Python"},
+	{"javascript", "JavaScript"},
+	{"go", "Go"},
+}
+
+var (
+	// linkLineRegex matches the list items SKILL.md links folder files with
+	linkLineRegex    = regexp.MustCompile(`^- \[([^\]]*)\]\(((?:reference|examples)/[^)\s]+)\)`)
+	labelRegex       = regexp.MustCompile(`^\*\*[^*]+\*\*:`)
+	exampleLinkRegex = regexp.MustCompile(`(?m)^\*\*Examples\*\*: \[[^\]]*\]\(\.\./examples/[^)]+\)\n*`)
+	detailsRegex     = regexp.MustCompile(`(?s)<details>.*?</details>\n*`)
+	codeLabelRegex   = regexp.MustCompile(`(?m)^\*\*Code Examples\*\*:[ \t]*\n*`)
+	operationRegex   = regexp.MustCompile(`^(?i:GET|POST|PUT|PATCH|DELETE|HEAD|OPTIONS) /`)
+)
+
+// Build lays s out as a skill folder. SKILL.md comes first and the other
+// files follow sorted by path.
+func Build(s *skill.Skill) []skill.File {
+	// Converters may keep subsections inside a section body; re-parse the
+	// rendered skill so every heading is its own node
+	normalized, err := skill.Parse(skill.Render(s))
+	if err != nil {
+		return []skill.File{{Path: SkillFile, Content: skill.Render(s)}}
+	}
+	normalized.Frontmatter = s.Frontmatter
+
+	b := &builder{files: make(map[string]string), used: make(map[string]bool)}
+	examples := b.toolExamples(s.Frontmatter)
+
+	roots := normalized.Tree()
+	top := &roots
+	if len(roots) == 1 && len(roots[0].Children) > 0 {
+		top = &roots[0].Children
+	}
+
+	var exampleNode *skill.ParsedSection
+	for _, node := range *top {
+		key := strings.ToLower(strings.TrimSpace(node.Title))
+		switch {
+		case exampleSections[key] && exampleNode == nil:
+			p := b.path("examples", node.Title)
+			b.files[p] = renderFile(node)
+			node.Content = link(node.Title, p, "")
+			node.Children = nil
+			exampleNode = node
+		case referenceGroups[key] || documentsOperations(node):
+			var links []string
+			for _, child := range node.Children {
+				p := b.path("reference", child.Title)
+				b.files[p] = b.referenceFile(child, s.Frontmatter.ToolDefinitions, examples)
+				links = append(links, link(child.Title, p, summary(child.Content)))
+			}
+			if len(links) > 0 {
+				node.Content = joinBlocks(node.Content, strings.Join(links, "\n"))
+				node.Children = nil
+			}
+		case !mainSections[key] && !exampleSections[key]:
+			p := b.path("reference", node.Title)
+			b.files[p] = renderFile(node)
+			node.Content = link(node.Title, p, summary(node.Content))
+			node.Children = nil
+		}
+	}
+
+	// Link the generated samples, in tool order
+	var links []string
+	for _, tool := range s.Frontmatter.ToolDefinitions {
+		if p, ok := examples[tool.Name]; ok {
+			links = append(links, link(tool.Name, p, fmt.Sprintf("`%s %s`", tool.Method, tool.Path)))
+		}
+	}
+	if len(links) > 0 {
+		if exampleNode == nil {
+			level := 2
+			if len(*top) > 0 {
+				level = (*top)[0].Level
+			}
+			exampleNode = &skill.ParsedSection{Title: "Examples", Level: level}
+			*top = append(*top, exampleNode)
+		}
+		exampleNode.Content = joinBlocks(exampleNode.Content, strings.Join(links, "\n"))
+	}
+
+	normalized.Sections = skill.FlattenTree(roots)
+	files := []skill.File{{Path: SkillFile, Content: skill.Render(normalized)}}
+
+	paths := make([]string, 0, len(b.files))
+	for p := range b.files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		files = append(files, skill.File{Path: p, Content: b.files[p]})
+	}
+	return files
+}
+
+// builder collects the files of a folder and hands out unique paths.
+type builder struct {
+	files map[string]string
+	used  map[string]bool
+}
+
+// path returns an unused path for a file named after title under dir.
+func (b *builder) path(dir, title string) string {
+	base := slug(title)
+	p := dir + "/" + base + ".md"
+	for n := 2; b.used[p]; n++ {
+		p = fmt.Sprintf("%s/%s-%d.md", dir, base, n)
+	}
+	b.used[p] = true
+	return p
+}
+
+// toolExamples writes a sample file for every HTTP tool and returns their
+// paths by tool name.
+func (b *builder) toolExamples(fm skill.Frontmatter) map[string]string {
+	examples := make(map[string]string)
+	for _, tool := range fm.ToolDefinitions {
+		if tool.Method == "" || tool.Path == "" {
+			continue
+		}
+		p := b.path("examples", tool.Name)
+		b.files[p] = exampleFile(fm, tool)
+		examples[tool.Name] = p
+	}
+	return examples
+}
+
+// referenceFile renders one reference section as a standalone file. The
+// inline code samples of an operation give way to a link to its generated
+// samples.
+func (b *builder) referenceFile(node *skill.ParsedSection, tools []skill.ToolDefinition, examples map[string]string) string {
+	for _, tool := range tools {
+		p, ok := examples[tool.Name]
+		if !ok || !strings.EqualFold(strings.TrimSpace(node.Title), tool.Method+" "+tool.Path) {
+			continue
+		}
+		content := detailsRegex.ReplaceAllStringFunc(node.Content, func(block string) string {
+			if strings.Contains(block, "```") {
+				return ""
+			}
+			return block
+		})
+		content = codeLabelRegex.ReplaceAllString(content, "")
+		node.Content = joinBlocks(strings.TrimSpace(content), fmt.Sprintf("**Examples**: [%s](../%s)", exampleTitles(), p))
+		break
+	}
+	return renderFile(node)
+}
+
+// exampleFile renders the generated samples for an HTTP tool.
+func exampleFile(fm skill.Frontmatter, tool skill.ToolDefinition) string {
+	baseURL := tool.BaseURL
+	if baseURL == "" {
+		baseURL = fm.BaseURL
+	}
+	if baseURL == "" {
+		baseURL = "https://api.example.com"
+	}
+	method := strings.ToUpper(tool.Method)
+	url := strings.TrimSuffix(baseURL, "/") + tool.Path
+
+	var headers map[string]string
+	if len(fm.AuthMethods) > 0 {
+		headers = map[string]string{"Authorization": "Bearer YOUR_TOKEN"}
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("# %s\n\n", tool.Name))
+	if tool.Description != "" {
+		b.WriteString(tool.Description + "\n\n")
+	}
+	b.WriteString(fmt.Sprintf("`%s %s`\n\n", method, url))
+	for _, l := range exampleLanguages {
+		b.WriteString(fmt.Sprintf("## %s\n\n", l.title))
+		b.WriteString(shared.GenerateCodeExample(shared.CodeExampleConfig{
+			Language: l.lang,
+			Method:   method,
+			URL:      url,
+			Headers:  headers,
+			Body:     requestBody(tool),
+		}))
+		b.WriteString("\n\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// requestBody builds a sample JSON body from the tool's body parameters.
+func requestBody(tool skill.ToolDefinition) string {
+	props, _ := tool.Parameters["properties"].(map[string]interface{})
+
+	body := make(map[string]interface{})
+	for name, schema := range props {
+		switch tool.Location[name] {
+		case "body":
+			// A single "body" argument holds the whole request body
+			if name == "body" {
+				if m, ok := schema.(map[string]interface{}); ok {
+					if v, ok := sampleValue(m).(map[string]interface{}); ok {
+						body = v
+						continue
+					}
+				}
+			}
+			fallthrough
+		case "form", "multipart":
+			if m, ok := schema.(map[string]interface{}); ok {
+				body[tool.ParamName(name)] = sampleValue(m)
+			}
+		}
+	}
+	if len(body) == 0 {
+		return ""
+	}
+
+	// encoding/json sorts map keys, so samples are stable
+	data, err := json.Marshal(body)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// sampleValue returns a placeholder value for a JSON Schema.
+func sampleValue(schema map[string]interface{}) interface{} {
+	if example, ok := schema["example"]; ok {
+		return example
+	}
+	typ, _ := schema["type"].(string)
+	switch typ {
+	case "string":
+		switch schema["format"] {
+		case "date-time":
+			return "2024-01-15T10:30:00Z"
+		case "email":
+			return "user@example.com"
+		case "uuid":
+			return "550e8400-e29b-41d4-a716-446655440000"
+		}
+		return "string"
+	case "integer", "number":
+		return 0
+	case "boolean":
+		return false
+	case "array":
+		return []interface{}{}
+	}
+
+	obj := make(map[string]interface{})
+	if props, ok := schema["properties"].(map[string]interface{}); ok {
+		for name, prop := range props {
+			if m, ok := prop.(map[string]interface{}); ok {
+				obj[name] = sampleValue(m)
+			}
+		}
+	}
+	return obj
+}
+
+// Assemble joins a skill folder back into a single skill, inlining the
+// files SKILL.md links in place of their links. Generated samples are left
+// out; they are derived from the tool definitions.
+func Assemble(files []skill.File) (*skill.Skill, error) {
+	byPath := make(map[string]string, len(files))
+	for _, f := range files {
+		if !ValidPath(f.Path) {
+			return nil, fmt.Errorf("invalid file path %q", f.Path)
+		}
+		byPath[f.Path] = f.Content
+	}
+	main, ok := byPath[SkillFile]
+	if !ok {
+		return nil, fmt.Errorf("skill folder has no %s", SkillFile)
+	}
+
+	s, err := skill.Parse(main)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", SkillFile, err)
+	}
+
+	generated := (&builder{files: make(map[string]string), used: make(map[string]bool)}).toolExamples(s.Frontmatter)
+	isGenerated := make(map[string]bool, len(generated))
+	for _, p := range generated {
+		isGenerated[p] = true
+	}
+
+	var sections []skill.Section
+	for i, sec := range s.Sections {
+		var kept []string
+		var inlined []skill.Section
+		for _, line := range strings.Split(sec.Content, "\n") {
+			m := linkLineRegex.FindStringSubmatch(line)
+			if m == nil {
+				kept = append(kept, line)
+				continue
+			}
+			if isGenerated[m[2]] {
+				continue
+			}
+			content, ok := byPath[m[2]]
+			if !ok {
+				kept = append(kept, line)
+				continue
+			}
+
+			linked, err := skill.Parse(exampleLinkRegex.ReplaceAllString(content, ""))
+			if err != nil || len(linked.Sections) == 0 {
+				kept = append(kept, line)
+				continue
+			}
+			shift := sec.Level + 1 - linked.Sections[0].Level
+			if strings.EqualFold(linked.Sections[0].Title, sec.Title) {
+				// A section moved out whole: its body replaces the link
+				kept = append(kept, linked.Sections[0].Content)
+				linked.Sections = linked.Sections[1:]
+				shift--
+			}
+			for _, sub := range linked.Sections {
+				sub.Level += shift
+				inlined = append(inlined, sub)
+			}
+		}
+		sec.Content = strings.TrimSpace(strings.Join(kept, "\n"))
+
+		// The section Build adds to link generated samples goes with them
+		hasChildren := i+1 < len(s.Sections) && s.Sections[i+1].Level > sec.Level
+		if sec.Title == "Examples" && sec.Content == "" && len(inlined) == 0 && !hasChildren {
+			continue
+		}
+		sections = append(sections, sec)
+		sections = append(sections, inlined...)
+	}
+
+	s.Sections = sections
+	s.Content = ""
+	s.Raw = ""
+	return s, nil
+}
+
+// ValidPath reports whether p is a clean relative slash-separated path that
+// stays inside the folder.
+func ValidPath(p string) bool {
+	if p == "" || strings.Contains(p, "\\") || strings.HasPrefix(p, "/") {
+		return false
+	}
+	if path.Clean(p) != p {
+		return false
+	}
+	return p != ".." && !strings.HasPrefix(p, "../")
+}
+
+// documentsOperations reports whether a section lists HTTP operations under
+// a heading other than the usual ones, e.g. an API Blueprint resource group.
+func documentsOperations(node *skill.ParsedSection) bool {
+	for _, child := range node.Children {
+		if operationRegex.MatchString(strings.TrimSpace(child.Title)) {
+			return true
+		}
+	}
+	return false
+}
+
+// renderFile renders a section and its subsections as a standalone file
+// whose top heading is level 1.
+func renderFile(node *skill.ParsedSection) string {
+	shift := node.Level - 1
+	var b strings.Builder
+	var walk func(n *skill.ParsedSection)
+	walk = func(n *skill.ParsedSection) {
+		b.WriteString(strings.Repeat("#", n.Level-shift) + " " + n.Title + "\n\n")
+		if n.Content != "" {
+			b.WriteString(n.Content + "\n\n")
+		}
+		for _, child := range n.Children {
+			walk(child)
+		}
+	}
+	walk(node)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// link renders a list item linking a folder file, with an optional summary.
+func link(title, p, summary string) string {
+	if summary == "" {
+		return fmt.Sprintf("- [%s](%s)", title, p)
+	}
+	return fmt.Sprintf("- [%s](%s): %s", title, p, summary)
+}
+
+// summary returns the first line of prose in content.
+func summary(content string) string {
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || labelRegex.MatchString(line) ||
+			strings.ContainsAny(line[:1], "`|<#->!*[") && !strings.HasPrefix(line, "**") {
+			continue
+		}
+		line = strings.Trim(line, "*")
+		if line == "" || strings.HasSuffix(line, ":") {
+			continue
+		}
+		return shared.Truncate(line, 100)
+	}
+	return ""
+}
+
+// exampleTitles lists the languages of the generated samples.
+func exampleTitles() string {
+	titles := make([]string, len(exampleLanguages))
+	for i, l := range exampleLanguages {
+		titles[i] = l.title
+	}
+	return strings.Join(titles, ", ")
+}
+
+// joinBlocks joins non-empty markdown blocks with a blank line.
+func joinBlocks(blocks ...string) string {
+	var kept []string
+	for _, block := range blocks {
+		if block = strings.TrimSpace(block); block != "" {
+			kept = append(kept, block)
+		}
+	}
+	return strings.Join(kept, "\n\n")
+}
+
+// slug turns a heading into a file name.
+func slug(title string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(title) {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	if s := strings.TrimSuffix(b.String(), "-"); s != "" {
+		return s
+	}
+	return "section"
+}
@@ -0,0 +1,322 @@
+package bundle
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sanixdarker/skill-md/pkg/skill"
+)
+
+const petsSkill = `---
+name: "Pets"
+version: "1.0.0"
+description: "Pet store API"
+auth_methods:
+  - "bearer"
+base_url: "https://api.example.com"
+tools:
+  - name: "get_pets"
+    description: "List pets"
+    method: "GET"
+    path: "/pets"
+  - name: "post_pets"
+    description: "Create a pet"
+    method: "POST"
+    path: "/pets"
+---
+
+## Overview
+
+Pets, in a store.
+
+## Endpoints
+
+### GET /pets
+
+**List pets**
+
+**Code Examples**:
+
+<details>
+<summary>cURL</summary>
+
+` + "```bash\ncurl https://api.example.com/pets\n```" + `
+
+</details>
+
+### POST /pets
+
+Creates a pet.
+
+#### 201 - Created
+
+The new pet.
+
+## Data Models
+
+### Pet
+
+**Type**: ` + "`object`" + `
+
+A pet.
+
+## Best Practices
+
+Be nice to pets.
+`
+
+func buildPets(t *testing.T) (*skill.Skill, map[string]string) {
+	t.Helper()
+	s, err := skill.Parse(petsSkill)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := make(map[string]string)
+	for _, f := range Build(s) {
+		files[f.Path] = f.Content
+	}
+	return s, files
+}
+
+func TestBuild_Layout(t *testing.T) {
+	_, files := buildPets(t)
+
+	for _, p := range []string{
+		SkillFile,
+		"reference/get-pets.md",
+		"reference/post-pets.md",
+		"reference/pet.md",
+		"reference/best-practices.md",
+		"examples/get-pets.md",
+		"examples/post-pets.md",
+	} {
+		if _, ok := files[p]; !ok {
+			t.Errorf("missing %s", p)
+		}
+	}
+	if len(files) != 7 {
+		t.Errorf("expected 7 files, got %d", len(files))
+	}
+
+	main := files[SkillFile]
+	for _, want := range []string{
+		"Pets, in a store.",
+		"- [GET /pets](reference/get-pets.md): List pets",
+		"- [POST /pets](reference/post-pets.md): Creates a pet.",
+		"- [Pet](reference/pet.md): A pet.",
+		"- [post_pets](examples/post-pets.md): `POST /pets`",
+	} {
+		if !strings.Contains(main, want) {
+			t.Errorf("SKILL.md is missing %q:\n%s", want, main)
+		}
+	}
+	if strings.Contains(main, "201 - Created") || strings.Contains(main, "## Best Practices\n\nBe nice") {
+		t.Errorf("SKILL.md should only link the details:\n%s", main)
+	}
+
+	ref := files["reference/post-pets.md"]
+	if !strings.HasPrefix(ref, "# POST /pets\n") || !strings.Contains(ref, "\n## 201 - Created\n") {
+		t.Errorf("reference headings should start at level 1:\n%s", ref)
+	}
+	if get := files["reference/get-pets.md"]; strings.Contains(get, "<details>") ||
+		!strings.Contains(get, "**Examples**: [cURL, Python, JavaScript, Go](../examples/get-pets.md)") {
+		t.Errorf("inline samples should give way to a link:\n%s", get)
+	}
+
+	example := files["examples/post-pets.md"]
+	for _, want := range []string{"# post_pets", "`POST https://api.example.com/pets`", "```bash", "```python", "```javascript", "```go", "Authorization"} {
+		if !strings.Contains(example, want) {
+			t.Errorf("example is missing %q:\n%s", want, example)
+		}
+	}
+}
+
+func TestRequestBody(t *testing.T) {
+	tool := skill.ToolDefinition{
+		Method:   "POST",
+		Path:     "/pets",
+		Location: map[string]string{"body": "body", "tag": "query"},
+		Parameters: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"tag": map[string]interface{}{"type": "string"},
+				"body": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"name":  map[string]interface{}{"type": "string"},
+						"age":   map[string]interface{}{"type": "integer"},
+						"email": map[string]interface{}{"type": "string", "format": "email"},
+					},
+				},
+			},
+		},
+	}
+	if got, want := requestBody(tool), `{"age":0,"email":"user@example.com","name":"string"}`; got != want {
+		t.Errorf("requestBody = %s, want %s", got, want)
+	}
+
+	tool.Location = map[string]string{"name": "form", "tag": "query"}
+	tool.Parameters["properties"] = map[string]interface{}{
+		"name": map[string]interface{}{"type": "string", "example": "Rex"},
+		"tag":  map[string]interface{}{"type": "string"},
+	}
+	if got, want := requestBody(tool), `{"name":"Rex"}`; got != want {
+		t.Errorf("requestBody = %s, want %s", got, want)
+	}
+}
+
+func TestBuild_Stable(t *testing.T) {
+	s, _ := skill.Parse(petsSkill)
+	first := Build(s)
+	second := Build(s)
+	if len(first) != len(second) {
+		t.Fatalf("file count changed: %d, %d", len(first), len(second))
+	}
+	for i := range first {
+		if first[i] != second[i] {
+			t.Errorf("%s differs between builds", first[i].Path)
+		}
+	}
+	if first[0].Path != SkillFile {
+		t.Errorf("expected SKILL.md first, got %s", first[0].Path)
+	}
+}
+
+func TestAssemble_RoundTrip(t *testing.T) {
+	s, _ := skill.Parse(petsSkill)
+	files := Build(s)
+
+	assembled, err := Assemble(files)
+	if err != nil {
+		t.Fatalf("Assemble failed: %v", err)
+	}
+
+	var titles []string
+	for _, sec := range assembled.Sections {
+		titles = append(titles, strings.Repeat("#", sec.Level)+" "+sec.Title)
+	}
+	want := []string{
+		"## Overview", "## Endpoints", "### GET /pets", "### POST /pets", "#### 201 - Created",
+		"## Data Models", "### Pet", "## Best Practices",
+	}
+	if strings.Join(titles, "|") != strings.Join(want, "|") {
+		t.Errorf("unexpected sections:\n got %v\nwant %v", titles, want)
+	}
+	rendered := skill.Render(assembled)
+	if strings.Contains(rendered, "](reference/") || strings.Contains(rendered, "../examples/") {
+		t.Errorf("links should be replaced by the linked files:\n%s", rendered)
+	}
+	if !strings.Contains(rendered, "Be nice to pets.") {
+		t.Errorf("moved section body was lost:\n%s", rendered)
+	}
+
+	// Laying the assembled skill out again gives the same folder
+	again := Build(assembled)
+	if len(again) != len(files) {
+		t.Fatalf("expected %d files, got %d", len(files), len(again))
+	}
+	for i := range files {
+		if again[i] != files[i] {
+			t.Errorf("%s changed after a round trip:\n%s\n---\n%s", files[i].Path, files[i].Content, again[i].Content)
+		}
+	}
+}
+
+func TestAssemble_KeepsExtraFiles(t *testing.T) {
+	files := []skill.File{
+		{Path: SkillFile, Content: "---\nname: \"Notes\"\nversion: \"1.0.0\"\n---\n\n## Guides\n\n- [Setup](reference/setup.md)\n- [Missing](reference/missing.md)\n"},
+		{Path: "reference/setup.md", Content: "# Setup\n\nRun the installer."},
+		{Path: "scripts/run.sh", Content: "#!/bin/sh\n"},
+	}
+	s, err := Assemble(files)
+	if err != nil {
+		t.Fatalf("Assemble failed: %v", err)
+	}
+	rendered := skill.Render(s)
+	if !strings.Contains(rendered, "### Setup\n\nRun the installer.") {
+		t.Errorf("linked file should be inlined under its link:\n%s", rendered)
+	}
+	if !strings.Contains(rendered, "- [Missing](reference/missing.md)") {
+		t.Errorf("links to absent files should be kept:\n%s", rendered)
+	}
+}
+
+func TestAssemble_Errors(t *testing.T) {
+	if _, err := Assemble([]skill.File{{Path: "reference/a.md", Content: "# A"}}); err == nil {
+		t.Error("expected an error without SKILL.md")
+	}
+	if _, err := Assemble([]skill.File{{Path: SkillFile}, {Path: "../etc/passwd"}}); err == nil {
+		t.Error("expected an error for a path leaving the folder")
+	}
+}
+
+func TestValidPath(t *testing.T) {
+	for p, want := range map[string]bool{
+		"SKILL.md":           true,
+		"reference/a.md":     true,
+		"":                   false,
+		"/etc/passwd":        false,
+		"../a.md":            false,
+		"reference/../../a":  false,
+		"reference//a.md":    false,
+		"reference\\a.md":    false,
+		"./SKILL.md":         false,
+		"scripts/run.sh":     true,
+		"reference/a/b/c.md": true,
+	} {
+		if got := ValidPath(p); got != want {
+			t.Errorf("ValidPath(%q) = %v, want %v", p, got, want)
+		}
+	}
+}
+
+func TestWriteSkill(t *testing.T) {
+	dir := t.TempDir()
+	s, _ := skill.Parse(petsSkill)
+
+	changed, err := WriteSkill(dir, s)
+	if err != nil || !changed {
+		t.Fatalf("first write: changed=%v err=%v", changed, err)
+	}
+	files, err := ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 7 || files[0].Path != SkillFile {
+		t.Fatalf("unexpected folder: %+v", files)
+	}
+
+	changed, err = WriteSkill(dir, s)
+	if err != nil || changed {
+		t.Errorf("unchanged write: changed=%v err=%v", changed, err)
+	}
+
+	// Dropping an endpoint removes its files
+	s.Frontmatter.ToolDefinitions = s.Frontmatter.ToolDefinitions[:1]
+	if _, err := WriteSkill(dir, s); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "examples", "post-pets.md")); !os.IsNotExist(err) {
+		t.Errorf("stale example should be removed, got %v", err)
+	}
+}
+
+func TestWriteZip(t *testing.T) {
+	s, _ := skill.Parse(petsSkill)
+	var buf bytes.Buffer
+	if err := WriteZip(&buf, "pets", Build(s)); err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(zr.File) != 7 || zr.File[0].Name != "pets/SKILL.md" {
+		t.Errorf("unexpected archive: %d files, first %q", len(zr.File), zr.File[0].Name)
+	}
+}
@@ -0,0 +1,168 @@
+package bundle
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/sanixdarker/skill-md/pkg/skill"
+)
+
+// Folder limits when reading a skill folder from disk
+const (
+	maxFiles    = 500
+	maxFileSize = 1 << 20 // 1MB
+)
+
+// generatedDirs are the directories Build fills; WriteFiles removes files
+// there that the folder no longer has.
+var generatedDirs = []string{"reference", "examples"}
+
+// ReadDir reads a skill folder from disk. Hidden files and directories are
+// skipped.
+func ReadDir(dir string) ([]skill.File, error) {
+	var files []skill.File
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p != dir && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		if info.Size() > maxFileSize {
+			return fmt.Errorf("%s is larger than 1MB", rel)
+		}
+		if len(files) == maxFiles {
+			return fmt.Errorf("skill folder has more than %d files", maxFiles)
+		}
+
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		files = append(files, skill.File{Path: filepath.ToSlash(rel), Content: string(data)})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sortFiles(files)
+	return files, nil
+}
+
+// IsDir reports whether path is a skill folder, i.e. a directory holding a
+// SKILL.md.
+func IsDir(path string) bool {
+	info, err := os.Stat(filepath.Join(path, SkillFile))
+	return err == nil && info.Mode().IsRegular()
+}
+
+// WriteSkill lays sk out as a folder in dir. As with a single SKILL.md, the
+// created_at of the skill already in dir is carried over unless sk has none.
+// It reports whether any file was written or removed.
+func WriteSkill(dir string, sk *skill.Skill) (bool, error) {
+	if existing, err := os.ReadFile(filepath.Join(dir, SkillFile)); err == nil && sk.Frontmatter.CreatedAt != "" {
+		if prev, err := skill.Parse(string(existing)); err == nil && prev.Frontmatter.CreatedAt != "" {
+			copied := *sk
+			copied.Frontmatter.CreatedAt = prev.Frontmatter.CreatedAt
+			sk = &copied
+		}
+	}
+	return WriteFiles(dir, Build(sk))
+}
+
+// WriteFiles writes a skill folder to dir, leaving files that already hold
+// the right content untouched. Stale files under reference/ and examples/
+// are removed. It reports whether anything changed.
+func WriteFiles(dir string, files []skill.File) (bool, error) {
+	changed := false
+	keep := make(map[string]bool, len(files))
+	for _, f := range files {
+		if !ValidPath(f.Path) {
+			return changed, fmt.Errorf("invalid file path %q", f.Path)
+		}
+		keep[f.Path] = true
+
+		p := filepath.Join(dir, filepath.FromSlash(f.Path))
+		if existing, err := os.ReadFile(p); err == nil && bytes.Equal(existing, []byte(f.Content)) {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			return changed, err
+		}
+		if err := os.WriteFile(p, []byte(f.Content), 0644); err != nil {
+			return changed, err
+		}
+		changed = true
+	}
+
+	for _, sub := range generatedDirs {
+		entries, err := os.ReadDir(filepath.Join(dir, sub))
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			if e.Type().IsRegular() && strings.HasSuffix(e.Name(), ".md") && !keep[sub+"/"+e.Name()] {
+				if err := os.Remove(filepath.Join(dir, sub, e.Name())); err != nil {
+					return changed, err
+				}
+				changed = true
+			}
+		}
+	}
+	return changed, nil
+}
+
+// WriteZip writes a skill folder as a zip archive with every file under
+// root/.
+func WriteZip(w io.Writer, root string, files []skill.File) error {
+	zw := zip.NewWriter(w)
+	for _, f := range files {
+		if !ValidPath(f.Path) {
+			return fmt.Errorf("invalid file path %q", f.Path)
+		}
+		fw, err := zw.CreateHeader(&zip.FileHeader{
+			Name:   root + "/" + f.Path,
+			Method: zip.Deflate,
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(fw, f.Content); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// sortFiles puts SKILL.md first and the other files in path order.
+func sortFiles(files []skill.File) {
+	sort.SliceStable(files, func(i, j int) bool {
+		if (files[i].Path == SkillFile) != (files[j].Path == SkillFile) {
+			return files[i].Path == SkillFile
+		}
+		return files[i].Path < files[j].Path
+	})
+}
@@ -0,0 +1,149 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/sanixdarker/skill-md/internal/converter"
+	"github.com/sanixdarker/skill-md/internal/diff"
+	"github.com/sanixdarker/skill-md/pkg/skill"
+	"github.com/spf13/cobra"
+)
+
+var (
+	breakingFormat     string
+	breakingSpecFormat string
+)
+
+var breakingCmd = &cobra.Command{
+	Use:   "breaking [old] [new]",
+	Short: "Detect breaking changes between two versions of a spec",
+	Long: `Convert two versions of an API spec and classify what changed in the
+skill agents see as breaking or non-breaking.
+
+Breaking changes:
+  - Removed endpoints, operations, RPCs and tools
+  - Tools whose method, path or base URL changed
+  - Removed parameters and fields
+  - Parameter and field type changes
+  - New required parameters and fields, or existing ones made required
+  - Enum values removed from a parameter
+  - A changed base URL, removed servers or new auth methods
+
+Added tools, optional parameters and fields, and documentation changes are
+non-breaking. The report ends with the next version for the old spec's
+version: a major bump for breaking changes, minor for additions and patch
+for anything else.
+
+The command exits non-zero when it finds breaking changes, so CI can block
+them.
+
+Examples:
+  skillmd breaking old/openapi.yaml openapi.yaml
+  skillmd breaking v1/schema.graphql v2/schema.graphql
+  skillmd breaking service-v1.proto service.proto --format json`,
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if breakingFormat != "text" && breakingFormat != "json" {
+			return fmt.Errorf("unknown format %q (use text or json)", breakingFormat)
+		}
+
+		manager := converter.NewManager()
+		// Both versions are named after the new file, so skills whose name
+		// comes from the file name compare equal
+		oldSkill, err := convertSpec(manager, args[0], args[1])
+		if err != nil {
+			return err
+		}
+		newSkill, err := convertSpec(manager, args[1], args[1])
+		if err != nil {
+			return err
+		}
+
+		report := diff.Classify(diff.Compare(oldSkill, newSkill))
+		current := oldSkill.Frontmatter.Version
+		// A version that is not semver only gets the bump suggested
+		next, _ := diff.NextVersion(current, report.Bump)
+
+		if breakingFormat == "json" {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(map[string]interface{}{
+				"breaking":          report.Breaking,
+				"non_breaking":      report.NonBreaking,
+				"sections_changed":  report.Sections,
+				"bump":              report.Bump,
+				"current_version":   current,
+				"suggested_version": next,
+			}); err != nil {
+				return err
+			}
+		} else {
+			printBreakingReport(report, current, next)
+		}
+
+		if len(report.Breaking) > 0 {
+			return fmt.Errorf("found %d breaking changes", len(report.Breaking))
+		}
+		return nil
+	},
+}
+
+// convertSpec converts a spec file to a skill, detecting its format unless
+// --spec-format is set.
+func convertSpec(manager *converter.Manager, path, sourcePath string) (*skill.Skill, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	format := breakingSpecFormat
+	if format == "" {
+		format = manager.DetectFormat(path, content)
+	}
+	s, err := manager.Convert(format, content, &converter.Options{SourcePath: sourcePath, OmitCreatedAt: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert %s: %w", path, err)
+	}
+	// Converters write subsections into their parent's content; parsing the
+	// rendered skill splits them out, as in a SKILL.md file
+	return skill.Parse(skill.Render(s))
+}
+
+func printBreakingReport(report *diff.Report, current, next string) {
+	if len(report.Breaking) > 0 {
+		fmt.Printf("Breaking changes (%d):\n", len(report.Breaking))
+		for _, c := range report.Breaking {
+			fmt.Printf("  %s\n", c)
+		}
+		fmt.Println()
+	}
+	if len(report.NonBreaking) > 0 {
+		fmt.Printf("Non-breaking changes (%d):\n", len(report.NonBreaking))
+		for _, c := range report.NonBreaking {
+			fmt.Printf("  %s\n", c)
+		}
+		fmt.Println()
+	}
+	if report.Sections > 0 {
+		fmt.Printf("Documentation sections changed: %d\n\n", report.Sections)
+	}
+
+	switch {
+	case report.Bump == "":
+		fmt.Println("No changes")
+	case next == "":
+		fmt.Printf("Suggested bump: %s (version %q is not semver)\n", report.Bump, current)
+	default:
+		fmt.Printf("Suggested version: %s -> %s (%s)\n", current, next, report.Bump)
+	}
+}
+
+func init() {
+	breakingCmd.Flags().StringVarP(&breakingFormat, "format", "f", "text", "Output format (text, json)")
+	breakingCmd.Flags().StringVar(&breakingSpecFormat, "spec-format", "", "Spec format (auto-detect if not specified)")
+
+	rootCmd.AddCommand(breakingCmd)
+}
@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sanixdarker/skill-md/pkg/skill"
+	"gopkg.in/yaml.v2"
+)
+
+// projectConfigFile is the name of the per-project settings file.
+const projectConfigFile = ".skillmd.yaml"
+
+// projectConfig holds the settings read from .skillmd.yaml.
+type projectConfig struct {
+	Lint skill.LintConfig `yaml:"lint"`
+}
+
+// loadProjectConfig reads the config file at path or, when path is empty,
+// the nearest .skillmd.yaml in dir or its parents. A missing file yields an
+// empty config.
+func loadProjectConfig(path, dir string) (*projectConfig, error) {
+	if path == "" {
+		path = findProjectConfig(dir)
+		if path == "" {
+			return &projectConfig{}, nil
+		}
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+	cfg := &projectConfig{}
+	if err := yaml.Unmarshal(content, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// findProjectConfig returns the path of the nearest .skillmd.yaml at or
+// above dir, or "" when there is none.
+func findProjectConfig(dir string) string {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	for {
+		path := filepath.Join(dir, projectConfigFile)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return ""
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
@@ -3,6 +3,7 @@ package cli
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/sanixdarker/skill-md/internal/converter"
@@ -11,10 +12,15 @@ import (
 )
 
 var (
-	convertFormat string
-	convertOutput string
-	convertName   string
-	convertURL    string
+	convertFormat  string
+	convertOutput  string
+	convertName    string
+	convertURL     string
+	convertWorkers int
+	convertWatch   bool
+	convertTokens  int
+	convertLayout  string
+	convertNoDate  bool
 )
 
 var convertCmd = &cobra.Command{
@@ -31,10 +37,40 @@ Supported formats:
   - raml:         RAML 1.0 specifications
   - wsdl:         WSDL/SOAP web service definitions
   - apiblueprint: API Blueprint Markdown specifications
+  - openai:       OpenAI function-calling tool definitions (JSON)
+  - agents:       AGENTS.md agent instructions
+  - claude:       CLAUDE.md project memory
+  - cursor:       Cursor rules (.cursorrules, .mdc)
+  - llms:         llms.txt and llms-full.txt
   - pdf:          PDF documents
   - url:          Web pages and documentation URLs
   - text:         Plain text descriptions
 
+When given a directory, every file under it is converted in parallel and
+written to the --output directory, mirroring the input tree. Files are
+auto-detected unless --format is set, and files that are not recognised
+as a spec are skipped. The command fails if any file could not be
+converted.
+
+With --max-tokens the skill is trimmed to an estimated token budget:
+best-practices boilerplate goes first, then code examples repeated in
+several languages, then long schema dumps. The budget is recorded as
+max_tokens_per_call, and token counts per section are printed to stderr.
+
+With --layout folder the skill is written as an Agent Skills folder in the
+--output directory: a short SKILL.md linking reference/ files for each
+endpoint and schema, and examples/ with code samples for each operation.
+Agents read SKILL.md first and load the rest on demand. When converting a
+directory, each spec gets its own folder.
+
+Output is deterministic: converting the same input twice gives the same
+bytes apart from the created_at timestamp, which --no-created-at leaves
+out.
+
+With --watch the command keeps running and converts again whenever the
+input changes, rewriting the output only when the skill actually changed.
+Conversion errors are reported without stopping the watch.
+
 Examples:
   skillmd convert api.yaml
   skillmd convert schema.graphql -f graphql
@@ -44,9 +80,27 @@ Examples:
   skillmd convert api.raml -f raml
   skillmd convert service.wsdl -f wsdl
   skillmd convert api.apib -f apiblueprint
-  skillmd convert --url https://docs.example.com/api`,
+  skillmd convert AGENTS.md
+  skillmd convert tools.json -f openai
+  skillmd convert --url https://docs.example.com/api
+  skillmd convert ./specs --output ./skills
+  skillmd convert openapi.yaml -o SKILL.md --watch
+  skillmd convert openapi.yaml -o SKILL.md --max-tokens 8000
+  skillmd convert openapi.yaml -o ./petstore --layout folder`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkLayout(convertLayout, convertOutput); err != nil {
+			return err
+		}
+		if convertWatch {
+			return runConvertWatch(args)
+		}
+		if convertURL == "" && len(args) > 0 {
+			if info, err := os.Stat(args[0]); err == nil && info.IsDir() {
+				return convertDir(args[0])
+			}
+		}
+
 		var content []byte
 		var sourcePath string
 		var format string
@@ -93,13 +147,28 @@ Examples:
 
 		// Convert
 		result, err := manager.Convert(format, content, &converter.Options{
-			Name:       convertName,
-			SourcePath: sourcePath,
+			Name:          convertName,
+			SourcePath:    sourcePath,
+			OmitCreatedAt: convertNoDate,
 		})
 		if err != nil {
 			return fmt.Errorf("conversion failed: %w", err)
 		}
 
+		if convertTokens > 0 {
+			var steps []string
+			result, steps = converter.ApplyTokenBudget(result, convertTokens)
+			printTokenReport(os.Stderr, result, convertTokens, steps)
+		}
+
+		if convertLayout == layoutFolder {
+			if err := writeSkillFolder(convertOutput, result); err != nil {
+				return err
+			}
+			fmt.Printf("Skill folder written to %s\n", convertOutput)
+			return nil
+		}
+
 		// Render output
 		output := skill.Render(result)
 
@@ -117,11 +186,60 @@ Examples:
 	},
 }
 
+// convertDir converts every spec under inDir into the --output directory
+// and prints a per-file summary.
+func convertDir(inDir string) error {
+	if convertOutput == "" {
+		return fmt.Errorf("please provide an output directory (--output) when converting a directory")
+	}
+	if convertName != "" {
+		return fmt.Errorf("--name cannot be used when converting a directory")
+	}
+
+	manager := converter.NewManager()
+	results, err := manager.ConvertDir(inDir, convertOutput, &converter.BatchOptions{
+		Format:        convertFormat,
+		Workers:       convertWorkers,
+		MaxTokens:     convertTokens,
+		Folder:        convertLayout == layoutFolder,
+		OmitCreatedAt: convertNoDate,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to scan %s: %w", inDir, err)
+	}
+
+	var converted, skipped, failed int
+	for _, r := range results {
+		switch {
+		case r.Err != nil:
+			failed++
+			fmt.Printf("  FAIL %s: %v\n", r.Source, r.Err)
+		case r.Skipped:
+			skipped++
+		default:
+			converted++
+			fmt.Printf("  ok   %s -> %s (%s)\n", r.Source, filepath.Join(convertOutput, r.Output), r.Format)
+		}
+	}
+
+	fmt.Printf("\nConverted %d, skipped %d, failed %d\n", converted, skipped, failed)
+	if failed > 0 {
+		return fmt.Errorf("%d of %d files failed to convert", failed, converted+failed)
+	}
+	return nil
+}
+
 func init() {
-	convertCmd.Flags().StringVarP(&convertFormat, "format", "f", "", "Input format (openapi, graphql, postman, asyncapi, proto, raml, wsdl, apiblueprint, pdf, url, text)")
-	convertCmd.Flags().StringVarP(&convertOutput, "output", "o", "", "Output file path")
+	convertCmd.Flags().StringVarP(&convertFormat, "format", "f", "", "Input format (openapi, graphql, postman, asyncapi, proto, raml, wsdl, apiblueprint, openai, agents, claude, cursor, llms, pdf, url, text)")
+	convertCmd.Flags().StringVarP(&convertOutput, "output", "o", "", "Output file path (output directory when converting a directory)")
 	convertCmd.Flags().StringVarP(&convertName, "name", "n", "", "Name for the skill")
 	convertCmd.Flags().StringVarP(&convertURL, "url", "u", "", "URL to fetch and convert")
+	convertCmd.Flags().IntVarP(&convertWorkers, "workers", "j", 0, "Files converted in parallel when converting a directory (default number of CPUs)")
+	convertCmd.Flags().StringVar(&convertOutput, "out", "", "Alias for --output")
+	convertCmd.Flags().BoolVarP(&convertWatch, "watch", "w", false, "Keep running and convert again when the input changes")
+	convertCmd.Flags().IntVar(&convertTokens, "max-tokens", 0, "Trim low-value sections until the skill fits this estimated token budget")
+	convertCmd.Flags().StringVar(&convertLayout, "layout", layoutFile, "Output layout (file, folder)")
+	convertCmd.Flags().BoolVar(&convertNoDate, "no-created-at", false, "Leave out created_at so the same input always gives the same output")
 
 	rootCmd.AddCommand(convertCmd)
 }
@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/sanixdarker/skill-md/internal/bundle"
+	"github.com/sanixdarker/skill-md/internal/converter"
+	"github.com/sanixdarker/skill-md/internal/watch"
+)
+
+// runConvertWatch converts the input once and then again after every change,
+// until interrupted. Conversion errors are reported and watching continues.
+func runConvertWatch(args []string) error {
+	if convertURL != "" || len(args) == 0 {
+		return fmt.Errorf("--watch needs a file or directory to watch")
+	}
+	input := args[0]
+	if strings.HasPrefix(input, "http://") || strings.HasPrefix(input, "https://") {
+		return fmt.Errorf("--watch cannot be used with URLs")
+	}
+	if convertOutput == "" {
+		return fmt.Errorf("please provide an output path (--output) when watching")
+	}
+
+	info, err := os.Stat(input)
+	if err != nil {
+		return fmt.Errorf("failed to read input: %w", err)
+	}
+
+	manager := converter.NewManager()
+	run := func() { watchConvertFile(manager, input) }
+	if info.IsDir() {
+		if convertName != "" {
+			return fmt.Errorf("--name cannot be used when converting a directory")
+		}
+		run = func() { watchConvertDir(manager, input) }
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	run()
+	fmt.Printf("Watching %s for changes (Ctrl+C to stop)\n", input)
+
+	err = watch.Watch(ctx, []string{input}, &watch.Options{Ignore: []string{convertOutput}}, func(changed []string) {
+		run()
+	})
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+	return err
+}
+
+func watchConvertFile(manager *converter.Manager, input string) {
+	content, err := os.ReadFile(input)
+	if err != nil {
+		watchLog("error: %v", err)
+		return
+	}
+
+	format := convertFormat
+	if format == "" {
+		format = manager.DetectFormat(input, content)
+	}
+
+	result, err := manager.Convert(format, content, &converter.Options{
+		Name:          convertName,
+		SourcePath:    input,
+		OmitCreatedAt: convertNoDate,
+	})
+	if err != nil {
+		watchLog("error: %s: %v", input, err)
+		return
+	}
+
+	result, _ = converter.ApplyTokenBudget(result, convertTokens)
+	var written bool
+	if convertLayout == layoutFolder {
+		written, err = bundle.WriteSkill(convertOutput, result)
+	} else {
+		written, err = converter.WriteSkill(convertOutput, result)
+	}
+	switch {
+	case err != nil:
+		watchLog("error: failed to write %s: %v", convertOutput, err)
+	case written:
+		watchLog("updated %s", convertOutput)
+	default:
+		watchLog("%s is up to date", convertOutput)
+	}
+}
+
+func watchConvertDir(manager *converter.Manager, input string) {
+	results, err := manager.ConvertDir(input, convertOutput, &converter.BatchOptions{
+		Format:        convertFormat,
+		Workers:       convertWorkers,
+		MaxTokens:     convertTokens,
+		Folder:        convertLayout == layoutFolder,
+		OmitCreatedAt: convertNoDate,
+	})
+	if err != nil {
+		watchLog("error: failed to scan %s: %v", input, err)
+		return
+	}
+
+	var updated, failed int
+	for _, r := range results {
+		switch {
+		case r.Err != nil:
+			failed++
+			watchLog("error: %s: %v", r.Source, r.Err)
+		case r.Skipped || r.Unchanged:
+		default:
+			updated++
+			watchLog("updated %s", filepath.Join(convertOutput, r.Output))
+		}
+	}
+	if updated == 0 && failed == 0 {
+		watchLog("%s is up to date", convertOutput)
+	}
+}
+
+func watchLog(format string, args ...interface{}) {
+	fmt.Printf("[%s] %s\n", time.Now().Format("15:04:05"), fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,21 @@
+package cli
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/sanixdarker/skill-md/internal/storage"
+)
+
+// openDB opens the registry database at path and applies pending migrations.
+func openDB(path string) (*sql.DB, error) {
+	db, err := storage.NewDB(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := storage.Migrate(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to run migrations: %w", err)
+	}
+	return db, nil
+}
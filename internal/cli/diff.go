@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/sanixdarker/skill-md/internal/diff"
+	"github.com/spf13/cobra"
+)
+
+var diffFormat string
+
+var diffCmd = &cobra.Command{
+	Use:   "diff [old] [new]",
+	Short: "Show the semantic changes between two skills",
+	Long: `Compare two SKILL.md files or skill folders and list what changed:
+frontmatter fields, added and removed tools, tool parameters whose type or
+requiredness changed, and sections added, removed or modified. Formatting
+and line noise that does not change the skill is ignored.
+
+Each change is printed on one line, prefixed with + (added), - (removed)
+or ~ (changed). Use --format json for machine-readable output.
+
+Examples:
+  skillmd diff old/SKILL.md SKILL.md
+  skillmd diff ./petstore-v1 ./petstore-v2
+  skillmd diff a.md b.md --format json`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if diffFormat != "text" && diffFormat != "json" {
+			return fmt.Errorf("unknown format %q (use text or json)", diffFormat)
+		}
+
+		oldSkill, err := readSkill(args[0])
+		if err != nil {
+			return err
+		}
+		newSkill, err := readSkill(args[1])
+		if err != nil {
+			return err
+		}
+
+		changes := diff.Compare(oldSkill, newSkill)
+		return printChanges(cmd, changes, diffFormat, "Skills are equivalent")
+	},
+}
+
+// printChanges writes semantic changes as text or JSON, or the given note on
+// stderr when there are none in text mode.
+func printChanges(cmd *cobra.Command, changes []diff.Change, format, same string) error {
+	if format == "json" {
+		if changes == nil {
+			changes = []diff.Change{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(changes)
+	}
+
+	if len(changes) == 0 {
+		fmt.Fprintln(cmd.ErrOrStderr(), same)
+		return nil
+	}
+	fmt.Print(diff.Text(changes))
+	return nil
+}
+
+func init() {
+	diffCmd.Flags().StringVarP(&diffFormat, "format", "f", "text", "Output format (text, json)")
+
+	rootCmd.AddCommand(diffCmd)
+}
@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sanixdarker/skill-md/pkg/skill"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportTo     string
+	exportOutput string
+	exportGlobs  []string
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export [file]",
+	Short: "Export a SKILL.md to another agent-instruction format",
+	Long: `Export a SKILL.md file or skill folder to another format.
+
+Supported formats (--to):
+  skill      SKILL.md
+  agents     AGENTS.md, plain markdown without frontmatter
+  cursor     Cursor rules file (.mdc), scoped with --globs
+  llms       llms.txt index
+  llms-full  llms-full.txt with the whole skill
+  openai     OpenAI function-calling tools JSON built from the tool definitions
+  json       The skill structure as JSON
+
+Examples:
+  skillmd export SKILL.md --to agents -o AGENTS.md
+  skillmd export SKILL.md --to cursor --globs "src/**/*.ts" -o .cursor/rules/api.mdc
+  skillmd export SKILL.md --to llms -o llms.txt
+  skillmd export ./petstore --to openai > tools.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		manager := skill.NewExportManager()
+		if _, ok := manager.Lookup(exportTo); !ok {
+			return fmt.Errorf("unknown export format %q (use %s)", exportTo, strings.Join(manager.SupportedFormats(), ", "))
+		}
+
+		s, err := readSkill(args[0])
+		if err != nil {
+			return err
+		}
+
+		out, err := manager.Export(exportTo, s, &skill.ExportOptions{Globs: exportGlobs})
+		if err != nil {
+			return fmt.Errorf("export failed: %w", err)
+		}
+
+		if exportOutput == "" {
+			fmt.Print(string(out))
+			return nil
+		}
+		if err := os.MkdirAll(filepath.Dir(exportOutput), 0755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+		if err := os.WriteFile(exportOutput, out, 0644); err != nil {
+			return fmt.Errorf("failed to write output file: %w", err)
+		}
+		fmt.Printf("%s written to %s\n", exportTo, exportOutput)
+		return nil
+	},
+}
+
+func init() {
+	exportCmd.Flags().StringVarP(&exportTo, "to", "t", "", "Output format (skill, agents, cursor, llms, llms-full, openai, json)")
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Output file path (default stdout)")
+	exportCmd.Flags().StringSliceVar(&exportGlobs, "globs", nil, "File globs a Cursor rule applies to")
+	exportCmd.MarkFlagRequired("to")
+
+	rootCmd.AddCommand(exportCmd)
+}
@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/sanixdarker/skill-md/pkg/skill"
+	"github.com/spf13/cobra"
+)
+
+var fmtCheck bool
+
+var fmtCmd = &cobra.Command{
+	Use:   "fmt [files...]",
+	Short: "Format SKILL.md files",
+	Long: `Rewrite SKILL.md files in canonical form, in place:
+
+  - Frontmatter keys in a fixed order with quoted strings; keys skillmd
+    does not know are kept, in alphabetical order after the others
+  - One blank line around headings and between blocks
+  - Backtick code fences with the language right after them
+  - "-" bullet list markers
+
+Text before the first heading is kept. Files whose skill would change, for
+example because of invalid frontmatter, are left alone and reported.
+
+--check rewrites nothing: it lists the files that are not formatted and
+exits non-zero if there are any, for CI.
+
+Examples:
+  skillmd fmt SKILL.md
+  skillmd fmt skills/*/SKILL.md
+  skillmd fmt --check skills/*/SKILL.md`,
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var unformatted, failed int
+		for _, path := range args {
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", path, err)
+			}
+			formatted, err := skill.Format(string(content))
+			if err != nil {
+				fmt.Fprintf(cmd.ErrOrStderr(), "%s: %v\n", path, err)
+				failed++
+				continue
+			}
+			if formatted == string(content) {
+				continue
+			}
+
+			unformatted++
+			if fmtCheck {
+				fmt.Println(path)
+				continue
+			}
+			if err := os.WriteFile(path, []byte(formatted), 0644); err != nil {
+				return fmt.Errorf("failed to write %s: %w", path, err)
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "Formatted %s\n", path)
+		}
+
+		switch {
+		case failed > 0:
+			return fmt.Errorf("failed to format %d files", failed)
+		case fmtCheck && unformatted > 0:
+			return fmt.Errorf("%d files need formatting", unformatted)
+		}
+		return nil
+	},
+}
+
+func init() {
+	fmtCmd.Flags().BoolVar(&fmtCheck, "check", false, "List unformatted files and fail instead of rewriting them")
+
+	rootCmd.AddCommand(fmtCmd)
+}
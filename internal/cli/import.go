@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import [source] [id]",
+	Short: "Copy an external skill into a remote registry",
+	Long: `Copy a skill from an external source into the registry of a running
+skill-md server. Use "skillmd search" to find the source and ID.
+
+Examples:
+  skillmd import github anthropics/skills/pdf
+  skillmd import skills.sh vercel/react-best-practices --token smd_...`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := newRemoteClient()
+		if err != nil {
+			return err
+		}
+
+		stored, err := c.Import(context.Background(), args[0], args[1])
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Imported %s as %s (v%s)\n", stored.Name, stored.Slug, stored.Version)
+		return nil
+	},
+}
+
+func init() {
+	addRemoteFlags(importCmd)
+	rootCmd.AddCommand(importCmd)
+}
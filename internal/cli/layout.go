@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/sanixdarker/skill-md/internal/bundle"
+	"github.com/sanixdarker/skill-md/pkg/skill"
+)
+
+// Output layouts accepted by --layout
+const (
+	layoutFile   = "file"
+	layoutFolder = "folder"
+)
+
+// checkLayout validates a --layout value. A folder needs an output
+// directory to be written to.
+func checkLayout(layout, output string) error {
+	switch layout {
+	case layoutFile:
+		return nil
+	case layoutFolder:
+		if output == "" {
+			return fmt.Errorf("please provide an output directory (--output) for --layout folder")
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown layout %q (use file or folder)", layout)
+}
+
+// writeSkillFolder lays s out as an Agent Skills folder in dir.
+func writeSkillFolder(dir string, s *skill.Skill) error {
+	if _, err := bundle.WriteSkill(dir, s); err != nil {
+		return fmt.Errorf("failed to write skill folder: %w", err)
+	}
+	return nil
+}
+
+// readSkill reads a SKILL.md file, or a skill folder joined into one skill.
+func readSkill(path string) (*skill.Skill, error) {
+	if bundle.IsDir(path) {
+		files, err := bundle.ReadDir(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		s, err := bundle.Assemble(files)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		return s, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	s, err := skill.Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return s, nil
+}
@@ -0,0 +1,341 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sanixdarker/skill-md/pkg/skill"
+	"github.com/spf13/cobra"
+)
+
+var (
+	lintFormat    string
+	lintFix       bool
+	lintConfig    string
+	lintListRules bool
+)
+
+var lintCmd = &cobra.Command{
+	Use:   "lint [files...]",
+	Short: "Check SKILL.md files against lint rules",
+	Long: `Check SKILL.md files for problems beyond what validate reports: missing
+descriptions, badly named tools, undocumented parameters, duplicate
+sections, dead internal links, code blocks without a language, oversized
+sections and placeholders left in examples.
+
+Rules are configured in the lint section of a .skillmd.yaml file, found in
+the file's directory or one of its parents unless --config is given. Each
+rule can be given a severity (error, warning, info or off) and options:
+
+  lint:
+    rules:
+      placeholder-text: off
+      oversized-section:
+        severity: error
+        max_tokens: 3000
+
+--fix applies the fixes of the rules that have them and rewrites the file
+in canonical form before reporting what is left. Use --list-rules to see
+every rule.
+
+The command exits non-zero when error-severity problems remain.
+
+Examples:
+  skillmd lint SKILL.md
+  skillmd lint skills/*.md --format sarif > lint.sarif
+  skillmd lint SKILL.md --fix
+  skillmd lint --list-rules`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		switch lintFormat {
+		case "text", "json", "sarif":
+		default:
+			return fmt.Errorf("unknown format %q (use text, json or sarif)", lintFormat)
+		}
+
+		if lintListRules {
+			cfg, err := loadProjectConfig(lintConfig, ".")
+			if err != nil {
+				return err
+			}
+			linter, err := skill.NewLinter(&cfg.Lint)
+			if err != nil {
+				return fmt.Errorf("invalid lint config: %w", err)
+			}
+			for _, r := range linter.Rules() {
+				fixable := ""
+				if _, ok := r.(skill.LintFixer); ok {
+					fixable = " (fixable)"
+				}
+				fmt.Printf("%-28s %-8s %s%s\n", r.ID(), linter.Severity(r), r.Description(), fixable)
+			}
+			return nil
+		}
+		if len(args) == 0 {
+			return fmt.Errorf("no files to lint")
+		}
+
+		var results []lintResult
+		for _, path := range args {
+			result, err := lintFile(path)
+			if err != nil {
+				return err
+			}
+			results = append(results, result)
+		}
+
+		var err error
+		switch lintFormat {
+		case "json":
+			err = printLintJSON(results)
+		case "sarif":
+			err = printLintSARIF(results)
+		default:
+			printLintText(cmd, results)
+		}
+		if err != nil {
+			return err
+		}
+
+		errors := 0
+		for _, r := range results {
+			for _, d := range r.Diagnostics {
+				if d.Severity == skill.SeverityError {
+					errors++
+				}
+			}
+		}
+		if errors > 0 {
+			return fmt.Errorf("lint found %d errors", errors)
+		}
+		return nil
+	},
+}
+
+// lintResult is the outcome of linting one file.
+type lintResult struct {
+	Path        string             `json:"path"`
+	Fixed       int                `json:"fixed,omitempty"`
+	Diagnostics []skill.Diagnostic `json:"diagnostics"`
+
+	linter *skill.Linter
+}
+
+// lintFile lints a file with the config that applies to it, fixing it first
+// when --fix is set.
+func lintFile(path string) (lintResult, error) {
+	result := lintResult{Path: path}
+
+	cfg, err := loadProjectConfig(lintConfig, filepath.Dir(path))
+	if err != nil {
+		return result, err
+	}
+	result.linter, err = skill.NewLinter(&cfg.Lint)
+	if err != nil {
+		return result, fmt.Errorf("invalid lint config: %w", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return result, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	s, err := skill.Parse(string(content))
+	if err != nil {
+		return result, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	if lintFix {
+		result.Fixed = result.linter.Fix(s)
+		if s.Preamble() != "" {
+			return result, fmt.Errorf("cannot fix %s: the text before its first heading would be lost (enable the orphan-text rule to move it)", path)
+		}
+		rendered := skill.Render(s)
+		if rendered != string(content) {
+			if err := os.WriteFile(path, []byte(rendered), 0644); err != nil {
+				return result, fmt.Errorf("failed to write %s: %w", path, err)
+			}
+		}
+		if s, err = skill.Parse(rendered); err != nil {
+			return result, fmt.Errorf("failed to parse fixed %s: %w", path, err)
+		}
+	}
+
+	result.Diagnostics = result.linter.Lint(s)
+	if result.Diagnostics == nil {
+		result.Diagnostics = []skill.Diagnostic{}
+	}
+	return result, nil
+}
+
+func printLintText(cmd *cobra.Command, results []lintResult) {
+	var errors, warnings, infos, fixable int
+	for _, r := range results {
+		if r.Fixed > 0 {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Fixed %d problems in %s\n", r.Fixed, r.Path)
+		}
+		for _, d := range r.Diagnostics {
+			location := r.Path
+			if d.Line > 0 {
+				location = fmt.Sprintf("%s:%d", r.Path, d.Line)
+			}
+			fmt.Printf("%s: %s [%s] %s\n", location, d.Severity, d.Rule, d.Message)
+
+			switch d.Severity {
+			case skill.SeverityError:
+				errors++
+			case skill.SeverityWarning:
+				warnings++
+			default:
+				infos++
+			}
+			if d.Fixable {
+				fixable++
+			}
+		}
+	}
+
+	total := errors + warnings + infos
+	if total == 0 {
+		fmt.Fprintln(cmd.ErrOrStderr(), "No problems found")
+		return
+	}
+	summary := fmt.Sprintf("\n%d problems (%d errors, %d warnings, %d info)", total, errors, warnings, infos)
+	if fixable > 0 && !lintFix {
+		summary += fmt.Sprintf(", %d fixable with --fix", fixable)
+	}
+	fmt.Println(summary)
+}
+
+func printLintJSON(results []lintResult) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(results)
+}
+
+// SARIF 2.1.0 log, reduced to the parts code scanning tools read.
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string       `json:"id"`
+	ShortDescription     sarifMessage `json:"shortDescription"`
+	DefaultConfiguration struct {
+		Level string `json:"level"`
+	} `json:"defaultConfiguration"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation struct {
+		ArtifactLocation struct {
+			URI string `json:"uri"`
+		} `json:"artifactLocation"`
+		Region *sarifRegion `json:"region,omitempty"`
+	} `json:"physicalLocation"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// sarifLevel maps a severity to a SARIF result level.
+func sarifLevel(severity skill.Severity) string {
+	switch severity {
+	case skill.SeverityError:
+		return "error"
+	case skill.SeverityWarning:
+		return "warning"
+	case skill.SeverityOff:
+		return "none"
+	}
+	return "note"
+}
+
+func printLintSARIF(results []lintResult) error {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "skillmd",
+			Version:        Version,
+			InformationURI: "https://skill-md.dev",
+			Rules:          []sarifRule{},
+		}},
+		Results: []sarifResult{},
+	}
+
+	seen := make(map[string]bool)
+	for _, r := range results {
+		for _, rule := range r.linter.Rules() {
+			if seen[rule.ID()] {
+				continue
+			}
+			seen[rule.ID()] = true
+			sr := sarifRule{ID: rule.ID(), ShortDescription: sarifMessage{Text: rule.Description()}}
+			sr.DefaultConfiguration.Level = sarifLevel(r.linter.Severity(rule))
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sr)
+		}
+
+		uri := filepath.ToSlash(strings.TrimPrefix(r.Path, "./"))
+		for _, d := range r.Diagnostics {
+			var loc sarifLocation
+			loc.PhysicalLocation.ArtifactLocation.URI = uri
+			if d.Line > 0 {
+				loc.PhysicalLocation.Region = &sarifRegion{StartLine: d.Line}
+			}
+			run.Results = append(run.Results, sarifResult{
+				RuleID:    d.Rule,
+				Level:     sarifLevel(d.Severity),
+				Message:   sarifMessage{Text: d.Message},
+				Locations: []sarifLocation{loc},
+			})
+		}
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{
+		Version: "2.1.0",
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Runs:    []sarifRun{run},
+	})
+}
+
+func init() {
+	lintCmd.Flags().StringVarP(&lintFormat, "format", "f", "text", "Output format (text, json, sarif)")
+	lintCmd.Flags().BoolVar(&lintFix, "fix", false, "Fix what the rules can and rewrite the files")
+	lintCmd.Flags().StringVar(&lintConfig, "config", "", "Config file (default: the nearest .skillmd.yaml)")
+	lintCmd.Flags().BoolVar(&lintListRules, "list-rules", false, "List the lint rules and their severities")
+
+	rootCmd.AddCommand(lintCmd)
+}
@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/sanixdarker/skill-md/internal/mcp"
+	"github.com/sanixdarker/skill-md/pkg/skill"
+	"github.com/spf13/cobra"
+)
+
+var (
+	mcpBaseURL string
+	mcpHeaders []string
+	mcpTimeout time.Duration
+)
+
+var mcpCmd = &cobra.Command{
+	Use:   "mcp",
+	Short: "Model Context Protocol tools",
+}
+
+var mcpServeCmd = &cobra.Command{
+	Use:   "serve [file]",
+	Short: "Serve a SKILL.md as an MCP server over stdio",
+	Long: `Serve a SKILL.md file as a Model Context Protocol server over stdio.
+
+The skill's tool definitions are exposed as MCP tools. Each tool call is
+turned into an HTTP request against the skill's base_url, using the method
+and path recorded when the skill was converted. The skill's sections are
+exposed as MCP resources.
+
+Examples:
+  skillmd mcp serve skill.md
+  skillmd mcp serve skill.md --base-url http://localhost:3000
+  skillmd mcp serve skill.md -H "Authorization: Bearer $API_TOKEN"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		content, err := os.ReadFile(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to read input file: %w", err)
+		}
+
+		s, err := skill.Parse(string(content))
+		if err != nil {
+			return fmt.Errorf("parse error: %w", err)
+		}
+
+		headers := http.Header{}
+		for _, h := range mcpHeaders {
+			key, value, ok := strings.Cut(h, ":")
+			if !ok {
+				return fmt.Errorf("invalid header %q, expected \"Name: value\"", h)
+			}
+			headers.Add(strings.TrimSpace(key), strings.TrimSpace(value))
+		}
+
+		provider := mcp.NewSkillProvider(mcp.SkillProviderConfig{
+			Skill:   s,
+			BaseURL: mcpBaseURL,
+			Headers: headers,
+			Client:  &http.Client{Timeout: mcpTimeout},
+		})
+		server := mcp.NewServer("skillmd", Version, provider)
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		// stdout carries the protocol; diagnostics go to stderr
+		fmt.Fprintf(os.Stderr, "Serving %s (%d tools, %d sections) over stdio\n",
+			s.Frontmatter.Name, len(s.Frontmatter.ToolDefinitions), len(s.Sections))
+
+		return server.ServeStdio(ctx, os.Stdin, os.Stdout)
+	},
+}
+
+func init() {
+	mcpServeCmd.Flags().StringVar(&mcpBaseURL, "base-url", "", "Override the skill's base_url")
+	mcpServeCmd.Flags().StringArrayVarP(&mcpHeaders, "header", "H", nil, "Header added to every request (repeatable), e.g. \"Authorization: Bearer TOKEN\"")
+	mcpServeCmd.Flags().DurationVar(&mcpTimeout, "timeout", 30*time.Second, "Timeout for each upstream request")
+
+	mcpCmd.AddCommand(mcpServeCmd)
+	rootCmd.AddCommand(mcpCmd)
+}
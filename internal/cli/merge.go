@@ -1,18 +1,25 @@
 package cli
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 
+	"github.com/sanixdarker/skill-md/internal/converter"
 	"github.com/sanixdarker/skill-md/internal/merger"
 	"github.com/sanixdarker/skill-md/pkg/skill"
 	"github.com/spf13/cobra"
 )
 
 var (
-	mergeOutput string
-	mergeName   string
-	mergeDedupe bool
+	mergeOutput   string
+	mergeName     string
+	mergeDedupe   bool
+	mergeStrategy string
+	mergeReport   string
+	mergeTokens   int
+	mergeLayout   string
+	mergeNoDate   bool
 )
 
 var mergeCmd = &cobra.Command{
@@ -26,38 +33,96 @@ The merge process:
   3. Optionally deduplicates similar content
   4. Resolves any conflicts
 
+Sections that share a title but differ are resolved with --strategy:
+  combine  keep every distinct body (default)
+  first    keep the body from the first file
+  last     keep the body from the last file
+  longer   keep the longest body
+
+Frontmatter keys skillmd does not know, such as license or metadata, are
+merged as well: maps key by key, lists as a union. Other values that differ
+follow --strategy first, last or longer, and keep the first file's value
+with combine.
+
+--report prints every detected conflict as json or markdown, with the
+files involved and the resolution chosen. The merged skill is then only
+written when --output is set.
+
+--max-tokens trims the merged skill to an estimated token budget, as in
+convert.
+
+Inputs may be SKILL.md files or skill folders. With --layout folder the
+merged skill is written as an Agent Skills folder in the --output
+directory, as in convert. --no-created-at leaves out the created_at
+timestamp so merging the same files always gives the same bytes.
+
 Examples:
   skillmd merge api1.md api2.md -o combined.md
-  skillmd merge *.md -n "Combined API Skills" --dedupe`,
+  skillmd merge *.md -n "Combined API Skills" --dedupe
+  skillmd merge api1.md api2.md --strategy longer --report markdown -o combined.md
+  skillmd merge ./users ./billing -o ./platform --layout folder`,
 	Args: cobra.MinimumNArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		strategy, err := merger.ParseStrategy(mergeStrategy)
+		if err != nil {
+			return err
+		}
+		if mergeReport != "" && mergeReport != "json" && mergeReport != "markdown" {
+			return fmt.Errorf("unknown report format %q (use json or markdown)", mergeReport)
+		}
+		if err := checkLayout(mergeLayout, mergeOutput); err != nil {
+			return err
+		}
+
 		var skills []*skill.Skill
 
 		// Parse all input files
 		for _, path := range args {
-			content, err := os.ReadFile(path)
+			s, err := readSkill(path)
 			if err != nil {
-				return fmt.Errorf("failed to read %s: %w", path, err)
+				return err
 			}
-
-			s, err := skill.Parse(string(content))
-			if err != nil {
-				return fmt.Errorf("failed to parse %s: %w", path, err)
-			}
-
 			skills = append(skills, s)
 		}
 
 		// Merge skills
 		m := merger.New()
-		result, err := m.Merge(skills, &merger.Options{
-			Name:        mergeName,
-			Deduplicate: mergeDedupe,
+		result, conflicts, err := m.MergeWithReport(skills, &merger.Options{
+			Name:          mergeName,
+			Deduplicate:   mergeDedupe,
+			Resolver:      merger.NewConflictResolver(strategy),
+			Sources:       args,
+			OmitCreatedAt: mergeNoDate,
 		})
 		if err != nil {
 			return fmt.Errorf("merge failed: %w", err)
 		}
 
+		if mergeTokens > 0 {
+			var steps []string
+			result, steps = converter.ApplyTokenBudget(result, mergeTokens)
+			printTokenReport(os.Stderr, result, mergeTokens, steps)
+		}
+
+		if mergeReport != "" {
+			if err := printMergeReport(conflicts); err != nil {
+				return err
+			}
+			if mergeOutput == "" {
+				return nil
+			}
+		}
+
+		if mergeLayout == layoutFolder {
+			if err := writeSkillFolder(mergeOutput, result); err != nil {
+				return err
+			}
+			if mergeReport == "" {
+				fmt.Printf("Merged skill folder written to %s\n", mergeOutput)
+			}
+			return nil
+		}
+
 		// Render output
 		output := skill.Render(result)
 
@@ -66,7 +131,10 @@ Examples:
 			if err := os.WriteFile(mergeOutput, []byte(output), 0644); err != nil {
 				return fmt.Errorf("failed to write output file: %w", err)
 			}
-			fmt.Printf("Merged SKILL.md written to %s\n", mergeOutput)
+			// Keep stdout to the report alone so it can be piped
+			if mergeReport == "" {
+				fmt.Printf("Merged SKILL.md written to %s\n", mergeOutput)
+			}
 		} else {
 			fmt.Println(output)
 		}
@@ -75,10 +143,32 @@ Examples:
 	},
 }
 
+func printMergeReport(conflicts []merger.Conflict) error {
+	if mergeReport == "markdown" {
+		fmt.Print(merger.FormatReport(conflicts))
+		return nil
+	}
+
+	if conflicts == nil {
+		conflicts = []merger.Conflict{}
+	}
+	data, err := json.MarshalIndent(conflicts, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode report: %w", err)
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
 func init() {
 	mergeCmd.Flags().StringVarP(&mergeOutput, "output", "o", "", "Output file path")
 	mergeCmd.Flags().StringVarP(&mergeName, "name", "n", "", "Name for the merged skill")
 	mergeCmd.Flags().BoolVar(&mergeDedupe, "dedupe", false, "Deduplicate similar content")
+	mergeCmd.Flags().StringVar(&mergeStrategy, "strategy", "combine", "Conflict strategy for differing sections (combine, first, last, longer)")
+	mergeCmd.Flags().StringVar(&mergeReport, "report", "", "Print the detected conflicts (json, markdown)")
+	mergeCmd.Flags().IntVar(&mergeTokens, "max-tokens", 0, "Trim low-value sections until the merged skill fits this estimated token budget")
+	mergeCmd.Flags().StringVar(&mergeLayout, "layout", layoutFile, "Output layout (file, folder)")
+	mergeCmd.Flags().BoolVar(&mergeNoDate, "no-created-at", false, "Leave out created_at so the same inputs always give the same output")
 
 	rootCmd.AddCommand(mergeCmd)
 }
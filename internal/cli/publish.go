@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/sanixdarker/skill-md/internal/bundle"
+	"github.com/sanixdarker/skill-md/pkg/skill"
+	"github.com/spf13/cobra"
+)
+
+var publishSlug string
+
+var publishCmd = &cobra.Command{
+	Use:   "publish [file|folder]",
+	Short: "Publish a SKILL.md file to a remote registry",
+	Long: `Publish a SKILL.md file to a running skill-md server.
+
+By default a new registry skill is created. With --slug the existing
+skill is updated instead, recording a new revision.
+
+An Agent Skills folder (a directory holding a SKILL.md) is published with
+all its files, and downloads of the skill keep that layout.
+
+The server and token can also be set with the SKILLMD_SERVER and
+SKILLMD_TOKEN environment variables.
+
+Examples:
+  skillmd publish SKILL.md --server https://skills.example.com --token smd_...
+  skillmd publish SKILL.md --slug my-skill
+  skillmd publish ./petstore`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if bundle.IsDir(args[0]) {
+			return publishFolder(args[0])
+		}
+
+		content, err := os.ReadFile(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to read file: %w", err)
+		}
+
+		// Catch malformed files before they reach the server
+		sk, err := skill.Parse(string(content))
+		if err != nil {
+			return fmt.Errorf("failed to parse skill: %w", err)
+		}
+		if sk.Frontmatter.Name == "" {
+			return fmt.Errorf("skill frontmatter must include a name")
+		}
+
+		c, err := newRemoteClient()
+		if err != nil {
+			return err
+		}
+
+		if publishSlug != "" {
+			stored, err := c.Update(context.Background(), publishSlug, string(content))
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Updated %s (v%s)\n", stored.Slug, stored.Version)
+			return nil
+		}
+
+		stored, err := c.Publish(context.Background(), string(content))
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Published %s as %s (v%s)\n", stored.Name, stored.Slug, stored.Version)
+		return nil
+	},
+}
+
+// publishFolder publishes an Agent Skills folder with all its files.
+func publishFolder(dir string) error {
+	files, err := bundle.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read folder: %w", err)
+	}
+
+	// Catch malformed folders before they reach the server
+	sk, err := bundle.Assemble(files)
+	if err != nil {
+		return fmt.Errorf("failed to parse skill folder: %w", err)
+	}
+	if sk.Frontmatter.Name == "" {
+		return fmt.Errorf("skill frontmatter must include a name")
+	}
+
+	c, err := newRemoteClient()
+	if err != nil {
+		return err
+	}
+
+	if publishSlug != "" {
+		stored, err := c.UpdateFiles(context.Background(), publishSlug, files)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Updated %s (v%s, %d files)\n", stored.Slug, stored.Version, len(files))
+		return nil
+	}
+
+	stored, err := c.PublishFiles(context.Background(), files)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Published %s as %s (v%s, %d files)\n", stored.Name, stored.Slug, stored.Version, len(files))
+	return nil
+}
+
+func init() {
+	publishCmd.Flags().StringVar(&publishSlug, "slug", "", "Update the existing skill with this slug instead of creating one")
+	addRemoteFlags(publishCmd)
+	rootCmd.AddCommand(publishCmd)
+}
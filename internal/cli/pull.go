@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sanixdarker/skill-md/internal/bundle"
+	"github.com/sanixdarker/skill-md/pkg/skill"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pullOutput string
+	pullLayout string
+)
+
+var pullCmd = &cobra.Command{
+	Use:   "pull [slug]",
+	Short: "Download a skill from a remote registry",
+	Long: `Download a registry skill from a running skill-md server.
+
+The skill is written to <slug>.md unless --output is given. Use
+"--output -" to print it to stdout.
+
+With --layout folder the skill is written as an Agent Skills folder, to
+<slug>/ unless --output is given. Skills published as a folder come back
+with their own files; others are laid out as convert --layout folder does.
+
+Examples:
+  skillmd pull stripe-api
+  skillmd pull stripe-api -o skills/stripe/SKILL.md
+  skillmd pull stripe-api --layout folder -o skills/stripe`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if pullLayout != layoutFile && pullLayout != layoutFolder {
+			return fmt.Errorf("unknown layout %q (use file or folder)", pullLayout)
+		}
+		if pullLayout == layoutFolder && pullOutput == "-" {
+			return fmt.Errorf("a skill folder cannot be written to stdout")
+		}
+
+		c, err := newRemoteClient()
+		if err != nil {
+			return err
+		}
+
+		stored, err := c.GetSkill(context.Background(), args[0])
+		if err != nil {
+			return err
+		}
+
+		if pullOutput == "-" {
+			fmt.Print(stored.Content)
+			return nil
+		}
+
+		output := pullOutput
+		if output == "" {
+			// The slug comes from the server, so it must not be able to
+			// point outside the working directory.
+			if !isSafeSlug(stored.Slug) {
+				return fmt.Errorf("server returned an unsafe slug %q; pass --output to choose a file", stored.Slug)
+			}
+			output = stored.Slug + ".md"
+			if pullLayout == layoutFolder {
+				output = stored.Slug
+			}
+		}
+		if pullLayout == layoutFolder {
+			return pullFolder(stored, output)
+		}
+		if err := os.WriteFile(output, []byte(stored.Content), 0644); err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+		fmt.Printf("Pulled %s (v%s) to %s\n", stored.Slug, stored.Version, output)
+		return nil
+	},
+}
+
+// pullFolder writes a registry skill as an Agent Skills folder in dir.
+func pullFolder(stored *skill.StoredSkill, dir string) error {
+	files := stored.Files
+	if len(files) == 0 {
+		sk, err := skill.Parse(stored.Content)
+		if err != nil {
+			return fmt.Errorf("failed to parse skill: %w", err)
+		}
+		files = bundle.Build(sk)
+	}
+
+	// Paths come from the server; WriteFiles keeps them inside dir
+	if _, err := bundle.WriteFiles(dir, files); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	fmt.Printf("Pulled %s (v%s) to %s (%d files)\n", stored.Slug, stored.Version, dir, len(files))
+	return nil
+}
+
+func init() {
+	pullCmd.Flags().StringVarP(&pullOutput, "output", "o", "", "Output file path (default <slug>.md, - for stdout)")
+	pullCmd.Flags().StringVar(&pullLayout, "layout", layoutFile, "Output layout (file, folder)")
+	addRemoteFlags(pullCmd)
+	rootCmd.AddCommand(pullCmd)
+}
+
+// isSafeSlug reports whether slug can be used as a file name as-is.
+func isSafeSlug(slug string) bool {
+	return slug != "" && slug != "." && slug != ".." &&
+		!strings.ContainsAny(slug, `/\`) && filepath.Base(slug) == slug
+}
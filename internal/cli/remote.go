@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"os"
+
+	"github.com/sanixdarker/skill-md/internal/client"
+	"github.com/spf13/cobra"
+)
+
+const defaultServerURL = "http://localhost:8080"
+
+var (
+	remoteServer string
+	remoteToken  string
+)
+
+// addRemoteFlags registers the --server and --token flags shared by the
+// commands that talk to a running skill-md server.
+func addRemoteFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&remoteServer, "server", "", "Registry server URL (default $SKILLMD_SERVER or "+defaultServerURL+")")
+	cmd.Flags().StringVar(&remoteToken, "token", "", "API token (default $SKILLMD_TOKEN)")
+}
+
+// newRemoteClient creates a client from the remote flags, falling back to
+// the SKILLMD_SERVER and SKILLMD_TOKEN environment variables.
+func newRemoteClient() (*client.Client, error) {
+	server := remoteServer
+	if server == "" {
+		server = os.Getenv("SKILLMD_SERVER")
+	}
+	if server == "" {
+		server = defaultServerURL
+	}
+
+	token := remoteToken
+	if token == "" {
+		token = os.Getenv("SKILLMD_TOKEN")
+	}
+
+	return client.New(server, token)
+}
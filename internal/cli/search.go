@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	searchSources []string
+	searchPage    int
+	searchLimit   int
+)
+
+var searchCmd = &cobra.Command{
+	Use:   "search [query]",
+	Short: "Search a remote registry and its external sources",
+	Long: `Run a federated search on a running skill-md server and print the
+results as a table. External results can be copied into the registry
+with "skillmd import".
+
+Sources: local, skills.sh, github, gitlab, bitbucket, codeberg
+
+Examples:
+  skillmd search stripe
+  skillmd search "payments api" --sources local,github --limit 50`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := newRemoteClient()
+		if err != nil {
+			return err
+		}
+
+		result, err := c.Search(context.Background(), args[0], searchSources, searchPage, searchLimit)
+		if err != nil {
+			return err
+		}
+
+		if len(result.Skills) == 0 {
+			fmt.Fprintln(cmd.ErrOrStderr(), "No skills found")
+		} else {
+			tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(tw, "SOURCE\tID\tNAME\tDESCRIPTION")
+			for _, sk := range result.Skills {
+				id := sk.ID
+				if sk.Slug != "" && sk.Source == "local" {
+					id = sk.Slug
+				}
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", sk.Source, id, sk.Name, truncateLine(sk.Description, 60))
+			}
+			tw.Flush()
+		}
+
+		fmt.Fprintf(cmd.ErrOrStderr(), "\n%d results in %s\n", result.Total, result.SearchTime.Round(1e6))
+		for source, msg := range result.SourceErrors {
+			fmt.Fprintf(cmd.ErrOrStderr(), "warning: %s: %s\n", source, msg)
+		}
+		return nil
+	},
+}
+
+// truncateLine collapses s onto one line and shortens it to max runes.
+func truncateLine(s string, max int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if r := []rune(s); len(r) > max {
+		return string(r[:max-3]) + "..."
+	}
+	return s
+}
+
+func init() {
+	searchCmd.Flags().StringSliceVar(&searchSources, "sources", nil, "Sources to search (default: all enabled)")
+	searchCmd.Flags().IntVar(&searchPage, "page", 1, "Result page")
+	searchCmd.Flags().IntVar(&searchLimit, "limit", 20, "Results per page (max 100)")
+	addRemoteFlags(searchCmd)
+	rootCmd.AddCommand(searchCmd)
+}
@@ -21,6 +21,7 @@ var (
 	serveDebug       bool
 	serveNoSSH       bool
 	serveGitHubToken string
+	serveNoAuth      bool
 )
 
 var serveCmd = &cobra.Command{
@@ -34,6 +35,7 @@ Examples:
   skillmd serve
   skillmd serve --port 8080 --ssh-port 2222
   skillmd serve --no-ssh
+  skillmd serve --no-auth   # local use only: writes need no API token
 
 Connect via SSH:
   ssh localhost -p 2222`,
@@ -49,6 +51,7 @@ Connect via SSH:
 			DBPath:      serveDBPath,
 			Debug:       serveDebug,
 			GitHubToken: githubToken,
+			RequireAuth: !serveNoAuth,
 		}
 
 		application, err := app.New(cfg)
@@ -97,6 +100,10 @@ Connect via SSH:
 			srv.Shutdown()
 		}()
 
+		if serveNoAuth {
+			application.Logger.Warn("API tokens are disabled; anyone who can reach the server can publish and delete skills")
+		}
+
 		application.Logger.Info("starting server", "port", cfg.Port)
 		fmt.Printf("Skill MD web server running at http://localhost:%d\n", cfg.Port)
 
@@ -112,5 +119,7 @@ func init() {
 	serveCmd.Flags().BoolVar(&serveNoSSH, "no-ssh", false, "Disable SSH server")
 	serveCmd.Flags().StringVar(&serveGitHubToken, "github-token", "", "GitHub API token (or set GITHUB_TOKEN env var)")
 
+	serveCmd.Flags().BoolVar(&serveNoAuth, "no-auth", false, "Allow publishing, deleting and MCP access without API tokens")
+
 	rootCmd.AddCommand(serveCmd)
 }
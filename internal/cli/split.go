@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sanixdarker/skill-md/internal/merger"
+	"github.com/sanixdarker/skill-md/pkg/skill"
+	"github.com/spf13/cobra"
+)
+
+var (
+	splitOutput string
+	splitBy     string
+)
+
+var splitCmd = &cobra.Command{
+	Use:   "split [file]",
+	Short: "Split a large SKILL.md into smaller skills",
+	Long: `Split a SKILL.md file into one skill per area, the inverse of merge.
+
+Each part is a complete SKILL.md with the tool definitions of the
+operations it documents. An index SKILL.md links the parts together:
+
+  <output>/SKILL.md
+  <output>/<part>/SKILL.md
+
+Split modes (--by):
+  auto     pick from the source format (default)
+  section  one part per top-level section
+  tag      OpenAPI operations grouped by tag
+  type     GraphQL operations grouped by root type
+  service  gRPC methods grouped by service
+
+Examples:
+  skillmd split petstore.md -o ./petstore
+  skillmd split schema.md -o ./schema --by type`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if splitOutput == "" {
+			return fmt.Errorf("please provide an output directory (--output)")
+		}
+		mode, err := merger.ParseSplitMode(splitBy)
+		if err != nil {
+			return err
+		}
+
+		content, err := os.ReadFile(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to read input file: %w", err)
+		}
+		s, err := skill.Parse(string(content))
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", args[0], err)
+		}
+
+		result, err := merger.New().Split(s, &merger.SplitOptions{Mode: mode})
+		if err != nil {
+			return fmt.Errorf("split failed: %w", err)
+		}
+
+		for _, part := range result.Parts {
+			path := filepath.Join(splitOutput, part.Slug, "SKILL.md")
+			if err := writeSkillFile(path, part.Skill); err != nil {
+				return err
+			}
+			fmt.Printf("  %s (%d tools)\n", path, len(part.Skill.Frontmatter.ToolDefinitions))
+		}
+
+		index := filepath.Join(splitOutput, "SKILL.md")
+		if err := writeSkillFile(index, result.Index); err != nil {
+			return err
+		}
+		fmt.Printf("Split into %d parts, index written to %s\n", len(result.Parts), index)
+
+		return nil
+	},
+}
+
+func writeSkillFile(path string, s *skill.Skill) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(skill.Render(s)), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+func init() {
+	splitCmd.Flags().StringVarP(&splitOutput, "output", "o", "", "Output directory")
+	splitCmd.Flags().StringVar(&splitBy, "by", "auto", "Split mode (auto, section, tag, type, service)")
+
+	rootCmd.AddCommand(splitCmd)
+}
@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sanixdarker/skill-md/internal/auth"
+	"github.com/spf13/cobra"
+)
+
+var (
+	tokenDBPath string
+	tokenOwner  string
+	tokenName   string
+	tokenScopes []string
+)
+
+var tokenCmd = &cobra.Command{
+	Use:   "token",
+	Short: "Manage registry API tokens",
+	Long: `Manage API tokens for the registry's write endpoints.
+
+Tokens are sent as "Authorization: Bearer <token>". Unless the server runs
+with --no-auth, publishing needs the publish scope, deleting needs the delete
+scope, and only a skill's owner or an admin token may modify it.
+
+Scopes: read, publish, delete, admin
+
+Examples:
+  skillmd token create --owner alice --scopes publish,delete
+  skillmd token create --owner ops --name ci --scopes admin
+  skillmd token list
+  skillmd token revoke smd_1a2b3c4d`,
+}
+
+var tokenCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create an API token",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := openDB(tokenDBPath)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		service := auth.NewService(auth.NewRepository(db))
+		token, secret, err := service.CreateToken(tokenOwner, tokenName, tokenScopes)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(cmd.ErrOrStderr(), "Created token %s for %s (scopes: %s)\n",
+			token.Prefix, token.Owner, strings.Join(token.Scopes, ", "))
+		fmt.Fprintln(cmd.ErrOrStderr(), "Store it now; the secret cannot be shown again.")
+		fmt.Println(secret)
+		return nil
+	},
+}
+
+var tokenListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List API tokens",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := openDB(tokenDBPath)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		service := auth.NewService(auth.NewRepository(db))
+		tokens, err := service.ListTokens(tokenOwner)
+		if err != nil {
+			return err
+		}
+
+		for _, t := range tokens {
+			status := "active"
+			if t.RevokedAt != nil {
+				status = "revoked"
+			}
+			fmt.Printf("%-14s %-16s %-12s %-24s %-8s %s\n", t.Prefix, t.Owner, t.Name, strings.Join(t.Scopes, ","), status, t.ID)
+		}
+		return nil
+	},
+}
+
+var tokenRevokeCmd = &cobra.Command{
+	Use:   "revoke [id-or-prefix]",
+	Short: "Revoke an API token",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := openDB(tokenDBPath)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		service := auth.NewService(auth.NewRepository(db))
+		token, err := service.RevokeToken(args[0])
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Revoked token %s (%s)\n", token.Prefix, token.Owner)
+		return nil
+	},
+}
+
+func init() {
+	tokenCmd.PersistentFlags().StringVar(&tokenDBPath, "db", "./skill-md.db", "Path to SQLite database")
+
+	tokenCreateCmd.Flags().StringVar(&tokenOwner, "owner", "", "Owner the token acts as (required)")
+	tokenCreateCmd.Flags().StringVar(&tokenName, "name", "", "Label for the token, e.g. ci")
+	tokenCreateCmd.Flags().StringSliceVar(&tokenScopes, "scopes", []string{auth.ScopeRead, auth.ScopePublish}, "Comma-separated scopes")
+	tokenCreateCmd.MarkFlagRequired("owner")
+
+	tokenListCmd.Flags().StringVar(&tokenOwner, "owner", "", "Only list tokens for this owner")
+
+	tokenCmd.AddCommand(tokenCreateCmd)
+	tokenCmd.AddCommand(tokenListCmd)
+	tokenCmd.AddCommand(tokenRevokeCmd)
+	rootCmd.AddCommand(tokenCmd)
+}
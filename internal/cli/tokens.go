@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/sanixdarker/skill-md/pkg/skill"
+)
+
+// printTokenReport writes the trimming steps taken for a token budget and
+// the estimated size of each top-level section.
+func printTokenReport(w io.Writer, s *skill.Skill, budget int, steps []string) {
+	counts := skill.CountTokens(s)
+	fmt.Fprintf(w, "Estimated tokens: %d (budget %d)\n", counts.Total, budget)
+	for _, step := range steps {
+		fmt.Fprintf(w, "  trimmed: %s\n", step)
+	}
+	if counts.Total > budget {
+		fmt.Fprintf(w, "  warning: still %d tokens over budget after trimming\n", counts.Total-budget)
+	}
+	printSectionTokens(w, counts, true)
+}
+
+// printSectionTokens writes per-section token estimates. With topLevel set
+// only the outermost sections are listed, each with its nested sections
+// included.
+func printSectionTokens(w io.Writer, counts skill.TokenCounts, topLevel bool) {
+	minLevel := 0
+	for _, sec := range counts.Sections {
+		if minLevel == 0 || sec.Level < minLevel {
+			minLevel = sec.Level
+		}
+	}
+
+	fmt.Fprintf(w, "  %6d  (frontmatter)\n", counts.Frontmatter)
+	for _, sec := range counts.Sections {
+		if topLevel {
+			if sec.Level == minLevel {
+				fmt.Fprintf(w, "  %6d  %s\n", sec.Cumulative, sec.Title)
+			}
+			continue
+		}
+		indent := strings.Repeat("  ", sec.Level-minLevel)
+		fmt.Fprintf(w, "  %6d  %s%s\n", sec.Tokens, indent, sec.Title)
+	}
+}
@@ -8,6 +8,12 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var (
+	validateTokens    bool
+	validateMaxTokens int
+	validateStrict    bool
+)
+
 var validateCmd = &cobra.Command{
 	Use:   "validate [file]",
 	Short: "Validate a SKILL.md file",
@@ -18,9 +24,21 @@ Checks performed:
   - Required fields (name, version)
   - Valid markdown structure
   - Section hierarchy
+  - Estimated size within the declared max_tokens_per_call budget
+
+--max-tokens checks against a budget other than the declared one, and
+--tokens lists the estimated tokens of every section.
+
+--strict also checks the frontmatter against the SKILL.md JSON Schema
+(served at /schema/skill.json): value types, allowed values such as
+difficulty and retry_strategy.backoff_type, and tool parameters that must
+be valid JSON Schemas. Errors give their line and column.
 
 Examples:
-  skillmd validate skill.md`,
+  skillmd validate skill.md
+  skillmd validate skill.md --tokens
+  skillmd validate skill.md --max-tokens 4000
+  skillmd validate skill.md --strict`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		inputPath := args[0]
@@ -31,25 +49,57 @@ Examples:
 			return fmt.Errorf("failed to read input file: %w", err)
 		}
 
+		// Check the frontmatter against the schema first: it reports type
+		// errors that make parsing fail with their position
+		var errors []string
+		if validateStrict {
+			schemaErrors, err := skill.ValidateSchema(string(content))
+			if err != nil {
+				return fmt.Errorf("schema error: %w", err)
+			}
+			for _, e := range schemaErrors {
+				errors = append(errors, fmt.Sprintf("%s:%s", inputPath, e))
+			}
+		}
+
 		// Parse the skill
 		s, err := skill.Parse(string(content))
 		if err != nil {
+			if len(errors) > 0 {
+				printValidationErrors(errors)
+			}
 			return fmt.Errorf("parse error: %w", err)
 		}
 
 		// Validate
-		errors := validate(s)
+		errors = append(errors, validate(s)...)
+
+		counts := skill.CountTokens(s)
+		budget := s.Frontmatter.MaxTokensPerCall
+		if validateMaxTokens > 0 {
+			budget = validateMaxTokens
+		}
+		if budget > 0 && counts.Total > budget {
+			errors = append(errors, fmt.Sprintf("skill is %d tokens, over its budget of %d", counts.Total, budget))
+		}
+		if validateTokens {
+			fmt.Println("Estimated tokens per section:")
+			printSectionTokens(os.Stdout, counts, false)
+		}
+
 		if len(errors) > 0 {
-			fmt.Println("Validation errors:")
-			for _, e := range errors {
-				fmt.Printf("  - %s\n", e)
-			}
+			printValidationErrors(errors)
 			return fmt.Errorf("validation failed with %d errors", len(errors))
 		}
 
 		fmt.Printf("Valid SKILL.md: %s\n", s.Frontmatter.Name)
 		fmt.Printf("  Version: %s\n", s.Frontmatter.Version)
 		fmt.Printf("  Sections: %d\n", len(s.Sections))
+		if budget > 0 {
+			fmt.Printf("  Tokens: %d of %d\n", counts.Total, budget)
+		} else {
+			fmt.Printf("  Tokens: %d\n", counts.Total)
+		}
 		if len(s.Frontmatter.Tags) > 0 {
 			fmt.Printf("  Tags: %v\n", s.Frontmatter.Tags)
 		}
@@ -58,6 +108,13 @@ Examples:
 	},
 }
 
+func printValidationErrors(errors []string) {
+	fmt.Println("Validation errors:")
+	for _, e := range errors {
+		fmt.Printf("  - %s\n", e)
+	}
+}
+
 func validate(s *skill.Skill) []string {
 	var errors []string
 
@@ -93,4 +150,8 @@ func validate(s *skill.Skill) []string {
 
 func init() {
 	rootCmd.AddCommand(validateCmd)
+
+	validateCmd.Flags().BoolVar(&validateTokens, "tokens", false, "Print the estimated tokens of each section")
+	validateCmd.Flags().IntVar(&validateMaxTokens, "max-tokens", 0, "Token budget to check against (default: the skill's max_tokens_per_call)")
+	validateCmd.Flags().BoolVar(&validateStrict, "strict", false, "Check the frontmatter against the SKILL.md JSON Schema")
 }
@@ -0,0 +1,214 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/sanixdarker/skill-md/internal/registry"
+	"github.com/sanixdarker/skill-md/pkg/skill"
+	"github.com/spf13/cobra"
+)
+
+var (
+	versionsDBPath     string
+	versionsDiffFormat string
+)
+
+var versionsCmd = &cobra.Command{
+	Use:   "versions",
+	Short: "Inspect and restore skill revisions in the local registry",
+	Long: `Inspect and restore skill revisions in the local registry.
+
+Every update to a skill is recorded as a new revision. Rolling back
+restores an earlier revision's content as a new revision, so no
+history is lost.
+
+Examples:
+  skillmd versions list my-skill
+  skillmd versions show my-skill 2
+  skillmd versions diff my-skill 1 3
+  skillmd versions rollback my-skill 2`,
+}
+
+var versionsListCmd = &cobra.Command{
+	Use:   "list [skill]",
+	Short: "List the revisions of a skill",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		service, stored, closeDB, err := openVersionedSkill(args[0])
+		if err != nil {
+			return err
+		}
+		defer closeDB()
+
+		versions, err := service.ListVersions(stored.ID)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("%s (%s)\n", stored.Name, stored.Slug)
+		for _, v := range versions {
+			fmt.Printf("  %4d  v%-10s %s  %s\n", v.Revision, v.Version, v.CreatedAt.Format("2006-01-02 15:04:05"), v.ContentHash[:12])
+		}
+		return nil
+	},
+}
+
+var versionsShowCmd = &cobra.Command{
+	Use:   "show [skill] [revision]",
+	Short: "Print the content of a revision",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		revision, err := parseRevision(args[1])
+		if err != nil {
+			return err
+		}
+
+		service, stored, closeDB, err := openVersionedSkill(args[0])
+		if err != nil {
+			return err
+		}
+		defer closeDB()
+
+		v, err := service.GetVersion(stored.ID, revision)
+		if err != nil {
+			return err
+		}
+		if v == nil {
+			return fmt.Errorf("revision %d not found", revision)
+		}
+
+		fmt.Print(v.Content)
+		return nil
+	},
+}
+
+var versionsDiffCmd = &cobra.Command{
+	Use:   "diff [skill] [from] [to]",
+	Short: "Show a diff between two revisions",
+	Long: `Show a unified diff between two revisions. When [to] is omitted the
+latest revision is used. --format text or json lists the semantic
+changes instead, as skillmd diff does.`,
+	Args: cobra.RangeArgs(2, 3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		switch versionsDiffFormat {
+		case "unified", "text", "json":
+		default:
+			return fmt.Errorf("unknown format %q (use unified, text or json)", versionsDiffFormat)
+		}
+
+		from, err := parseRevision(args[1])
+		if err != nil {
+			return err
+		}
+
+		service, stored, closeDB, err := openVersionedSkill(args[0])
+		if err != nil {
+			return err
+		}
+		defer closeDB()
+
+		var to int
+		if len(args) == 3 {
+			if to, err = parseRevision(args[2]); err != nil {
+				return err
+			}
+		} else {
+			versions, err := service.ListVersions(stored.ID)
+			if err != nil {
+				return err
+			}
+			if len(versions) == 0 {
+				return fmt.Errorf("skill %s has no revisions", stored.Slug)
+			}
+			to = versions[0].Revision
+		}
+
+		if versionsDiffFormat != "unified" {
+			changes, err := service.CompareVersions(stored.ID, from, to)
+			if err != nil {
+				return err
+			}
+			return printChanges(cmd, changes, versionsDiffFormat, "Revisions are equivalent")
+		}
+
+		out, err := service.DiffVersions(stored.ID, from, to)
+		if err != nil {
+			return err
+		}
+		if out == "" {
+			fmt.Fprintln(cmd.ErrOrStderr(), "Revisions are identical")
+			return nil
+		}
+
+		fmt.Print(out)
+		return nil
+	},
+}
+
+var versionsRollbackCmd = &cobra.Command{
+	Use:   "rollback [skill] [revision]",
+	Short: "Restore a skill to an earlier revision",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		revision, err := parseRevision(args[1])
+		if err != nil {
+			return err
+		}
+
+		service, stored, closeDB, err := openVersionedSkill(args[0])
+		if err != nil {
+			return err
+		}
+		defer closeDB()
+
+		restored, err := service.RollbackSkill(stored.ID, revision)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Rolled back %s to revision %d (v%s)\n", restored.Slug, revision, restored.Version)
+		return nil
+	},
+}
+
+// openVersionedSkill opens the registry database and resolves a skill by ID
+// or slug. The returned function closes the database.
+func openVersionedSkill(idOrSlug string) (*registry.Service, *skill.StoredSkill, func(), error) {
+	db, err := openDB(versionsDBPath)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	service := registry.NewService(registry.NewRepository(db))
+	stored, err := service.GetSkill(idOrSlug)
+	if err != nil {
+		db.Close()
+		return nil, nil, nil, err
+	}
+	if stored == nil {
+		db.Close()
+		return nil, nil, nil, fmt.Errorf("skill not found: %s", idOrSlug)
+	}
+
+	return service, stored, func() { db.Close() }, nil
+}
+
+func parseRevision(s string) (int, error) {
+	revision, err := strconv.Atoi(s)
+	if err != nil || revision < 1 {
+		return 0, fmt.Errorf("invalid revision: %s", s)
+	}
+	return revision, nil
+}
+
+func init() {
+	versionsCmd.PersistentFlags().StringVar(&versionsDBPath, "db", "./skill-md.db", "Path to SQLite database")
+	versionsDiffCmd.Flags().StringVarP(&versionsDiffFormat, "format", "f", "unified", "Output format (unified, text, json)")
+
+	versionsCmd.AddCommand(versionsListCmd)
+	versionsCmd.AddCommand(versionsShowCmd)
+	versionsCmd.AddCommand(versionsDiffCmd)
+	versionsCmd.AddCommand(versionsRollbackCmd)
+	rootCmd.AddCommand(versionsCmd)
+}
@@ -0,0 +1,202 @@
+// Package client talks to a remote skill-md server over its /api/v1 JSON API.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sanixdarker/skill-md/internal/sources"
+	"github.com/sanixdarker/skill-md/pkg/skill"
+)
+
+// maxResponseSize caps how much of a response body is read.
+const maxResponseSize = 20 << 20 // 20MB
+
+// Error is an error response from the server.
+type Error struct {
+	Status  int
+	Code    string
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("server returned %d %s: %s", e.Status, e.Code, e.Message)
+}
+
+// Client is a skill-md API client.
+type Client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+// New creates a client for the server at baseURL. token may be empty for
+// servers that do not require auth.
+func New(baseURL, token string) (*Client, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid server URL: %s", baseURL)
+	}
+
+	return &Client{
+		baseURL:    strings.TrimSuffix(u.String(), "/") + "/api/v1",
+		token:      token,
+		httpClient: &http.Client{Timeout: 90 * time.Second},
+	}, nil
+}
+
+// Publish uploads a SKILL.md document as a new registry skill.
+func (c *Client) Publish(ctx context.Context, content string) (*skill.StoredSkill, error) {
+	var stored skill.StoredSkill
+	err := c.do(ctx, http.MethodPost, "/skills", map[string]string{"content": content}, &stored)
+	if err != nil {
+		return nil, err
+	}
+	return &stored, nil
+}
+
+// Update replaces the content of an existing registry skill.
+func (c *Client) Update(ctx context.Context, idOrSlug, content string) (*skill.StoredSkill, error) {
+	var stored skill.StoredSkill
+	err := c.do(ctx, http.MethodPut, "/skills/"+url.PathEscape(idOrSlug), map[string]string{"content": content}, &stored)
+	if err != nil {
+		return nil, err
+	}
+	return &stored, nil
+}
+
+// PublishFiles uploads an Agent Skills folder as a new registry skill.
+func (c *Client) PublishFiles(ctx context.Context, files []skill.File) (*skill.StoredSkill, error) {
+	var stored skill.StoredSkill
+	err := c.do(ctx, http.MethodPost, "/skills", map[string][]skill.File{"files": files}, &stored)
+	if err != nil {
+		return nil, err
+	}
+	return &stored, nil
+}
+
+// UpdateFiles replaces an existing registry skill with an Agent Skills
+// folder.
+func (c *Client) UpdateFiles(ctx context.Context, idOrSlug string, files []skill.File) (*skill.StoredSkill, error) {
+	var stored skill.StoredSkill
+	err := c.do(ctx, http.MethodPut, "/skills/"+url.PathEscape(idOrSlug), map[string][]skill.File{"files": files}, &stored)
+	if err != nil {
+		return nil, err
+	}
+	return &stored, nil
+}
+
+// GetSkill fetches a registry skill by ID or slug.
+func (c *Client) GetSkill(ctx context.Context, idOrSlug string) (*skill.StoredSkill, error) {
+	var stored skill.StoredSkill
+	if err := c.do(ctx, http.MethodGet, "/skills/"+url.PathEscape(idOrSlug), nil, &stored); err != nil {
+		return nil, err
+	}
+	return &stored, nil
+}
+
+// Import copies an external skill into the registry.
+func (c *Client) Import(ctx context.Context, source, id string) (*skill.StoredSkill, error) {
+	var stored skill.StoredSkill
+	err := c.do(ctx, http.MethodPost, "/skills/import", map[string]string{"source": source, "id": id}, &stored)
+	if err != nil {
+		return nil, err
+	}
+	return &stored, nil
+}
+
+// Search runs a federated search. An empty srcs searches every source the
+// server has enabled.
+func (c *Client) Search(ctx context.Context, query string, srcs []string, page, perPage int) (*sources.FederatedResult, error) {
+	params := url.Values{}
+	params.Set("q", query)
+	if len(srcs) > 0 {
+		params.Set("sources", strings.Join(srcs, ","))
+	}
+	if page > 0 {
+		params.Set("page", strconv.Itoa(page))
+	}
+	if perPage > 0 {
+		params.Set("per_page", strconv.Itoa(perPage))
+	}
+
+	var result sources.FederatedResult
+	if err := c.do(ctx, http.MethodGet, "/search?"+params.Encode(), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// do sends a request and decodes a JSON response into out.
+func (c *Client) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		return decodeError(resp.StatusCode, data)
+	}
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("invalid response from server: %w", err)
+	}
+	return nil
+}
+
+// decodeError builds an Error from an error envelope, falling back to the
+// raw body for responses that did not come from the API handlers.
+func decodeError(status int, data []byte) error {
+	var envelope struct {
+		Error struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if json.Unmarshal(data, &envelope) == nil && envelope.Error.Code != "" {
+		return &Error{Status: status, Code: envelope.Error.Code, Message: envelope.Error.Message}
+	}
+
+	message := strings.TrimSpace(string(data))
+	if message == "" {
+		message = http.StatusText(status)
+	}
+	return &Error{Status: status, Code: strings.ToLower(strings.ReplaceAll(http.StatusText(status), " ", "_")), Message: message}
+}
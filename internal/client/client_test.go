@@ -0,0 +1,116 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNew_InvalidURL(t *testing.T) {
+	for _, u := range []string{"", "localhost:8080", "ftp://example.com", "http://"} {
+		if _, err := New(u, ""); err == nil {
+			t.Errorf("expected error for %q", u)
+		}
+	}
+}
+
+func TestClient_Publish(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/skills" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer smd_secret" {
+			t.Errorf("unexpected Authorization header %q", got)
+		}
+		if got := r.Header.Get("Content-Type"); got != "application/json" {
+			t.Errorf("unexpected Content-Type %q", got)
+		}
+
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["content"] != "---\nname: Test\n---\n" {
+			t.Errorf("unexpected body %v", body)
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		io.WriteString(w, `{"id":"1","slug":"test","name":"Test"}`)
+	}))
+	defer srv.Close()
+
+	c, err := New(srv.URL+"/", "smd_secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stored, err := c.Publish(context.Background(), "---\nname: Test\n---\n")
+	if err != nil {
+		t.Fatalf("publish failed: %v", err)
+	}
+	if stored.Slug != "test" {
+		t.Errorf("expected slug test, got %q", stored.Slug)
+	}
+}
+
+func TestClient_Search(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/api/v1/search" || q.Get("q") != "pets" || q.Get("sources") != "local,github" || q.Get("per_page") != "5" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		if r.Header.Get("Authorization") != "" {
+			t.Error("expected no Authorization header without a token")
+		}
+		io.WriteString(w, `{"skills":[{"id":"a/b","name":"Pets","source":"github"}],"total":1,"by_source":{"github":1}}`)
+	}))
+	defer srv.Close()
+
+	c, _ := New(srv.URL, "")
+	result, err := c.Search(context.Background(), "pets", []string{"local", "github"}, 0, 5)
+	if err != nil {
+		t.Fatalf("search failed: %v", err)
+	}
+	if result.Total != 1 || len(result.Skills) != 1 || result.Skills[0].Name != "Pets" {
+		t.Errorf("unexpected result %+v", result)
+	}
+}
+
+func TestClient_ErrorEnvelope(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		io.WriteString(w, `{"error":{"code":"not_found","message":"skill not found: nope"}}`)
+	}))
+	defer srv.Close()
+
+	c, _ := New(srv.URL, "")
+	_, err := c.GetSkill(context.Background(), "nope")
+
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *Error, got %v", err)
+	}
+	if apiErr.Status != http.StatusNotFound || apiErr.Code != "not_found" || apiErr.Message != "skill not found: nope" {
+		t.Errorf("unexpected error %+v", apiErr)
+	}
+}
+
+func TestClient_PlainTextError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Invalid API token", http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	c, _ := New(srv.URL, "smd_bogus")
+	_, err := c.Import(context.Background(), "github", "a/b")
+
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *Error, got %v", err)
+	}
+	if apiErr.Code != "unauthorized" || apiErr.Message != "Invalid API token" {
+		t.Errorf("unexpected error %+v", apiErr)
+	}
+}
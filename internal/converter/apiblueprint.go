@@ -41,6 +41,7 @@ type apibResource struct {
 type apibAction struct {
 	Method      string
 	Name        string
+	URI         string // Overrides the resource URI when set
 	Description string
 	URIParams   []apibParam
 	QueryParams []apibParam
@@ -275,6 +276,7 @@ func (c *APIBlueprintConverter) parseAction(name, method, uri, content string) a
 	action := apibAction{
 		Name:        name,
 		Method:      method,
+		URI:         uri,
 		URIParams:   []apibParam{},
 		QueryParams: []apibParam{},
 		Headers:     []apibHeader{},
@@ -325,14 +327,15 @@ func (c *APIBlueprintConverter) parseParameters(content string) []apibParam {
 	params := []apibParam{}
 
 	// Parse each parameter line: + name: `example` (type, required/optional) - description
-	paramRe := regexp.MustCompile(`(?m)^\s*\+\s+(\w+)(?::\s*\x60?(.+?)\x60?)?\s*(?:\((.+?)\))?\s*(?:-\s*(.+))?$`)
+	paramRe := regexp.MustCompile(`(?m)^\s*\+\s+(\w+)(?::\s*(\x60[^\x60]*\x60|[^\s(]+))?\s*(?:\((.+?)\))?\s*(?:-\s*(.+))?$`)
 	matches := paramRe.FindAllStringSubmatch(content, -1)
 
 	for _, match := range matches {
 		param := apibParam{
 			Name:     match[1],
 			Example:  strings.Trim(match[2], "`"),
-			Required: true, // default
+			Type:     "string", // default
+			Required: true,     // default
 		}
 
 		// Parse type and required/optional
@@ -823,11 +826,16 @@ func (c *APIBlueprintConverter) buildToolDefinitions(spec *apibSpec) []skill.Too
 	for _, group := range spec.ResourceGroups {
 		for _, resource := range group.Resources {
 			for _, action := range resource.Actions {
-				toolName := c.pathToToolName(action.Method, resource.URI)
+				uri := resource.URI
+				if action.URI != "" {
+					uri = action.URI
+				}
+				path := apibPath(uri)
+				toolName := c.pathToToolName(action.Method, path)
 
 				desc := action.Description
 				if desc == "" {
-					desc = fmt.Sprintf("%s %s", action.Method, resource.URI)
+					desc = fmt.Sprintf("%s %s", action.Method, uri)
 				}
 
 				params := map[string]interface{}{
@@ -837,13 +845,31 @@ func (c *APIBlueprintConverter) buildToolDefinitions(spec *apibSpec) []skill.Too
 
 				props := params["properties"].(map[string]interface{})
 				required := []string{}
-
-				// URI parameters
-				for _, p := range resource.URIParams {
+				location := map[string]string{}
+
+				// URI parameters are either path placeholders or part of the
+				// {?query} template. Parameters declared on the resource take
+				// precedence over ones only inferred from the action URI.
+				uriParams := append([]apibParam{}, resource.URIParams...)
+				for _, p := range action.URIParams {
+					declared := false
+					for _, rp := range resource.URIParams {
+						declared = declared || rp.Name == p.Name
+					}
+					if !declared {
+						uriParams = append(uriParams, p)
+					}
+				}
+				for _, p := range uriParams {
 					props[p.Name] = map[string]interface{}{
 						"type":        p.Type,
 						"description": p.Description,
 					}
+					if strings.Contains(path, "{"+p.Name+"}") {
+						location[p.Name] = "path"
+					} else {
+						location[p.Name] = "query"
+					}
 					if p.Required {
 						required = append(required, p.Name)
 					}
@@ -855,6 +881,7 @@ func (c *APIBlueprintConverter) buildToolDefinitions(spec *apibSpec) []skill.Too
 						"type":        p.Type,
 						"description": p.Description,
 					}
+					location[p.Name] = "query"
 					if p.Required {
 						required = append(required, p.Name)
 					}
@@ -866,6 +893,7 @@ func (c *APIBlueprintConverter) buildToolDefinitions(spec *apibSpec) []skill.Too
 						"type":        "object",
 						"description": "Request body",
 					}
+					location["body"] = "body"
 				}
 
 				tools = append(tools, skill.ToolDefinition{
@@ -873,6 +901,9 @@ func (c *APIBlueprintConverter) buildToolDefinitions(spec *apibSpec) []skill.Too
 					Description: truncate(desc, 200),
 					Parameters:  params,
 					Required:    required,
+					Method:      strings.ToUpper(action.Method),
+					Path:        path,
+					Location:    location,
 				})
 			}
 		}
@@ -881,6 +912,14 @@ func (c *APIBlueprintConverter) buildToolDefinitions(spec *apibSpec) []skill.Too
 	return tools
 }
 
+// apibPath strips the {?query} part of a URI template, leaving the path.
+func apibPath(uri string) string {
+	if i := strings.Index(uri, "{?"); i >= 0 {
+		return uri[:i]
+	}
+	return uri
+}
+
 func (c *APIBlueprintConverter) pathToToolName(method, path string) string {
 	name := strings.ReplaceAll(path, "/", "_")
 	name = strings.ReplaceAll(name, "{", "")
@@ -0,0 +1,36 @@
+package converter
+
+import (
+	"os"
+	"testing"
+)
+
+func TestAPIBlueprintConverter_ToolDefinitions(t *testing.T) {
+	content, err := os.ReadFile("../../testdata/api.apib")
+	if err != nil {
+		t.Fatalf("failed to read testdata: %v", err)
+	}
+
+	c := &APIBlueprintConverter{}
+	s, err := c.Convert(content, &Options{})
+	if err != nil {
+		t.Fatalf("convert failed: %v", err)
+	}
+
+	get := findTool(s.Frontmatter.ToolDefinitions, "get_users_userid")
+	if get == nil {
+		t.Fatalf("expected get_users_userid tool, got %+v", s.Frontmatter.ToolDefinitions)
+	}
+	if get.Method != "GET" || get.Path != "/users/{userId}" {
+		t.Errorf("expected GET /users/{userId}, got %s %s", get.Method, get.Path)
+	}
+	if get.Location["userId"] != "path" {
+		t.Errorf("expected userId to be located in the path, got %v", get.Location)
+	}
+
+	for _, tool := range s.Frontmatter.ToolDefinitions {
+		if tool.Method == "" || tool.Path == "" {
+			t.Errorf("%s: missing method or path", tool.Name)
+		}
+	}
+}
@@ -172,8 +172,8 @@ func (c *AsyncAPIConverter) buildSkill(spec *asyncAPISpec, opts *Options) *skill
 	s.Frontmatter.MessageCount = c.countMessages(spec)
 
 	// Extract servers
-	for _, server := range spec.Servers {
-		s.Frontmatter.Servers = append(s.Frontmatter.Servers, server.URL)
+	for _, name := range sortedKeys(spec.Servers) {
+		s.Frontmatter.Servers = append(s.Frontmatter.Servers, spec.Servers[name].URL)
 	}
 
 	// Extract auth methods
@@ -263,6 +263,7 @@ func (c *AsyncAPIConverter) extractAuthMethods(spec *asyncAPISpec) []string {
 	for m := range methods {
 		result = append(result, m)
 	}
+	sort.Strings(result)
 	return result
 }
 
@@ -292,6 +293,7 @@ func (c *AsyncAPIConverter) extractBindings(spec *asyncAPISpec) []string {
 	for b := range bindings {
 		result = append(result, b)
 	}
+	sort.Strings(result)
 	return result
 }
 
@@ -305,9 +307,8 @@ func (c *AsyncAPIConverter) buildQuickStart(spec *asyncAPISpec) string {
 	}
 
 	var serverURL string
-	for _, srv := range spec.Servers {
-		serverURL = srv.URL
-		break
+	if names := sortedKeys(spec.Servers); len(names) > 0 {
+		serverURL = spec.Servers[names[0]].URL
 	}
 
 	b.WriteString("### Getting Started\n\n")
@@ -391,7 +392,8 @@ func (c *AsyncAPIConverter) buildServersSection(spec *asyncAPISpec) string {
 		return b.String()
 	}
 
-	for name, server := range spec.Servers {
+	for _, name := range sortedKeys(spec.Servers) {
+		server := spec.Servers[name]
 		b.WriteString(fmt.Sprintf("### %s\n\n", name))
 		b.WriteString(fmt.Sprintf("- **URL**: `%s`\n", server.URL))
 		b.WriteString(fmt.Sprintf("- **Protocol**: %s\n", server.Protocol))
@@ -401,7 +403,8 @@ func (c *AsyncAPIConverter) buildServersSection(spec *asyncAPISpec) string {
 
 		if len(server.Variables) > 0 {
 			b.WriteString("\n**Variables:**\n")
-			for varName, v := range server.Variables {
+			for _, varName := range sortedKeys(server.Variables) {
+				v := server.Variables[varName]
 				b.WriteString(fmt.Sprintf("- `{%s}`: %s (default: `%s`)\n", varName, v.Description, v.Default))
 			}
 		}
@@ -468,7 +471,7 @@ func (c *AsyncAPIConverter) buildChannelsSection(spec *asyncAPISpec) string {
 
 		if len(ch.Parameters) > 0 {
 			b.WriteString("**Parameters:**\n")
-			for pName := range ch.Parameters {
+			for _, pName := range sortedKeys(ch.Parameters) {
 				b.WriteString(fmt.Sprintf("- `{%s}`\n", pName))
 			}
 			b.WriteString("\n")
@@ -491,7 +494,8 @@ func (c *AsyncAPIConverter) buildMessagesSection(spec *asyncAPISpec) string {
 	}
 
 	// Collect inline messages from channels
-	for _, ch := range spec.Channels {
+	for _, name := range sortedKeys(spec.Channels) {
+		ch := spec.Channels[name]
 		if ch.Subscribe != nil && ch.Subscribe.Message != nil && ch.Subscribe.Message.Name != "" {
 			messages[ch.Subscribe.Message.Name] = *ch.Subscribe.Message
 		}
@@ -681,7 +685,8 @@ func (c *AsyncAPIConverter) buildSecuritySection(spec *asyncAPISpec) string {
 		return "No security schemes defined."
 	}
 
-	for name, scheme := range spec.Components.SecuritySchemes {
+	for _, name := range sortedKeys(spec.Components.SecuritySchemes) {
+		scheme := spec.Components.SecuritySchemes[name]
 		b.WriteString(fmt.Sprintf("### %s\n\n", name))
 		b.WriteString(fmt.Sprintf("**Type**: %s\n", scheme.Type))
 		if scheme.Description != "" {
@@ -718,10 +723,9 @@ func (c *AsyncAPIConverter) buildCodeExamples(spec *asyncAPISpec) string {
 	protocols := c.extractProtocols(spec)
 	var serverURL string
 	var protocol string
-	for _, srv := range spec.Servers {
-		serverURL = srv.URL
-		protocol = srv.Protocol
-		break
+	if names := sortedKeys(spec.Servers); len(names) > 0 {
+		serverURL = spec.Servers[names[0]].URL
+		protocol = spec.Servers[names[0]].Protocol
 	}
 
 	if len(protocols) > 0 {
@@ -730,9 +734,8 @@ func (c *AsyncAPIConverter) buildCodeExamples(spec *asyncAPISpec) string {
 
 	// Get first channel for examples
 	var channelName string
-	for name := range spec.Channels {
-		channelName = name
-		break
+	if names := sortedKeys(spec.Channels); len(names) > 0 {
+		channelName = names[0]
 	}
 
 	b.WriteString("### Python\n\n")
@@ -837,10 +840,11 @@ func (c *AsyncAPIConverter) buildCodeExamples(spec *asyncAPISpec) string {
 func (c *AsyncAPIConverter) buildToolDefinitions(spec *asyncAPISpec) []skill.ToolDefinition {
 	tools := make([]skill.ToolDefinition, 0)
 
-	for channelName, ch := range spec.Channels {
+	for _, channelName := range sortedKeys(spec.Channels) {
+		ch := spec.Channels[channelName]
 		// Publish tool
 		if ch.Publish != nil {
-			toolName := fmt.Sprintf("publish_to_%s", sanitizeToolName(channelName))
+			toolName := fmt.Sprintf("publish_to_%s", skill.SanitizeToolName(channelName))
 			desc := fmt.Sprintf("Publish a message to the %s channel", channelName)
 			if ch.Publish.Summary != "" {
 				desc = ch.Publish.Summary
@@ -866,7 +870,7 @@ func (c *AsyncAPIConverter) buildToolDefinitions(spec *asyncAPISpec) []skill.Too
 
 		// Subscribe tool
 		if ch.Subscribe != nil {
-			toolName := fmt.Sprintf("subscribe_to_%s", sanitizeToolName(channelName))
+			toolName := fmt.Sprintf("subscribe_to_%s", skill.SanitizeToolName(channelName))
 			desc := fmt.Sprintf("Subscribe to messages from the %s channel", channelName)
 			if ch.Subscribe.Summary != "" {
 				desc = ch.Subscribe.Summary
@@ -900,9 +904,10 @@ func (c *AsyncAPIConverter) buildToolDefinitionsSection(spec *asyncAPISpec) stri
 	b.WriteString("```yaml\n")
 	b.WriteString("tools:\n")
 
-	for channelName, ch := range spec.Channels {
+	for _, channelName := range sortedKeys(spec.Channels) {
+		ch := spec.Channels[channelName]
 		if ch.Publish != nil {
-			b.WriteString(fmt.Sprintf("  - name: publish_to_%s\n", sanitizeToolName(channelName)))
+			b.WriteString(fmt.Sprintf("  - name: publish_to_%s\n", skill.SanitizeToolName(channelName)))
 			b.WriteString(fmt.Sprintf("    description: Publish to %s\n", channelName))
 			b.WriteString("    parameters:\n")
 			b.WriteString("      type: object\n")
@@ -913,7 +918,7 @@ func (c *AsyncAPIConverter) buildToolDefinitionsSection(spec *asyncAPISpec) stri
 			b.WriteString("      required: [message]\n")
 		}
 		if ch.Subscribe != nil {
-			b.WriteString(fmt.Sprintf("  - name: subscribe_to_%s\n", sanitizeToolName(channelName)))
+			b.WriteString(fmt.Sprintf("  - name: subscribe_to_%s\n", skill.SanitizeToolName(channelName)))
 			b.WriteString(fmt.Sprintf("    description: Subscribe to %s\n", channelName))
 			b.WriteString("    parameters:\n")
 			b.WriteString("      type: object\n")
@@ -975,14 +980,3 @@ func (c *AsyncAPIConverter) buildBestPractices(spec *asyncAPISpec) string {
 
 	return strings.TrimSpace(b.String())
 }
-
-func sanitizeToolName(name string) string {
-	// Replace common separators with underscores
-	name = strings.ReplaceAll(name, "/", "_")
-	name = strings.ReplaceAll(name, "-", "_")
-	name = strings.ReplaceAll(name, ".", "_")
-	name = strings.ReplaceAll(name, "{", "")
-	name = strings.ReplaceAll(name, "}", "")
-	name = strings.Trim(name, "_")
-	return strings.ToLower(name)
-}
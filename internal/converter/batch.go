@@ -0,0 +1,248 @@
+package converter
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sanixdarker/skill-md/internal/bundle"
+	"github.com/sanixdarker/skill-md/pkg/skill"
+)
+
+// BatchOptions configures a directory conversion.
+type BatchOptions struct {
+	// Format forces every file through one converter. When empty each file
+	// is auto-detected, and files that are only recognised as plain text
+	// are skipped.
+	Format string
+	// Workers is the number of files converted in parallel. Defaults to the
+	// number of CPUs.
+	Workers int
+	// MaxTokens trims each skill to this estimated token budget when set;
+	// see skill.TrimToBudget.
+	MaxTokens int
+	// Folder writes each skill as an Agent Skills folder named after its
+	// source, instead of a single .md file.
+	Folder bool
+	// OmitCreatedAt leaves created_at out of every skill.
+	OmitCreatedAt bool
+}
+
+// BatchResult is the outcome of converting one file in a batch.
+type BatchResult struct {
+	Source  string // Path relative to the input directory
+	Output  string // Path relative to the output directory
+	Format  string
+	Skipped bool
+	// Unchanged is set when the output already held the rendered skill and
+	// was not rewritten.
+	Unchanged bool
+	Err       error
+}
+
+type batchJob struct {
+	source, output string
+}
+
+// ConvertDir converts every spec under inDir, writing one SKILL.md per file
+// to outDir at the same relative path with a .md extension. Hidden files
+// and directories are ignored, as is outDir when it is inside inDir.
+// Per-file failures are reported in the results, which are sorted by
+// source path; the error is only set if the walk itself fails.
+func (m *Manager) ConvertDir(inDir, outDir string, opts *BatchOptions) ([]BatchResult, error) {
+	if opts == nil {
+		opts = &BatchOptions{}
+	}
+	workers := opts.Workers
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+
+	absOut, err := filepath.Abs(outDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var sources []string
+	err = filepath.WalkDir(inDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != inDir && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			if abs, err := filepath.Abs(path); err == nil && abs == absOut && path != inDir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(inDir, path)
+		if err != nil {
+			return err
+		}
+		sources = append(sources, rel)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(sources)
+
+	jobs := make(chan batchJob)
+	results := make([]BatchResult, 0, len(sources))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				result := m.convertFile(inDir, outDir, job, opts)
+				mu.Lock()
+				results = append(results, result)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, job := range batchJobs(sources, opts.Folder) {
+		jobs <- job
+	}
+	close(jobs)
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool { return results[i].Source < results[j].Source })
+	return results, nil
+}
+
+// batchJobs maps each source to an output path, a directory for folders.
+// Sources that would collide once their extension is replaced (api.yaml and
+// api.json) keep it instead.
+func batchJobs(sources []string, folder bool) []batchJob {
+	suffix := ".md"
+	if folder {
+		suffix = ""
+	}
+
+	outputs := make(map[string]int, len(sources))
+	for _, src := range sources {
+		outputs[skillPath(src)+suffix]++
+	}
+
+	jobs := make([]batchJob, len(sources))
+	for i, src := range sources {
+		out := skillPath(src) + suffix
+		if outputs[out] > 1 {
+			out = src + suffix
+			if folder {
+				out = strings.ReplaceAll(src, ".", "_")
+			}
+		}
+		jobs[i] = batchJob{source: src, output: out}
+	}
+	return jobs
+}
+
+func skillPath(source string) string {
+	return strings.TrimSuffix(source, filepath.Ext(source))
+}
+
+func (m *Manager) convertFile(inDir, outDir string, job batchJob, opts *BatchOptions) BatchResult {
+	result := BatchResult{Source: job.source, Output: job.output}
+
+	content, err := os.ReadFile(filepath.Join(inDir, job.source))
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	result.Format = opts.Format
+	if result.Format == "" {
+		result.Format = m.DetectFormat(job.source, content)
+		// Unrecognised files fall through to plain text, and URL detection
+		// would fetch from the network; neither belongs in a spec tree walk.
+		if result.Format == "text" || result.Format == "url" {
+			result.Skipped = true
+			return result
+		}
+	}
+
+	sk, err := m.Convert(result.Format, content, &Options{SourcePath: job.source, OmitCreatedAt: opts.OmitCreatedAt})
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	sk, _ = ApplyTokenBudget(sk, opts.MaxTokens)
+
+	outPath := filepath.Join(outDir, job.output)
+	if err := os.MkdirAll(filepath.Dir(outPath), 0755); err != nil {
+		result.Err = err
+		return result
+	}
+	var written bool
+	if opts.Folder {
+		written, err = bundle.WriteSkill(outPath, sk)
+	} else {
+		written, err = WriteSkill(outPath, sk)
+	}
+	if err != nil {
+		result.Err = fmt.Errorf("failed to write output: %w", err)
+	}
+	result.Unchanged = !written
+	return result
+}
+
+// ApplyTokenBudget trims sk to maxTokens and records the budget as the
+// skill's max_tokens_per_call when none is declared. It returns the trimming
+// steps taken. A zero budget returns sk unchanged.
+func ApplyTokenBudget(sk *skill.Skill, maxTokens int) (*skill.Skill, []string) {
+	if maxTokens <= 0 {
+		return sk, nil
+	}
+	trimmed, steps := skill.TrimToBudget(sk, maxTokens)
+	if trimmed == sk {
+		copied := *sk
+		trimmed = &copied
+	}
+	if trimmed.Frontmatter.MaxTokensPerCall == 0 {
+		trimmed.Frontmatter.MaxTokensPerCall = maxTokens
+	}
+	return trimmed, steps
+}
+
+// WriteSkill renders sk to path unless the file already holds exactly that
+// content, so unchanged skills keep their modification time. The created_at
+// of an existing skill at path is carried over, since regenerating a skill
+// does not create a new one; a skill without created_at is written without
+// one. It reports whether the file was written.
+func WriteSkill(path string, sk *skill.Skill) (bool, error) {
+	existing, err := os.ReadFile(path)
+	if err == nil && sk.Frontmatter.CreatedAt != "" {
+		if prev, err := skill.Parse(string(existing)); err == nil && prev.Frontmatter.CreatedAt != "" {
+			sk.Frontmatter.CreatedAt = prev.Frontmatter.CreatedAt
+		}
+	}
+
+	data := []byte(skill.Render(sk))
+	if bytes.Equal(existing, data) {
+		return false, nil
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,214 @@
+package converter
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+const batchSpec = `openapi: "3.0.0"
+info:
+  title: Pets
+  version: "1.0.0"
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        "200":
+          description: OK
+`
+
+const batchSchema = `type Query {
+  user(id: ID!): User
+}
+
+type User {
+  id: ID!
+}
+`
+
+func writeTree(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestManager_ConvertDir(t *testing.T) {
+	in := t.TempDir()
+	out := filepath.Join(t.TempDir(), "skills")
+	writeTree(t, in, map[string]string{
+		"pets/openapi.yaml":     batchSpec,
+		"users/schema.graphql":  batchSchema,
+		"README.md":             "# Specs\n",
+		".git/config":           batchSpec,
+		"broken/openapi.yaml":   "openapi: [",
+		"collide/api.yaml":      batchSpec,
+		"collide/api.json.orig": "ignored",
+		"collide/api.json":      `{"openapi": "3.0.0", "info": {"title": "Pets", "version": "1.0.0"}, "paths": {}}`,
+	})
+
+	results, err := NewManager().ConvertDir(in, out, &BatchOptions{Workers: 3})
+	if err != nil {
+		t.Fatalf("ConvertDir failed: %v", err)
+	}
+
+	bySource := make(map[string]BatchResult)
+	for _, r := range results {
+		bySource[filepath.ToSlash(r.Source)] = r
+	}
+
+	if _, ok := bySource[".git/config"]; ok {
+		t.Error("expected hidden directories to be skipped")
+	}
+	if r := bySource["README.md"]; !r.Skipped {
+		t.Errorf("expected plain text to be skipped, got %+v", r)
+	}
+	if r := bySource["broken/openapi.yaml"]; r.Err == nil {
+		t.Errorf("expected broken spec to fail, got %+v", r)
+	}
+
+	for src, want := range map[string]string{
+		"pets/openapi.yaml":    "pets/openapi.md",
+		"users/schema.graphql": "users/schema.md",
+		"collide/api.yaml":     "collide/api.yaml.md",
+		"collide/api.json":     "collide/api.json.md",
+	} {
+		r := bySource[src]
+		if r.Err != nil || r.Skipped {
+			t.Errorf("%s: unexpected result %+v", src, r)
+			continue
+		}
+		if filepath.ToSlash(r.Output) != want {
+			t.Errorf("%s: expected output %s, got %s", src, want, r.Output)
+		}
+		data, err := os.ReadFile(filepath.Join(out, r.Output))
+		if err != nil {
+			t.Errorf("%s: output not written: %v", src, err)
+			continue
+		}
+		if !strings.HasPrefix(string(data), "---\n") {
+			t.Errorf("%s: output is not a SKILL.md", src)
+		}
+	}
+
+	for i := 1; i < len(results); i++ {
+		if results[i-1].Source > results[i].Source {
+			t.Fatalf("results not sorted: %s before %s", results[i-1].Source, results[i].Source)
+		}
+	}
+}
+
+func TestManager_ConvertDir_KeepsUnchangedOutput(t *testing.T) {
+	in := t.TempDir()
+	out := t.TempDir()
+	writeTree(t, in, map[string]string{"notes.txt": "Some notes about the API.\n"})
+
+	m := NewManager()
+	opts := &BatchOptions{Format: "text"}
+	if results, _ := m.ConvertDir(in, out, opts); len(results) != 1 || results[0].Unchanged {
+		t.Fatalf("expected first run to write output, got %+v", results)
+	}
+
+	// A later run must not count a new timestamp as a change
+	outPath := filepath.Join(out, "notes.md")
+	data, _ := os.ReadFile(outPath)
+	stamp := regexp.MustCompile(`created_at: "[^"]*"`)
+	os.WriteFile(outPath, stamp.ReplaceAll(data, []byte(`created_at: "2020-01-01T00:00:00Z"`)), 0644)
+
+	results, err := m.ConvertDir(in, out, opts)
+	if err != nil {
+		t.Fatalf("ConvertDir failed: %v", err)
+	}
+	if len(results) != 1 || !results[0].Unchanged {
+		t.Errorf("expected second run to leave output untouched, got %+v", results)
+	}
+	if data, _ := os.ReadFile(outPath); !strings.Contains(string(data), "2020-01-01T00:00:00Z") {
+		t.Error("expected created_at to be preserved")
+	}
+}
+
+func TestManager_ConvertDir_SkipsNestedOutput(t *testing.T) {
+	in := t.TempDir()
+	out := filepath.Join(in, "skills")
+	writeTree(t, in, map[string]string{
+		"openapi.yaml":      batchSpec,
+		"skills/stale.yaml": batchSpec,
+	})
+
+	results, err := NewManager().ConvertDir(in, out, nil)
+	if err != nil {
+		t.Fatalf("ConvertDir failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Source != "openapi.yaml" {
+		t.Errorf("expected only openapi.yaml to be converted, got %+v", results)
+	}
+}
+
+func TestManager_ConvertDir_ForcedFormat(t *testing.T) {
+	in := t.TempDir()
+	out := t.TempDir()
+	writeTree(t, in, map[string]string{"notes.txt": "Some notes about the API.\n"})
+
+	results, err := NewManager().ConvertDir(in, out, &BatchOptions{Format: "text"})
+	if err != nil {
+		t.Fatalf("ConvertDir failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Skipped || results[0].Err != nil {
+		t.Fatalf("expected forced text conversion, got %+v", results)
+	}
+	if _, err := os.Stat(filepath.Join(out, "notes.md")); err != nil {
+		t.Errorf("expected notes.md to be written: %v", err)
+	}
+}
+
+func TestManager_ConvertDir_MaxTokens(t *testing.T) {
+	in := t.TempDir()
+	out := t.TempDir()
+	writeTree(t, in, map[string]string{"openapi.yaml": batchSpec})
+
+	if _, err := NewManager().ConvertDir(in, out, &BatchOptions{MaxTokens: 50000}); err != nil {
+		t.Fatalf("ConvertDir failed: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(out, "openapi.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "max_tokens_per_call: 50000") {
+		t.Errorf("expected the budget to be recorded, got:\n%s", data)
+	}
+}
+
+func TestManager_ConvertDir_Folder(t *testing.T) {
+	in := t.TempDir()
+	out := t.TempDir()
+	writeTree(t, in, map[string]string{
+		"pets/openapi.yaml": batchSpec,
+		"api.yaml":          batchSpec,
+		"api.json":          `{"openapi": "3.0.0", "info": {"title": "Pets", "version": "1.0.0"}, "paths": {}}`,
+	})
+
+	results, err := NewManager().ConvertDir(in, out, &BatchOptions{Folder: true})
+	if err != nil {
+		t.Fatalf("ConvertDir failed: %v", err)
+	}
+	for _, r := range results {
+		if r.Err != nil {
+			t.Errorf("%s failed: %v", r.Source, r.Err)
+		}
+	}
+	for _, p := range []string{"pets/openapi/SKILL.md", "pets/openapi/examples/listpets.md", "api_yaml/SKILL.md", "api_json/SKILL.md"} {
+		if _, err := os.Stat(filepath.Join(out, p)); err != nil {
+			t.Errorf("expected %s: %v", p, err)
+		}
+	}
+}
@@ -4,6 +4,7 @@ package converter
 import (
 	"fmt"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/sanixdarker/skill-md/pkg/skill"
@@ -23,6 +24,9 @@ type Converter interface {
 type Options struct {
 	Name       string
 	SourcePath string
+	// OmitCreatedAt leaves created_at unset, so converting the same input
+	// always renders the same bytes.
+	OmitCreatedAt bool
 }
 
 // Manager manages available converters.
@@ -41,6 +45,11 @@ func NewManager() *Manager {
 	m.Register(&RAMLConverter{})
 	m.Register(&WSDLConverter{})
 	m.Register(&APIBlueprintConverter{})
+	m.Register(&OpenAIFunctionsConverter{})
+	m.Register(&AgentsMDConverter{})
+	m.Register(&ClaudeMDConverter{})
+	m.Register(&CursorRulesConverter{})
+	m.Register(&LLMSTxtConverter{})
 	m.Register(&PDFConverter{})
 	m.Register(NewURLConverter())
 	m.Register(&PlainTextConverter{})
@@ -56,7 +65,11 @@ func (m *Manager) Register(c Converter) {
 func (m *Manager) Convert(format string, content []byte, opts *Options) (*skill.Skill, error) {
 	for _, c := range m.converters {
 		if strings.EqualFold(c.Name(), format) {
-			return c.Convert(content, opts)
+			s, err := c.Convert(content, opts)
+			if err == nil && s != nil && opts != nil && opts.OmitCreatedAt {
+				s.Frontmatter.CreatedAt = ""
+			}
+			return s, err
 		}
 	}
 	return nil, fmt.Errorf("unknown format: %s", format)
@@ -81,7 +94,57 @@ func (m *Manager) SupportedFormats() []string {
 	return formats
 }
 
+// renderToolDefinitionsSection renders the body of a "Tool Definitions" section
+// listing each tool with its required parameters.
+func renderToolDefinitionsSection(tools []skill.ToolDefinition) string {
+	var b strings.Builder
+
+	b.WriteString("MCP-compatible tool definitions for AI agents:\n\n")
+	b.WriteString("```yaml\n")
+	b.WriteString("tools:\n")
+
+	for _, tool := range tools {
+		b.WriteString(fmt.Sprintf("  - name: %s\n", tool.Name))
+		b.WriteString(fmt.Sprintf("    description: %s\n", truncate(tool.Description, 60)))
+		b.WriteString("    parameters:\n")
+		b.WriteString("      type: object\n")
+		if len(tool.Required) > 0 {
+			b.WriteString("      required:\n")
+			for _, req := range tool.Required {
+				b.WriteString(fmt.Sprintf("        - %s\n", req))
+			}
+		}
+	}
+
+	b.WriteString("```\n")
+	return strings.TrimSpace(b.String())
+}
+
 // getExtension returns the lowercase file extension.
 func getExtension(filename string) string {
 	return strings.ToLower(filepath.Ext(filename))
 }
+
+// sortedKeys returns the keys of m in order, so output built from a map is
+// the same on every run.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// sortedYAMLKeys is sortedKeys for maps decoded from YAML with
+// interface{} keys.
+func sortedYAMLKeys(m map[interface{}]interface{}) []interface{} {
+	keys := make([]interface{}, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+	})
+	return keys
+}
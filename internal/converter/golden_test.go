@@ -0,0 +1,85 @@
+package converter
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sanixdarker/skill-md/pkg/skill"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata/golden")
+
+// TestGolden converts every spec in testdata/ and compares the rendered skill
+// byte for byte with testdata/golden/<spec>.md. Run with -update to accept
+// new output.
+func TestGolden(t *testing.T) {
+	entries, err := os.ReadDir("../../testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewManager()
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		name := e.Name()
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join("../../testdata", name)
+			content, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			format := m.DetectFormat(path, content)
+			convert := func() string {
+				s, err := m.Convert(format, content, &Options{SourcePath: "testdata/" + name, OmitCreatedAt: true})
+				if err != nil {
+					t.Fatalf("convert as %s failed: %v", format, err)
+				}
+				return skill.Render(s)
+			}
+
+			got := convert()
+			if strings.Contains(got, "created_at:") {
+				t.Errorf("created_at should be omitted:\n%s", got)
+			}
+			// Converters only emit tool names the tool-name lint rule accepts
+			parsed, err := skill.Parse(got)
+			if err != nil {
+				t.Fatalf("rendered skill does not parse: %v", err)
+			}
+			for _, tool := range parsed.Frontmatter.ToolDefinitions {
+				if !skill.ValidToolName(tool.Name) {
+					t.Errorf("tool %q is not a valid tool name", tool.Name)
+				}
+			}
+			// Map iteration order differs between runs; so must not the output
+			for i := 0; i < 5; i++ {
+				if again := convert(); again != got {
+					t.Fatalf("output changed between conversions:\n%s\n---\n%s", got, again)
+				}
+			}
+
+			golden := filepath.Join("../../testdata/golden", name+".md")
+			if *update {
+				if err := os.MkdirAll(filepath.Dir(golden), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(golden, []byte(got), 0644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("missing golden file, run go test ./internal/converter -update: %v", err)
+			}
+			if got != string(want) {
+				t.Errorf("%s (%s) does not match %s:\n%s", name, format, golden, got)
+			}
+		})
+	}
+}
@@ -78,6 +78,11 @@ func (c *GraphQLConverter) buildSkill(schema *ast.Schema, opts *Options) *skill.
 
 	s.Frontmatter.HasExamples = true // We generate examples
 
+	// MCP-compatible tool definitions, one per query and mutation field
+	tools := c.buildToolDefinitions(schema)
+	s.Frontmatter.MCPCompatible = len(tools) > 0
+	s.Frontmatter.ToolDefinitions = tools
+
 	// Add Quick Start section
 	s.AddSection("Quick Start", 2, c.buildQuickStart(schema))
 
@@ -107,6 +112,11 @@ func (c *GraphQLConverter) buildSkill(schema *ast.Schema, opts *Options) *skill.
 		s.AddSection("Directives", 2, directivesContent)
 	}
 
+	// Add Tool Definitions section
+	if len(tools) > 0 {
+		s.AddSection("Tool Definitions", 2, renderToolDefinitionsSection(tools))
+	}
+
 	// Add Best Practices section
 	s.AddSection("Best Practices", 2, c.buildBestPracticesSection())
 
@@ -540,8 +550,8 @@ func (c *GraphQLConverter) buildDirectivesSection(schema *ast.Schema) string {
 	}
 
 	var customDirectives []*ast.DirectiveDefinition
-	for _, dir := range schema.Directives {
-		if !builtIn[dir.Name] {
+	for _, name := range sortedKeys(schema.Directives) {
+		if dir := schema.Directives[name]; !builtIn[dir.Name] {
 			customDirectives = append(customDirectives, dir)
 		}
 	}
@@ -635,6 +645,215 @@ func (c *GraphQLConverter) buildBestPracticesSection() string {
 	return strings.TrimSpace(b.String())
 }
 
+// buildToolDefinitions creates one MCP tool per query and mutation field, with
+// the field arguments described as JSON Schema.
+func (c *GraphQLConverter) buildToolDefinitions(schema *ast.Schema) []skill.ToolDefinition {
+	tools := make([]skill.ToolDefinition, 0)
+
+	roots := []struct {
+		opType string
+		def    *ast.Definition
+	}{
+		{"query", schema.Query},
+		{"mutation", schema.Mutation},
+	}
+
+	for _, root := range roots {
+		if root.def == nil {
+			continue
+		}
+
+		fields := make([]*ast.FieldDefinition, 0, len(root.def.Fields))
+		for _, field := range root.def.Fields {
+			if !strings.HasPrefix(field.Name, "__") {
+				fields = append(fields, field)
+			}
+		}
+		sort.Slice(fields, func(i, j int) bool {
+			return fields[i].Name < fields[j].Name
+		})
+
+		for _, field := range fields {
+			props := map[string]interface{}{}
+			required := []string{}
+			for _, arg := range field.Arguments {
+				prop := c.typeToJSONSchema(arg.Type, schema, map[string]bool{})
+				if arg.Description != "" {
+					prop["description"] = arg.Description
+				}
+				props[arg.Name] = prop
+				if arg.Type.NonNull && arg.DefaultValue == nil {
+					required = append(required, arg.Name)
+				}
+			}
+
+			desc := strings.TrimSpace(strings.SplitN(field.Description, "\n", 2)[0])
+			if desc == "" {
+				desc = fmt.Sprintf("Run the %s %s, returning %s", field.Name, root.opType, c.formatType(field.Type))
+			}
+
+			tools = append(tools, skill.ToolDefinition{
+				Name:        fmt.Sprintf("%s_%s", root.opType, field.Name),
+				Description: truncate(desc, 200),
+				Parameters: map[string]interface{}{
+					"type":       "object",
+					"properties": props,
+				},
+				Required: required,
+				Method:   "POST",
+				GraphQL:  c.operationDocument(root.opType, field, schema),
+			})
+		}
+	}
+
+	return tools
+}
+
+// operationDocument builds the document a tool sends for a root field. The
+// field arguments become variables and the scalar fields of the result are
+// selected.
+func (c *GraphQLConverter) operationDocument(opType string, field *ast.FieldDefinition, schema *ast.Schema) string {
+	var vars, refs []string
+	for _, arg := range field.Arguments {
+		// A non-null argument with a default can be left out, so its
+		// variable must be nullable too
+		t := *arg.Type
+		if arg.DefaultValue != nil {
+			t.NonNull = false
+		}
+		vars = append(vars, fmt.Sprintf("$%s: %s", arg.Name, c.formatType(&t)))
+		refs = append(refs, fmt.Sprintf("%s: $%s", arg.Name, arg.Name))
+	}
+
+	var b strings.Builder
+	b.WriteString(opType + " " + c.capitalize(field.Name))
+	if len(vars) > 0 {
+		b.WriteString("(" + strings.Join(vars, ", ") + ")")
+	}
+	b.WriteString(" { " + field.Name)
+	if len(refs) > 0 {
+		b.WriteString("(" + strings.Join(refs, ", ") + ")")
+	}
+	if sel := c.selectionSet(field.Type, schema); sel != "" {
+		b.WriteString(" " + sel)
+	}
+	b.WriteString(" }")
+	return b.String()
+}
+
+// selectionSet selects the scalar and enum fields of an object or interface
+// type, or __typename for a union. Scalars and enums need no selection.
+func (c *GraphQLConverter) selectionSet(t *ast.Type, schema *ast.Schema) string {
+	def := schema.Types[namedType(t)]
+	if def == nil {
+		return ""
+	}
+
+	switch def.Kind {
+	case ast.Object, ast.Interface:
+		var fields []string
+		for _, field := range def.Fields {
+			if strings.HasPrefix(field.Name, "__") || c.hasRequiredArgs(field.Arguments) {
+				continue
+			}
+			if inner := schema.Types[namedType(field.Type)]; inner != nil && (inner.Kind == ast.Scalar || inner.Kind == ast.Enum) {
+				fields = append(fields, field.Name)
+			}
+		}
+		if len(fields) == 0 {
+			fields = []string{"__typename"}
+		}
+		return "{ " + strings.Join(fields, " ") + " }"
+	case ast.Union:
+		return "{ __typename }"
+	}
+	return ""
+}
+
+// namedType returns the type a possibly wrapped list type holds.
+func namedType(t *ast.Type) string {
+	for t.Elem != nil {
+		t = t.Elem
+	}
+	return t.NamedType
+}
+
+// typeToJSONSchema maps a GraphQL input type to JSON Schema. Input objects
+// are expanded inline; an input object already being expanded further up the
+// tree is cut short to avoid infinite recursion.
+func (c *GraphQLConverter) typeToJSONSchema(t *ast.Type, schema *ast.Schema, seen map[string]bool) map[string]interface{} {
+	if t.Elem != nil {
+		return map[string]interface{}{
+			"type":  "array",
+			"items": c.typeToJSONSchema(t.Elem, schema, seen),
+		}
+	}
+
+	switch t.NamedType {
+	case "String", "ID":
+		return map[string]interface{}{"type": "string"}
+	case "Int":
+		return map[string]interface{}{"type": "integer"}
+	case "Float":
+		return map[string]interface{}{"type": "number"}
+	case "Boolean":
+		return map[string]interface{}{"type": "boolean"}
+	}
+
+	def := schema.Types[t.NamedType]
+	if def == nil {
+		return map[string]interface{}{"type": "string"}
+	}
+
+	result := map[string]interface{}{}
+	if def.Description != "" {
+		result["description"] = def.Description
+	}
+
+	switch def.Kind {
+	case ast.Enum:
+		values := make([]interface{}, 0, len(def.EnumValues))
+		for _, v := range def.EnumValues {
+			values = append(values, v.Name)
+		}
+		result["type"] = "string"
+		result["enum"] = values
+	case ast.InputObject:
+		result["type"] = "object"
+		if seen[def.Name] {
+			result["description"] = fmt.Sprintf("Recursive reference to %s", def.Name)
+			return result
+		}
+		seen[def.Name] = true
+		defer delete(seen, def.Name)
+
+		props := map[string]interface{}{}
+		var required []interface{}
+		for _, field := range def.Fields {
+			prop := c.typeToJSONSchema(field.Type, schema, seen)
+			if field.Description != "" {
+				prop["description"] = field.Description
+			}
+			props[field.Name] = prop
+			if field.Type.NonNull && field.DefaultValue == nil {
+				required = append(required, field.Name)
+			}
+		}
+		result["properties"] = props
+		if len(required) > 0 {
+			result["required"] = required
+		}
+	default:
+		// Custom scalars are transported as strings
+		result["type"] = "string"
+		if _, ok := result["description"]; !ok {
+			result["description"] = fmt.Sprintf("%s scalar", def.Name)
+		}
+	}
+
+	return result
+}
+
 // Helper functions
 
 func (c *GraphQLConverter) formatType(t *ast.Type) string {
@@ -0,0 +1,86 @@
+package converter
+
+import "testing"
+
+func TestGraphQLConverter_ToolDefinitions(t *testing.T) {
+	schema := `type Query {
+  user(id: ID!): User
+  users(filter: UserFilter, first: Int = 10): [User]
+}
+
+type Mutation {
+  createUser(input: CreateUserInput!): User
+}
+
+type User {
+  id: ID!
+  name: String
+}
+
+input UserFilter {
+  role: Role
+  parent: UserFilter
+}
+
+input CreateUserInput {
+  name: String!
+  role: Role
+}
+
+enum Role {
+  ADMIN
+  USER
+}
+`
+	c := &GraphQLConverter{}
+	s, err := c.Convert([]byte(schema), &Options{})
+	if err != nil {
+		t.Fatalf("convert failed: %v", err)
+	}
+
+	if !s.Frontmatter.MCPCompatible {
+		t.Error("expected mcp_compatible to be true")
+	}
+	if len(s.Frontmatter.ToolDefinitions) != 3 {
+		t.Fatalf("expected 3 tools, got %d", len(s.Frontmatter.ToolDefinitions))
+	}
+
+	user := findTool(s.Frontmatter.ToolDefinitions, "query_user")
+	if user == nil {
+		t.Fatal("expected query_user tool")
+	}
+	if len(user.Required) != 1 || user.Required[0] != "id" {
+		t.Errorf("expected id to be required, got %v", user.Required)
+	}
+
+	users := findTool(s.Frontmatter.ToolDefinitions, "query_users")
+	if users == nil {
+		t.Fatal("expected query_users tool")
+	}
+	if len(users.Required) != 0 {
+		t.Errorf("expected no required args, got %v", users.Required)
+	}
+	filter := users.Parameters["properties"].(map[string]interface{})["filter"].(map[string]interface{})
+	filterProps := filter["properties"].(map[string]interface{})
+	role := filterProps["role"].(map[string]interface{})
+	if enum, _ := role["enum"].([]interface{}); len(enum) != 2 {
+		t.Errorf("expected Role enum values, got %v", role)
+	}
+	if parent := filterProps["parent"].(map[string]interface{}); parent["properties"] != nil {
+		t.Errorf("expected recursive input to be cut short, got %v", parent)
+	}
+
+	wantDoc := "query Users($filter: UserFilter, $first: Int) { users(filter: $filter, first: $first) { id name } }"
+	if users.Method != "POST" || users.GraphQL != wantDoc {
+		t.Errorf("expected a POSTed operation document, got %s %q", users.Method, users.GraphQL)
+	}
+
+	create := findTool(s.Frontmatter.ToolDefinitions, "mutation_createUser")
+	if create == nil {
+		t.Fatal("expected mutation_createUser tool")
+	}
+	input := create.Parameters["properties"].(map[string]interface{})["input"].(map[string]interface{})
+	if required, _ := input["required"].([]interface{}); len(required) != 1 || required[0] != "name" {
+		t.Errorf("expected name to be required in input, got %v", input["required"])
+	}
+}
@@ -0,0 +1,316 @@
+package converter
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/sanixdarker/skill-md/pkg/skill"
+)
+
+// AgentsMDConverter converts AGENTS.md instruction files to skills.
+type AgentsMDConverter struct{}
+
+func (c *AgentsMDConverter) Name() string {
+	return "agents"
+}
+
+func (c *AgentsMDConverter) CanHandle(filename string, content []byte) bool {
+	return strings.EqualFold(filepath.Base(filename), "AGENTS.md")
+}
+
+func (c *AgentsMDConverter) Convert(content []byte, opts *Options) (*skill.Skill, error) {
+	return convertInstructions(string(stripBOM(content)), "agents", opts), nil
+}
+
+// ClaudeMDConverter converts CLAUDE.md memory files to skills.
+type ClaudeMDConverter struct{}
+
+func (c *ClaudeMDConverter) Name() string {
+	return "claude"
+}
+
+func (c *ClaudeMDConverter) CanHandle(filename string, content []byte) bool {
+	base := filepath.Base(filename)
+	return strings.EqualFold(base, "CLAUDE.md") || strings.EqualFold(base, "CLAUDE.local.md")
+}
+
+func (c *ClaudeMDConverter) Convert(content []byte, opts *Options) (*skill.Skill, error) {
+	return convertInstructions(string(stripBOM(content)), "claude", opts), nil
+}
+
+// CursorRulesConverter converts Cursor rules, both the legacy .cursorrules
+// file and .mdc rule files, to skills.
+type CursorRulesConverter struct{}
+
+func (c *CursorRulesConverter) Name() string {
+	return "cursor"
+}
+
+func (c *CursorRulesConverter) CanHandle(filename string, content []byte) bool {
+	return filepath.Base(filename) == ".cursorrules" || getExtension(filename) == ".mdc"
+}
+
+func (c *CursorRulesConverter) Convert(content []byte, opts *Options) (*skill.Skill, error) {
+	text := string(stripBOM(content))
+
+	// Rule frontmatter is not valid YAML in general (globs such as **/*.ts
+	// start with an alias marker), so read it line by line
+	meta := make(map[string]string)
+	if strings.HasPrefix(text, "---\n") || strings.HasPrefix(text, "---\r\n") {
+		lines := strings.Split(text, "\n")
+		for i := 1; i < len(lines); i++ {
+			line := strings.TrimRight(lines[i], "\r")
+			if line == "---" {
+				text = strings.Join(lines[i+1:], "\n")
+				break
+			}
+			if key, value, ok := strings.Cut(line, ":"); ok {
+				value = strings.TrimSpace(value)
+				// skillmd export writes the description double-quoted
+				if unquoted, err := strconv.Unquote(value); err == nil && strings.HasPrefix(value, `"`) {
+					value = unquoted
+				} else {
+					value = strings.Trim(value, `"'`)
+				}
+				meta[strings.TrimSpace(key)] = value
+			}
+		}
+	}
+
+	s := convertInstructions(text, "cursor", opts)
+	if desc := meta["description"]; desc != "" {
+		s.Frontmatter.Description = desc
+	}
+	// Keys other than the ones read below are kept as they are
+	for key, value := range meta {
+		switch key {
+		case "", "description", "globs", "alwaysApply":
+			continue
+		}
+		if s.Frontmatter.Extensions == nil {
+			s.Frontmatter.Extensions = make(map[string]interface{})
+		}
+		s.Frontmatter.Extensions[key] = value
+	}
+
+	var globs []string
+	for _, g := range strings.Split(strings.Trim(meta["globs"], "[]"), ",") {
+		if g = strings.Trim(strings.TrimSpace(g), `"'`); g != "" {
+			globs = append(globs, "`"+g+"`")
+		}
+	}
+	switch {
+	case len(globs) > 0:
+		s.Sections = append([]skill.Section{{
+			Title:   "Scope",
+			Level:   2,
+			Content: fmt.Sprintf("Applies to files matching %s.", strings.Join(globs, ", ")),
+		}}, s.Sections...)
+	case meta["alwaysApply"] == "true":
+		s.Sections = append([]skill.Section{{
+			Title:   "Scope",
+			Level:   2,
+			Content: "Applies to every request.",
+		}}, s.Sections...)
+	}
+	return s, nil
+}
+
+// LLMSTxtConverter converts llms.txt and llms-full.txt files to skills.
+type LLMSTxtConverter struct{}
+
+func (c *LLMSTxtConverter) Name() string {
+	return "llms"
+}
+
+func (c *LLMSTxtConverter) CanHandle(filename string, content []byte) bool {
+	base := strings.ToLower(filepath.Base(filename))
+	return base == "llms.txt" || base == "llms-full.txt"
+}
+
+// llmsToolRegex matches a tool in an llms.txt "Tools" list, linked or not:
+// "- [name](url): description" or "- name: description".
+var llmsToolRegex = regexp.MustCompile(`^[-*]\s+(?:\[([\w.-]+)\]\([^)]*\)|([\w.-]+))(?::\s*(.*))?$`)
+
+func (c *LLMSTxtConverter) Convert(content []byte, opts *Options) (*skill.Skill, error) {
+	s := convertInstructions(string(stripBOM(content)), "llms", opts)
+
+	// The "> summary" blockquote opening the file is the description
+	if len(s.Sections) > 0 && s.Sections[0].Title == "Overview" {
+		var quote, rest []string
+		for _, line := range strings.Split(s.Sections[0].Content, "\n") {
+			if strings.HasPrefix(line, ">") && len(rest) == 0 {
+				quote = append(quote, strings.TrimSpace(strings.TrimPrefix(line, ">")))
+				continue
+			}
+			rest = append(rest, line)
+		}
+		if len(quote) > 0 {
+			s.Frontmatter.Description = strings.Join(strings.Fields(strings.Join(quote, " ")), " ")
+			if body := strings.TrimSpace(strings.Join(rest, "\n")); body != "" {
+				s.Sections[0].Content = body
+			} else {
+				s.Sections = s.Sections[1:]
+			}
+		}
+	}
+
+	// Tools listed by name, as skillmd export writes them
+	if len(s.Frontmatter.ToolDefinitions) == 0 {
+		if tools := s.GetSectionByTitle("Tools"); tools != nil {
+			for _, line := range strings.Split(tools.Content, "\n") {
+				m := llmsToolRegex.FindStringSubmatch(strings.TrimSpace(line))
+				if m == nil {
+					continue
+				}
+				name := m[1]
+				if name == "" {
+					name = m[2]
+				}
+				s.Frontmatter.ToolDefinitions = append(s.Frontmatter.ToolDefinitions, skill.ToolDefinition{
+					Name:        name,
+					Description: strings.TrimSpace(m[3]),
+				})
+			}
+			s.Frontmatter.MCPCompatible = len(s.Frontmatter.ToolDefinitions) > 0
+		}
+	}
+	return s, nil
+}
+
+// convertInstructions maps a markdown instruction file onto a skill. A lone
+// top-level heading names the skill and its first paragraph describes it;
+// the text before the first subheading becomes an Overview section. The
+// remaining headings are kept as they are, moved down a level when the file
+// uses several top-level headings. JSON code blocks holding function-calling
+// tools become tool definitions.
+func convertInstructions(text, sourceType string, opts *Options) *skill.Skill {
+	preamble, sections := splitPreamble(text)
+
+	title := ""
+	if len(sections) > 0 && sections[0].Level == 1 && countLevel(sections, 1) == 1 {
+		title = sections[0].Title
+		preamble = strings.TrimSpace(preamble + "\n\n" + sections[0].Content)
+		sections = sections[1:]
+	} else if countLevel(sections, 1) > 0 {
+		for i := range sections {
+			if sections[i].Level < 6 {
+				sections[i].Level++
+			}
+		}
+	}
+
+	name := title
+	if opts != nil && opts.Name != "" {
+		name = opts.Name
+	}
+	if name == "" && opts != nil && opts.SourcePath != "" {
+		name = nameFromPath(opts.SourcePath)
+	}
+	if name == "" {
+		name = "Agent Instructions"
+	}
+
+	s := skill.NewSkill(name, firstParagraph(preamble))
+	s.Frontmatter.SourceType = sourceType
+	if opts != nil && opts.SourcePath != "" {
+		s.Frontmatter.Source = opts.SourcePath
+	}
+
+	if preamble != "" && preamble != s.Frontmatter.Description {
+		s.AddSection("Overview", 2, preamble)
+	}
+	s.Sections = append(s.Sections, sections...)
+	if len(s.Sections) == 0 {
+		s.AddSection("Instructions", 2, strings.TrimSpace(text))
+	}
+
+	for _, sec := range s.Sections {
+		for _, m := range codeBlockRegex.FindAllStringSubmatch(sec.Content, -1) {
+			if strings.EqualFold(m[1], "json") {
+				if tools, ok := parseFunctionTools([]byte(m[2])); ok {
+					s.Frontmatter.ToolDefinitions = append(s.Frontmatter.ToolDefinitions, tools...)
+				}
+			}
+		}
+	}
+	s.Frontmatter.MCPCompatible = len(s.Frontmatter.ToolDefinitions) > 0
+	s.Frontmatter.HasExamples = strings.Contains(text, "```")
+
+	return s
+}
+
+// codeBlockRegex matches a fenced code block, capturing its language and
+// body.
+var codeBlockRegex = regexp.MustCompile("(?ms)^```([\\w#+-]*)[^\\n]*\\n(.*?)^```[ \\t]*$")
+
+// splitPreamble returns the text before the first heading and the sections
+// after it. Headings inside fenced code blocks are ignored.
+func splitPreamble(text string) (string, []skill.Section) {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	lines := strings.Split(text, "\n")
+	fence := ""
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			marker := trimmed[:3]
+			switch fence {
+			case "":
+				fence = marker
+			case marker:
+				fence = ""
+			}
+			continue
+		}
+		if fence == "" && headingRegex.MatchString(line) {
+			parsed, _ := skill.Parse(strings.Join(lines[i:], "\n"))
+			return strings.TrimSpace(strings.Join(lines[:i], "\n")), parsed.Sections
+		}
+	}
+	return strings.TrimSpace(text), nil
+}
+
+var headingRegex = regexp.MustCompile(`^#{1,6}\s+\S`)
+
+// countLevel counts the sections at a heading level.
+func countLevel(sections []skill.Section, level int) int {
+	n := 0
+	for _, sec := range sections {
+		if sec.Level == level {
+			n++
+		}
+	}
+	return n
+}
+
+// firstParagraph returns the first paragraph of prose in text on one line.
+func firstParagraph(text string) string {
+	for _, para := range strings.Split(text, "\n\n") {
+		para = strings.TrimSpace(para)
+		if para == "" || strings.HasPrefix(para, "```") || strings.HasPrefix(para, "<") ||
+			strings.HasPrefix(para, "-") || strings.HasPrefix(para, "*") || strings.HasPrefix(para, ">") {
+			continue
+		}
+		return strings.Join(strings.Fields(para), " ")
+	}
+	return ""
+}
+
+// nameFromPath names a skill after its file, or after the directory holding
+// it when the file name is a well-known instruction file name.
+func nameFromPath(path string) string {
+	base := filepath.Base(path)
+	name := strings.TrimSuffix(base, filepath.Ext(base))
+	switch strings.ToLower(base) {
+	case "agents.md", "claude.md", "claude.local.md", ".cursorrules", "llms.txt", "llms-full.txt":
+		name = filepath.Base(filepath.Dir(path))
+		if name == "." || name == string(filepath.Separator) {
+			return ""
+		}
+	}
+	name = strings.NewReplacer("-", " ", "_", " ", ".", " ").Replace(name)
+	return strings.Title(strings.TrimSpace(name))
+}
@@ -0,0 +1,190 @@
+package converter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sanixdarker/skill-md/pkg/skill"
+)
+
+func sectionTitles(s *skill.Skill) string {
+	var titles []string
+	for _, sec := range s.Sections {
+		titles = append(titles, strings.Repeat("#", sec.Level)+" "+sec.Title)
+	}
+	return strings.Join(titles, "|")
+}
+
+func TestManager_DetectInstructionFiles(t *testing.T) {
+	m := NewManager()
+	for filename, want := range map[string]string{
+		"repo/AGENTS.md":          "agents",
+		"CLAUDE.md":               "claude",
+		"CLAUDE.local.md":         "claude",
+		".cursorrules":            "cursor",
+		".cursor/rules/api.mdc":   "cursor",
+		"docs/llms.txt":           "llms",
+		"llms-full.txt":           "llms",
+		"README.md":               "text",
+		"tools.json":              "openai",
+		"package.json":            "text",
+		"schema/queries.graphql":  "graphql",
+		"openapi/functions.json":  "openapi",
+		"collection/postman.json": "postman",
+	} {
+		content := "# Notes\n\nSome notes."
+		switch filename {
+		case "tools.json":
+			content = `[{"type": "function", "function": {"name": "get_weather", "parameters": {"type": "object"}}}]`
+		case "package.json":
+			content = `{"name": "app", "version": "1.0.0"}`
+		case "schema/queries.graphql":
+			content = "type Query { user: User }"
+		case "openapi/functions.json":
+			content = `{"openapi": "3.0.0", "paths": {"/f": {"post": {"parameters": []}}}}`
+		case "collection/postman.json":
+			content = `{"info": {"_postman_id": "x"}, "item": []}`
+		}
+		if got := m.DetectFormat(filename, []byte(content)); got != want {
+			t.Errorf("DetectFormat(%q) = %s, want %s", filename, got, want)
+		}
+	}
+}
+
+func TestAgentsMDConverter_Convert(t *testing.T) {
+	content := "# Payments Service\n\nGuidance for agents working on payments.\n\nRun `make test` before committing.\n\n" +
+		"## Setup\n\nInstall deps.\n\n### Database\n\nUse Postgres.\n\n```bash\n# not a heading\nmake db\n```\n\n## Conventions\n\nWrap errors.\n"
+
+	s, err := (&AgentsMDConverter{}).Convert([]byte(content), &Options{SourcePath: "payments/AGENTS.md"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Frontmatter.Name != "Payments Service" || s.Frontmatter.Description != "Guidance for agents working on payments." {
+		t.Errorf("unexpected frontmatter: %+v", s.Frontmatter)
+	}
+	if s.Frontmatter.SourceType != "agents" || s.Frontmatter.Source != "payments/AGENTS.md" {
+		t.Errorf("unexpected source: %s %s", s.Frontmatter.SourceType, s.Frontmatter.Source)
+	}
+	if got, want := sectionTitles(s), "## Overview|## Setup|### Database|## Conventions"; got != want {
+		t.Errorf("sections = %s, want %s", got, want)
+	}
+	if !strings.Contains(s.Sections[0].Content, "make test") {
+		t.Errorf("overview should keep the intro: %q", s.Sections[0].Content)
+	}
+	if !strings.Contains(s.Sections[2].Content, "# not a heading") {
+		t.Errorf("code blocks should stay intact: %q", s.Sections[2].Content)
+	}
+}
+
+func TestClaudeMDConverter_Convert(t *testing.T) {
+	// Several top-level headings move down a level; the directory names the skill
+	content := "Project memory.\n\n# Build\n\nRun go build.\n\n## Flags\n\nNone.\n\n# Tools\n\n```json\n" +
+		`{"name": "deploy", "description": "Deploy the app", "parameters": {"type": "object", "properties": {"env": {"type": "string"}}, "required": ["env"]}}` +
+		"\n```\n"
+
+	s, err := (&ClaudeMDConverter{}).Convert([]byte(content), &Options{SourcePath: "/src/my-app/CLAUDE.md"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Frontmatter.Name != "My App" || s.Frontmatter.Description != "Project memory." {
+		t.Errorf("unexpected frontmatter: %+v", s.Frontmatter)
+	}
+	if got, want := sectionTitles(s), "## Build|### Flags|## Tools"; got != want {
+		t.Errorf("sections = %s, want %s", got, want)
+	}
+	if len(s.Frontmatter.ToolDefinitions) != 1 || !s.Frontmatter.MCPCompatible {
+		t.Fatalf("expected the JSON tool to be recovered, got %+v", s.Frontmatter.ToolDefinitions)
+	}
+	if tool := s.Frontmatter.ToolDefinitions[0]; tool.Name != "deploy" || len(tool.Required) != 1 || tool.Required[0] != "env" {
+		t.Errorf("unexpected tool: %+v", tool)
+	}
+}
+
+func TestCursorRulesConverter_Convert(t *testing.T) {
+	content := "---\ndescription: API handler conventions\nglobs: **/*.go, internal/api/**\nalwaysApply: false\nowner: platform\n---\n\n- Return JSON errors.\n- Log with slog.\n"
+
+	s, err := (&CursorRulesConverter{}).Convert([]byte(content), &Options{SourcePath: ".cursor/rules/api-handlers.mdc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Frontmatter.Name != "Api Handlers" || s.Frontmatter.Description != "API handler conventions" {
+		t.Errorf("unexpected frontmatter: %+v", s.Frontmatter)
+	}
+	if ext := s.Frontmatter.Extensions; len(ext) != 1 || ext["owner"] != "platform" {
+		t.Errorf("expected unknown rule keys to be kept, got %#v", ext)
+	}
+	if got, want := sectionTitles(s), "## Scope|## Overview"; got != want {
+		t.Fatalf("sections = %s, want %s", got, want)
+	}
+	if s.Sections[0].Content != "Applies to files matching `**/*.go`, `internal/api/**`." {
+		t.Errorf("unexpected scope: %q", s.Sections[0].Content)
+	}
+	if !strings.Contains(s.Sections[1].Content, "- Log with slog.") || strings.Contains(s.Sections[1].Content, "globs") {
+		t.Errorf("unexpected body: %q", s.Sections[1].Content)
+	}
+}
+
+func TestCursorRulesConverter_RoundTrip(t *testing.T) {
+	original := skill.NewSkill("Pets", `*Pets*: the #1 "store"`)
+	original.Sections = []skill.Section{{Title: "Overview", Level: 2, Content: "Pets, in a store."}}
+	out, err := skill.NewExportManager().Export("cursor", original, &skill.ExportOptions{Globs: []string{"src/**/*.ts"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := (&CursorRulesConverter{}).Convert(out, &Options{SourcePath: ".cursor/rules/pets.mdc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Frontmatter.Description != original.Frontmatter.Description {
+		t.Errorf("expected the description back, got %q", s.Frontmatter.Description)
+	}
+	if s.Sections[0].Content != "Applies to files matching `src/**/*.ts`." {
+		t.Errorf("unexpected scope: %q", s.Sections[0].Content)
+	}
+}
+
+func TestLLMSTxtConverter_RoundTrip(t *testing.T) {
+	original := &skill.Skill{
+		Frontmatter: skill.Frontmatter{
+			Name:        "Pets",
+			Version:     "1.0.0",
+			Description: "Pet store API",
+			BaseURL:     "https://api.example.com",
+			ToolDefinitions: []skill.ToolDefinition{
+				{Name: "get_pets", Description: "List pets", Path: "/pets"},
+				{Name: "feed", Description: "Feed a pet"},
+			},
+		},
+		Sections: []skill.Section{{Title: "Overview", Level: 2, Content: "Pets, in a store."}},
+	}
+	out, err := skill.NewExportManager().Export("llms", original, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := (&LLMSTxtConverter{}).Convert(out, &Options{SourcePath: "llms.txt"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Frontmatter.Name != "Pets" || s.Frontmatter.Description != "Pet store API" {
+		t.Errorf("unexpected frontmatter: %+v", s.Frontmatter)
+	}
+	if !strings.HasPrefix(sectionTitles(s), "## Overview|## Contents|## Tools") {
+		t.Errorf("unexpected sections: %s", sectionTitles(s))
+	}
+	tools := s.Frontmatter.ToolDefinitions
+	if len(tools) != 2 || tools[0].Name != "get_pets" || tools[0].Description != "List pets" || tools[1].Name != "feed" {
+		t.Errorf("unexpected tools: %+v", tools)
+	}
+}
+
+func TestConvertInstructions_Plain(t *testing.T) {
+	s := convertInstructions("Always answer in French.", "agents", nil)
+	if s.Frontmatter.Name != "Agent Instructions" || s.Frontmatter.Description != "Always answer in French." {
+		t.Errorf("unexpected frontmatter: %+v", s.Frontmatter)
+	}
+	if len(s.Sections) != 1 || s.Sections[0].Title != "Instructions" {
+		t.Errorf("expected a single Instructions section, got %s", sectionTitles(s))
+	}
+}
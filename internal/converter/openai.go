@@ -0,0 +1,185 @@
+package converter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sanixdarker/skill-md/pkg/skill"
+)
+
+// OpenAIFunctionsConverter converts OpenAI function-calling tool definitions
+// to skills.
+type OpenAIFunctionsConverter struct{}
+
+func (c *OpenAIFunctionsConverter) Name() string {
+	return "openai"
+}
+
+func (c *OpenAIFunctionsConverter) CanHandle(filename string, content []byte) bool {
+	if getExtension(filename) != ".json" {
+		return false
+	}
+	if !bytes.Contains(content, []byte(`"parameters"`)) && !bytes.Contains(content, []byte(`"function"`)) {
+		return false
+	}
+	_, ok := parseFunctionTools(content)
+	return ok
+}
+
+func (c *OpenAIFunctionsConverter) Convert(content []byte, opts *Options) (*skill.Skill, error) {
+	tools, ok := parseFunctionTools(stripBOM(content))
+	if !ok {
+		return nil, fmt.Errorf("no function-calling tool definitions found")
+	}
+
+	name := "Tools"
+	if opts != nil && opts.Name != "" {
+		name = opts.Name
+	} else if opts != nil && opts.SourcePath != "" {
+		if n := nameFromPath(opts.SourcePath); n != "" {
+			name = n
+		}
+	}
+
+	s := skill.NewSkill(name, fmt.Sprintf("%d function-calling tools.", len(tools)))
+	s.Frontmatter.SourceType = "openai"
+	if opts != nil && opts.SourcePath != "" {
+		s.Frontmatter.Source = opts.SourcePath
+	}
+	s.Frontmatter.ToolDefinitions = tools
+	s.Frontmatter.MCPCompatible = true
+
+	s.AddSection("Overview", 2, "Functions an agent can call. Each one takes a JSON object of arguments.")
+	s.AddSection("Functions", 2, "")
+	for _, tool := range tools {
+		s.AddSection(tool.Name, 3, renderFunction(tool))
+	}
+	s.AddSection("Tool Definitions", 2, renderToolDefinitionsSection(tools))
+
+	return s, nil
+}
+
+// openAITool is an entry of an OpenAI tools list. Chat Completions nests the
+// function under "function"; the Responses API and the legacy "functions"
+// list put its fields at the top level.
+type openAITool struct {
+	Type        string                 `json:"type"`
+	Function    *openAITool            `json:"function"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	Parameters  map[string]interface{} `json:"parameters"`
+}
+
+// parseFunctionTools reads OpenAI function-calling tools from JSON: a list of
+// tools, a {"tools": [...]} or {"functions": [...]} object, or a single tool.
+// It reports false when the JSON holds no function definitions.
+func parseFunctionTools(data []byte) ([]skill.ToolDefinition, bool) {
+	data = bytes.TrimSpace(data)
+	var entries []openAITool
+	switch {
+	case bytes.HasPrefix(data, []byte("[")):
+		if err := json.Unmarshal(data, &entries); err != nil {
+			return nil, false
+		}
+	case bytes.HasPrefix(data, []byte("{")):
+		var wrapper struct {
+			Tools     []openAITool `json:"tools"`
+			Functions []openAITool `json:"functions"`
+			openAITool
+		}
+		if err := json.Unmarshal(data, &wrapper); err != nil {
+			return nil, false
+		}
+		entries = append(wrapper.Tools, wrapper.Functions...)
+		if len(entries) == 0 {
+			entries = []openAITool{wrapper.openAITool}
+		}
+	default:
+		return nil, false
+	}
+
+	var tools []skill.ToolDefinition
+	for _, e := range entries {
+		fn := e
+		if e.Function != nil {
+			fn = *e.Function
+		} else if e.Type != "function" && e.Parameters == nil {
+			continue
+		}
+		if fn.Name == "" {
+			continue
+		}
+
+		tool := skill.ToolDefinition{Name: fn.Name, Description: fn.Description}
+		if len(fn.Parameters) > 0 {
+			tool.Parameters = make(map[string]interface{}, len(fn.Parameters))
+			for k, v := range fn.Parameters {
+				if k == "required" {
+					if list, ok := v.([]interface{}); ok {
+						for _, r := range list {
+							if name, ok := r.(string); ok {
+								tool.Required = append(tool.Required, name)
+							}
+						}
+						continue
+					}
+				}
+				tool.Parameters[k] = v
+			}
+		}
+		tools = append(tools, tool)
+	}
+	return tools, len(tools) > 0
+}
+
+// renderFunction documents a function and its arguments.
+func renderFunction(tool skill.ToolDefinition) string {
+	var b strings.Builder
+	if tool.Description != "" {
+		b.WriteString(tool.Description + "\n\n")
+	}
+
+	props, _ := tool.Parameters["properties"].(map[string]interface{})
+	if len(props) == 0 {
+		b.WriteString("Takes no arguments.")
+		return b.String()
+	}
+
+	required := make(map[string]bool, len(tool.Required))
+	for _, r := range tool.Required {
+		required[r] = true
+	}
+	names := make([]string, 0, len(props))
+	for name := range props {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	b.WriteString("**Arguments**:\n\n")
+	for _, name := range names {
+		prop, _ := props[name].(map[string]interface{})
+		kind, _ := prop["type"].(string)
+		if kind == "" {
+			kind = "any"
+		}
+		if required[name] {
+			kind += ", required"
+		}
+		line := fmt.Sprintf("- `%s` (%s)", name, kind)
+		if desc, _ := prop["description"].(string); desc != "" {
+			line += ": " + desc
+		}
+		if enum, ok := prop["enum"].([]interface{}); ok && len(enum) > 0 {
+			values := make([]string, len(enum))
+			for i, v := range enum {
+				values[i] = fmt.Sprintf("`%v`", v)
+			}
+			line += " One of " + strings.Join(values, ", ") + "."
+		}
+		b.WriteString(line + "\n")
+	}
+	return strings.TrimSpace(b.String())
+}
@@ -0,0 +1,83 @@
+package converter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sanixdarker/skill-md/pkg/skill"
+)
+
+func TestParseFunctionTools_Shapes(t *testing.T) {
+	for name, content := range map[string]string{
+		"chat completions": `[{"type": "function", "function": {"name": "get_weather", "description": "Weather", "parameters": {"type": "object"}}}]`,
+		"tools object":     `{"tools": [{"type": "function", "function": {"name": "get_weather", "description": "Weather"}}]}`,
+		"legacy functions": `{"functions": [{"name": "get_weather", "description": "Weather", "parameters": {"type": "object"}}]}`,
+		"responses api":    `[{"type": "function", "name": "get_weather", "description": "Weather"}]`,
+		"single tool":      `{"type": "function", "function": {"name": "get_weather", "description": "Weather"}}`,
+	} {
+		tools, ok := parseFunctionTools([]byte(content))
+		if !ok || len(tools) != 1 || tools[0].Name != "get_weather" || tools[0].Description != "Weather" {
+			t.Errorf("%s: unexpected tools %+v", name, tools)
+		}
+	}
+
+	for _, content := range []string{`{"name": "app", "version": "1.0.0"}`, `[1, 2]`, `not json`} {
+		if _, ok := parseFunctionTools([]byte(content)); ok {
+			t.Errorf("expected no tools in %s", content)
+		}
+	}
+}
+
+func TestOpenAIFunctionsConverter_RoundTrip(t *testing.T) {
+	original := &skill.Skill{Frontmatter: skill.Frontmatter{
+		Name: "Weather",
+		ToolDefinitions: []skill.ToolDefinition{{
+			Name:        "get_weather",
+			Description: "Current weather",
+			Parameters: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"city":  map[string]interface{}{"type": "string", "description": "City name"},
+					"units": map[string]interface{}{"type": "string", "enum": []interface{}{"c", "f"}},
+				},
+			},
+			Required: []string{"city"},
+		}},
+	}}
+	out, err := skill.NewExportManager().Export("openai", original, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := (&OpenAIFunctionsConverter{}).Convert(out, &Options{SourcePath: "weather-tools.json"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Frontmatter.Name != "Weather Tools" || s.Frontmatter.SourceType != "openai" || !s.Frontmatter.MCPCompatible {
+		t.Errorf("unexpected frontmatter: %+v", s.Frontmatter)
+	}
+	if len(s.Frontmatter.ToolDefinitions) != 1 {
+		t.Fatalf("expected 1 tool, got %d", len(s.Frontmatter.ToolDefinitions))
+	}
+	tool := s.Frontmatter.ToolDefinitions[0]
+	if tool.Name != "get_weather" || len(tool.Required) != 1 || tool.Required[0] != "city" {
+		t.Errorf("unexpected tool: %+v", tool)
+	}
+	if _, ok := tool.Parameters["required"]; ok {
+		t.Error("required should move out of the parameters")
+	}
+
+	fn := s.GetSectionByTitle("get_weather")
+	if fn == nil {
+		t.Fatalf("expected a section per function, got %s", sectionTitles(s))
+	}
+	for _, want := range []string{"- `city` (string, required): City name", "- `units` (string) One of `c`, `f`."} {
+		if !strings.Contains(fn.Content, want) {
+			t.Errorf("function section is missing %q:\n%s", want, fn.Content)
+		}
+	}
+
+	if _, err := (&OpenAIFunctionsConverter{}).Convert([]byte(`{"name": "app"}`), nil); err == nil {
+		t.Error("expected an error without tools")
+	}
+}
@@ -136,6 +136,11 @@ func (c *OpenAPIConverter) buildSkill(model *libopenapi.DocumentModel[v3.Documen
 	// Check if spec has examples
 	s.Frontmatter.HasExamples = c.hasExamples(&doc)
 
+	// MCP-compatible tool definitions, one per operation
+	tools := c.buildToolDefinitions(&doc)
+	s.Frontmatter.MCPCompatible = len(tools) > 0
+	s.Frontmatter.ToolDefinitions = tools
+
 	// Add Quick Start section (NEW)
 	s.AddSection("Quick Start", 2, c.buildQuickStart(&doc))
 
@@ -163,6 +168,11 @@ func (c *OpenAPIConverter) buildSkill(model *libopenapi.DocumentModel[v3.Documen
 		s.AddSection("Rate Limiting", 2, rateLimiting)
 	}
 
+	// Add Tool Definitions section
+	if len(tools) > 0 {
+		s.AddSection("Tool Definitions", 2, renderToolDefinitionsSection(tools))
+	}
+
 	// Add Best Practices section (NEW)
 	s.AddSection("Best Practices", 2, c.buildBestPracticesSection(&doc))
 
@@ -1055,6 +1065,329 @@ func (c *OpenAPIConverter) buildBestPracticesSection(doc *v3.Document) string {
 	return strings.TrimSpace(b.String())
 }
 
+// buildToolDefinitions creates one MCP tool per operation, with a JSON Schema
+// built from the path, query and header parameters plus the request body.
+func (c *OpenAPIConverter) buildToolDefinitions(doc *v3.Document) []skill.ToolDefinition {
+	tools := make([]skill.ToolDefinition, 0)
+	if doc.Paths == nil {
+		return tools
+	}
+
+	var paths []string
+	for pair := doc.Paths.PathItems.First(); pair != nil; pair = pair.Next() {
+		paths = append(paths, pair.Key())
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		item, _ := doc.Paths.PathItems.Get(path)
+		operations := []struct {
+			method string
+			op     *v3.Operation
+		}{
+			{"GET", item.Get},
+			{"POST", item.Post},
+			{"PUT", item.Put},
+			{"DELETE", item.Delete},
+			{"PATCH", item.Patch},
+			{"HEAD", item.Head},
+			{"OPTIONS", item.Options},
+		}
+		for _, entry := range operations {
+			if entry.op == nil {
+				continue
+			}
+			tools = append(tools, c.buildOperationTool(entry.method, path, item, entry.op))
+		}
+	}
+
+	return tools
+}
+
+func (c *OpenAPIConverter) buildOperationTool(method, path string, item *v3.PathItem, op *v3.Operation) skill.ToolDefinition {
+	props := map[string]interface{}{}
+	required := []string{}
+	location := map[string]string{}
+
+	// Operation-level parameters override path-level ones with the same name and location
+	params := make(map[string]*v3.Parameter)
+	var order []string
+	for _, list := range [][]*v3.Parameter{item.Parameters, op.Parameters} {
+		for _, param := range list {
+			if param == nil {
+				continue
+			}
+			key := param.In + ":" + param.Name
+			if _, exists := params[key]; !exists {
+				order = append(order, key)
+			}
+			params[key] = param
+		}
+	}
+
+	var keys []string
+	for _, key := range order {
+		if params[key].In != "cookie" {
+			keys = append(keys, key)
+		}
+	}
+	hasBody := op.RequestBody != nil && op.RequestBody.Content != nil && op.RequestBody.Content.Len() > 0
+	args, paramNames := argumentNames(keys, hasBody)
+
+	for _, key := range keys {
+		param := params[key]
+		name := args[key]
+		prop := c.schemaToJSONSchema(param.Schema, map[string]bool{})
+		if _, ok := prop["type"]; !ok {
+			prop["type"] = "string"
+		}
+		if param.Description != "" {
+			prop["description"] = param.Description
+		} else if _, ok := prop["description"]; !ok {
+			prop["description"] = fmt.Sprintf("%s parameter %s", param.In, param.Name)
+		}
+		props[name] = prop
+		location[name] = param.In
+		if param.In == "path" || (param.Required != nil && *param.Required) {
+			required = append(required, name)
+		}
+	}
+
+	if hasBody {
+		var media *v3.MediaType
+		bodyLocation := "body"
+		if jsonMedia, ok := op.RequestBody.Content.Get("application/json"); ok {
+			media = jsonMedia
+		} else if first := op.RequestBody.Content.First(); first != nil {
+			media = first.Value()
+			switch first.Key() {
+			case "application/x-www-form-urlencoded":
+				bodyLocation = "form"
+			case "multipart/form-data":
+				bodyLocation = "multipart"
+			}
+		}
+		if media != nil {
+			body := c.schemaToJSONSchema(media.Schema, map[string]bool{})
+			if _, ok := body["type"]; !ok {
+				body["type"] = "object"
+			}
+			if op.RequestBody.Description != "" {
+				body["description"] = op.RequestBody.Description
+			} else if _, ok := body["description"]; !ok {
+				body["description"] = "Request body"
+			}
+			props["body"] = body
+			location["body"] = bodyLocation
+			if op.RequestBody.Required != nil && *op.RequestBody.Required {
+				required = append(required, "body")
+			}
+		}
+	}
+
+	return skill.ToolDefinition{
+		Name:        operationToolName(method, path, op.OperationId),
+		Description: truncate(operationToolDescription(method, path, op.Summary, op.Description), 200),
+		Parameters: map[string]interface{}{
+			"type":       "object",
+			"properties": props,
+		},
+		Required:   required,
+		Method:     method,
+		Path:       path,
+		Location:   location,
+		ParamNames: paramNames,
+	}
+}
+
+// argumentNames names the tool arguments of the parameters behind keys, which
+// have the "in:name" form. A parameter keeps its name unless another one in a
+// different location shares it, or it is called "body" and the operation has
+// a request body; it is then prefixed with its location, as in query_id. The
+// second map records the wire name of each renamed argument.
+func argumentNames(keys []string, hasBody bool) (map[string]string, map[string]string) {
+	count := map[string]int{}
+	for _, key := range keys {
+		_, name, _ := strings.Cut(key, ":")
+		count[name]++
+	}
+
+	taken := map[string]bool{}
+	if hasBody {
+		taken["body"] = true
+	}
+	args := make(map[string]string, len(keys))
+	for _, key := range keys {
+		if _, name, _ := strings.Cut(key, ":"); count[name] == 1 && !taken[name] {
+			args[key] = name
+			taken[name] = true
+		}
+	}
+
+	var renamed map[string]string
+	for _, key := range keys {
+		if _, ok := args[key]; ok {
+			continue
+		}
+		in, name, _ := strings.Cut(key, ":")
+		arg := in + "_" + name
+		for i := 2; taken[arg]; i++ {
+			arg = fmt.Sprintf("%s_%s_%d", in, name, i)
+		}
+		args[key] = arg
+		taken[arg] = true
+		if renamed == nil {
+			renamed = map[string]string{}
+		}
+		renamed[arg] = name
+	}
+	return args, renamed
+}
+
+// schemaToJSONSchema converts an OpenAPI schema into a plain JSON Schema map.
+// References are resolved inline; a reference already being expanded further
+// up the tree is cut short to avoid infinite recursion.
+func (c *OpenAPIConverter) schemaToJSONSchema(proxy *base.SchemaProxy, seen map[string]bool) map[string]interface{} {
+	result := map[string]interface{}{}
+	if proxy == nil {
+		return result
+	}
+
+	if proxy.IsReference() {
+		ref := proxy.GetReference()
+		if seen[ref] {
+			result["type"] = "object"
+			result["description"] = fmt.Sprintf("Recursive reference to %s", ref[strings.LastIndex(ref, "/")+1:])
+			return result
+		}
+		seen[ref] = true
+		defer delete(seen, ref)
+	}
+
+	schema := proxy.Schema()
+	if schema == nil {
+		return result
+	}
+
+	for _, t := range schema.Type {
+		if t != "null" {
+			result["type"] = t
+			break
+		}
+	}
+	if schema.Description != "" {
+		result["description"] = schema.Description
+	}
+	if schema.Format != "" {
+		result["format"] = schema.Format
+	}
+	if schema.Pattern != "" {
+		result["pattern"] = schema.Pattern
+	}
+	if schema.Minimum != nil {
+		result["minimum"] = *schema.Minimum
+	}
+	if schema.Maximum != nil {
+		result["maximum"] = *schema.Maximum
+	}
+	if schema.MinLength != nil {
+		result["minLength"] = *schema.MinLength
+	}
+	if schema.MaxLength != nil {
+		result["maxLength"] = *schema.MaxLength
+	}
+	if len(schema.Enum) > 0 {
+		// Decode each value so integer and boolean enums keep their type
+		enum := make([]interface{}, 0, len(schema.Enum))
+		for _, e := range schema.Enum {
+			var v interface{}
+			if e != nil && e.Decode(&v) == nil {
+				enum = append(enum, v)
+			}
+		}
+		result["enum"] = enum
+	}
+
+	if schema.Items != nil && schema.Items.IsA() {
+		result["items"] = c.schemaToJSONSchema(schema.Items.A, seen)
+	}
+
+	properties := map[string]interface{}{}
+	var required []interface{}
+	if schema.Properties != nil {
+		for pair := schema.Properties.First(); pair != nil; pair = pair.Next() {
+			properties[pair.Key()] = c.schemaToJSONSchema(pair.Value(), seen)
+		}
+	}
+	for _, r := range schema.Required {
+		required = append(required, r)
+	}
+
+	// allOf members are flattened into a single object schema
+	for _, member := range schema.AllOf {
+		merged := c.schemaToJSONSchema(member, seen)
+		if memberProps, ok := merged["properties"].(map[string]interface{}); ok {
+			for k, v := range memberProps {
+				properties[k] = v
+			}
+		}
+		if memberRequired, ok := merged["required"].([]interface{}); ok {
+			required = append(required, memberRequired...)
+		}
+		if _, ok := result["description"]; !ok && merged["description"] != nil {
+			result["description"] = merged["description"]
+		}
+	}
+
+	for key, members := range map[string][]*base.SchemaProxy{"oneOf": schema.OneOf, "anyOf": schema.AnyOf} {
+		if len(members) == 0 {
+			continue
+		}
+		variants := make([]interface{}, 0, len(members))
+		for _, member := range members {
+			variants = append(variants, c.schemaToJSONSchema(member, seen))
+		}
+		result[key] = variants
+	}
+
+	if len(properties) > 0 {
+		result["properties"] = properties
+		if _, ok := result["type"]; !ok {
+			result["type"] = "object"
+		}
+	}
+	if len(required) > 0 {
+		result["required"] = required
+	}
+
+	return result
+}
+
+// operationToolName derives a tool name from the operationId, falling back to
+// the HTTP method and path (e.g. GET /users/{id} -> get_users_id).
+func operationToolName(method, path, operationID string) string {
+	if operationID != "" {
+		return skill.SanitizeToolName(operationID)
+	}
+	name := skill.SanitizeToolName(path)
+	if name == "" {
+		return strings.ToLower(method)
+	}
+	return fmt.Sprintf("%s_%s", strings.ToLower(method), name)
+}
+
+// operationToolDescription picks the summary, then the first line of the
+// description, then "METHOD path" as the tool description.
+func operationToolDescription(method, path, summary, description string) string {
+	if summary != "" {
+		return summary
+	}
+	if description != "" {
+		return strings.TrimSpace(strings.SplitN(description, "\n", 2)[0])
+	}
+	return fmt.Sprintf("%s %s", method, path)
+}
+
 // buildSkillFromV2 builds a skill from a Swagger 2.x (OpenAPI 2.0) model.
 func (c *OpenAPIConverter) buildSkillFromV2(model *libopenapi.DocumentModel[v2.Swagger], opts *Options) *skill.Skill {
 	doc := model.Model
@@ -1113,6 +1446,11 @@ func (c *OpenAPIConverter) buildSkillFromV2(model *libopenapi.DocumentModel[v2.S
 	// Determine difficulty based on complexity
 	s.Frontmatter.Difficulty = c.determineDifficultyV2(&doc, endpointCount)
 
+	// MCP-compatible tool definitions, one per operation
+	tools := c.buildToolDefinitionsV2(&doc)
+	s.Frontmatter.MCPCompatible = len(tools) > 0
+	s.Frontmatter.ToolDefinitions = tools
+
 	// Add Quick Start section
 	s.AddSection("Quick Start", 2, c.buildQuickStartV2(&doc))
 
@@ -1135,6 +1473,11 @@ func (c *OpenAPIConverter) buildSkillFromV2(model *libopenapi.DocumentModel[v2.S
 	// Add Error Handling section
 	s.AddSection("Error Handling", 2, c.buildErrorHandlingSectionV2(&doc))
 
+	// Add Tool Definitions section
+	if len(tools) > 0 {
+		s.AddSection("Tool Definitions", 2, renderToolDefinitionsSection(tools))
+	}
+
 	// Add Best Practices section
 	s.AddSection("Best Practices", 2, c.buildBestPracticesSectionV2(&doc))
 
@@ -1665,3 +2008,203 @@ func (c *OpenAPIConverter) buildBestPracticesSectionV2(doc *v2.Swagger) string {
 
 	return strings.TrimSpace(b.String())
 }
+
+// buildToolDefinitionsV2 creates one MCP tool per Swagger 2.x operation.
+func (c *OpenAPIConverter) buildToolDefinitionsV2(doc *v2.Swagger) []skill.ToolDefinition {
+	tools := make([]skill.ToolDefinition, 0)
+	if doc.Paths == nil {
+		return tools
+	}
+
+	var paths []string
+	for pair := doc.Paths.PathItems.First(); pair != nil; pair = pair.Next() {
+		paths = append(paths, pair.Key())
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		item, _ := doc.Paths.PathItems.Get(path)
+		operations := []struct {
+			method string
+			op     *v2.Operation
+		}{
+			{"GET", item.Get},
+			{"POST", item.Post},
+			{"PUT", item.Put},
+			{"DELETE", item.Delete},
+			{"PATCH", item.Patch},
+			{"HEAD", item.Head},
+			{"OPTIONS", item.Options},
+		}
+		for _, entry := range operations {
+			if entry.op == nil {
+				continue
+			}
+			consumes := entry.op.Consumes
+			if len(consumes) == 0 {
+				consumes = doc.Consumes
+			}
+			tools = append(tools, c.buildOperationToolV2(entry.method, path, item, entry.op, consumes))
+		}
+	}
+
+	return tools
+}
+
+// buildOperationToolV2 builds the tool of a Swagger 2.x operation. consumes
+// lists the media types the operation accepts; formData parameters are sent
+// as multipart/form-data when it includes that type or a parameter is a file,
+// and URL-encoded otherwise.
+func (c *OpenAPIConverter) buildOperationToolV2(method, path string, item *v2.PathItem, op *v2.Operation, consumes []string) skill.ToolDefinition {
+	props := map[string]interface{}{}
+	required := []string{}
+	location := map[string]string{}
+
+	params := make(map[string]*v2.Parameter)
+	var order []string
+	for _, list := range [][]*v2.Parameter{item.Parameters, op.Parameters} {
+		for _, param := range list {
+			if param == nil {
+				continue
+			}
+			key := param.In + ":" + param.Name
+			if _, exists := params[key]; !exists {
+				order = append(order, key)
+			}
+			params[key] = param
+		}
+	}
+
+	formLocation := "form"
+	for _, mediaType := range consumes {
+		if strings.HasPrefix(mediaType, "multipart/form-data") {
+			formLocation = "multipart"
+		}
+	}
+
+	var keys []string
+	hasBody := false
+	for _, key := range order {
+		switch param := params[key]; {
+		case param.In == "body":
+			hasBody = true
+			continue
+		case param.In == "formData" && param.Type == "file":
+			formLocation = "multipart"
+		}
+		keys = append(keys, key)
+	}
+	args, paramNames := argumentNames(keys, hasBody)
+
+	for _, key := range order {
+		param := params[key]
+
+		var prop map[string]interface{}
+		name := args[key]
+		if param.In == "body" {
+			// Swagger 2.x carries the request body as a single "body" parameter
+			name = "body"
+			prop = c.schemaToJSONSchema(param.Schema, map[string]bool{})
+			if _, ok := prop["type"]; !ok {
+				prop["type"] = "object"
+			}
+		} else {
+			prop = c.parameterToJSONSchemaV2(param)
+		}
+
+		if param.Description != "" {
+			prop["description"] = param.Description
+		} else if _, ok := prop["description"]; !ok {
+			prop["description"] = fmt.Sprintf("%s parameter %s", param.In, param.Name)
+		}
+		props[name] = prop
+		switch param.In {
+		case "path", "query", "header", "body":
+			location[name] = param.In
+		case "formData":
+			location[name] = formLocation
+		}
+		if param.In == "path" || (param.Required != nil && *param.Required) {
+			required = append(required, name)
+		}
+	}
+
+	return skill.ToolDefinition{
+		Name:        operationToolName(method, path, op.OperationId),
+		Description: truncate(operationToolDescription(method, path, op.Summary, op.Description), 200),
+		Parameters: map[string]interface{}{
+			"type":       "object",
+			"properties": props,
+		},
+		Required:   required,
+		Method:     method,
+		Path:       path,
+		Location:   location,
+		ParamNames: paramNames,
+	}
+}
+
+// parameterToJSONSchemaV2 converts a non-body Swagger 2.x parameter, whose
+// type information lives directly on the parameter, into JSON Schema.
+func (c *OpenAPIConverter) parameterToJSONSchemaV2(param *v2.Parameter) map[string]interface{} {
+	prop := map[string]interface{}{
+		"type": "string",
+	}
+	if param.Type != "" && param.Type != "file" {
+		prop["type"] = param.Type
+	}
+	if param.Type == "file" {
+		prop["format"] = "binary"
+	} else if param.Format != "" {
+		prop["format"] = param.Format
+	}
+	if param.Pattern != "" {
+		prop["pattern"] = param.Pattern
+	}
+	if param.Minimum != nil {
+		prop["minimum"] = *param.Minimum
+	}
+	if param.Maximum != nil {
+		prop["maximum"] = *param.Maximum
+	}
+	if len(param.Enum) > 0 {
+		enum := make([]interface{}, 0, len(param.Enum))
+		for _, e := range param.Enum {
+			var v interface{}
+			if e != nil && e.Decode(&v) == nil {
+				enum = append(enum, v)
+			}
+		}
+		prop["enum"] = enum
+	}
+	if param.Items != nil {
+		prop["items"] = c.itemsToJSONSchemaV2(param.Items)
+	}
+	return prop
+}
+
+func (c *OpenAPIConverter) itemsToJSONSchemaV2(items *v2.Items) map[string]interface{} {
+	prop := map[string]interface{}{
+		"type": "string",
+	}
+	if items.Type != "" {
+		prop["type"] = items.Type
+	}
+	if items.Format != "" {
+		prop["format"] = items.Format
+	}
+	if len(items.Enum) > 0 {
+		enum := make([]interface{}, 0, len(items.Enum))
+		for _, e := range items.Enum {
+			var v interface{}
+			if e != nil && e.Decode(&v) == nil {
+				enum = append(enum, v)
+			}
+		}
+		prop["enum"] = enum
+	}
+	if items.Items != nil {
+		prop["items"] = c.itemsToJSONSchemaV2(items.Items)
+	}
+	return prop
+}
@@ -0,0 +1,391 @@
+package converter
+
+import (
+	"os"
+	"testing"
+
+	"github.com/sanixdarker/skill-md/pkg/skill"
+)
+
+func findTool(tools []skill.ToolDefinition, name string) *skill.ToolDefinition {
+	for i := range tools {
+		if tools[i].Name == name {
+			return &tools[i]
+		}
+	}
+	return nil
+}
+
+func TestOpenAPIConverter_ToolDefinitions(t *testing.T) {
+	content, err := os.ReadFile("../../testdata/sample.yaml")
+	if err != nil {
+		t.Fatalf("failed to read testdata: %v", err)
+	}
+
+	c := &OpenAPIConverter{}
+	s, err := c.Convert(content, &Options{})
+	if err != nil {
+		t.Fatalf("convert failed: %v", err)
+	}
+
+	if !s.Frontmatter.MCPCompatible {
+		t.Error("expected mcp_compatible to be true")
+	}
+	if len(s.Frontmatter.ToolDefinitions) != 3 {
+		t.Fatalf("expected 3 tools, got %d", len(s.Frontmatter.ToolDefinitions))
+	}
+
+	get := findTool(s.Frontmatter.ToolDefinitions, "get_users_id")
+	if get == nil {
+		t.Fatal("expected get_users_id tool")
+	}
+	if len(get.Required) != 1 || get.Required[0] != "id" {
+		t.Errorf("expected id to be required, got %v", get.Required)
+	}
+	if get.Location["id"] != "path" {
+		t.Errorf("expected id to be located in the path, got %v", get.Location)
+	}
+
+	// The request body references #/components/schemas/User and must be inlined
+	post := findTool(s.Frontmatter.ToolDefinitions, "post_users")
+	if post == nil {
+		t.Fatal("expected post_users tool")
+	}
+	props := post.Parameters["properties"].(map[string]interface{})
+	body, ok := props["body"].(map[string]interface{})
+	if !ok {
+		t.Fatal("expected body parameter")
+	}
+	bodyProps, ok := body["properties"].(map[string]interface{})
+	if !ok || bodyProps["email"] == nil {
+		t.Errorf("expected resolved User properties in body, got %v", body)
+	}
+	required, _ := body["required"].([]interface{})
+	if len(required) != 2 {
+		t.Errorf("expected 2 required body fields, got %v", body["required"])
+	}
+	if post.Location["body"] != "body" {
+		t.Errorf("expected JSON body location, got %v", post.Location)
+	}
+
+	if s.GetSectionByTitle("Tool Definitions") == nil {
+		t.Error("expected Tool Definitions section")
+	}
+}
+
+func TestOpenAPIConverter_ToolDefinitions_RecursiveRef(t *testing.T) {
+	spec := `openapi: "3.0.0"
+info:
+  title: Tree API
+  version: "1.0.0"
+paths:
+  /nodes:
+    post:
+      operationId: createNode
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Node'
+      responses:
+        "201":
+          description: Created
+components:
+  schemas:
+    Node:
+      type: object
+      properties:
+        name:
+          type: string
+        children:
+          type: array
+          items:
+            $ref: '#/components/schemas/Node'
+`
+	c := &OpenAPIConverter{}
+	s, err := c.Convert([]byte(spec), &Options{})
+	if err != nil {
+		t.Fatalf("convert failed: %v", err)
+	}
+
+	tool := findTool(s.Frontmatter.ToolDefinitions, "createnode")
+	if tool == nil {
+		t.Fatalf("expected createnode tool, got %v", s.Frontmatter.ToolDefinitions)
+	}
+	if len(tool.Required) != 1 || tool.Required[0] != "body" {
+		t.Errorf("expected body to be required, got %v", tool.Required)
+	}
+
+	body := tool.Parameters["properties"].(map[string]interface{})["body"].(map[string]interface{})
+	children := body["properties"].(map[string]interface{})["children"].(map[string]interface{})
+	items := children["items"].(map[string]interface{})
+	if items["type"] != "object" || items["properties"] != nil {
+		t.Errorf("expected recursive reference to be cut short, got %v", items)
+	}
+}
+
+func TestOpenAPIConverter_ToolDefinitions_Swagger2(t *testing.T) {
+	spec := `swagger: "2.0"
+info:
+  title: Pet API
+  version: "1.0.0"
+host: api.example.com
+basePath: /v1
+paths:
+  /pets/{petId}:
+    parameters:
+      - name: petId
+        in: path
+        required: true
+        type: integer
+    put:
+      operationId: updatePet
+      summary: Update a pet
+      parameters:
+        - name: body
+          in: body
+          required: true
+          schema:
+            $ref: '#/definitions/Pet'
+        - name: tags
+          in: query
+          type: array
+          items:
+            type: string
+      responses:
+        "200":
+          description: OK
+definitions:
+  Pet:
+    type: object
+    required:
+      - name
+    properties:
+      name:
+        type: string
+      status:
+        type: string
+        enum: [available, sold]
+`
+	c := &OpenAPIConverter{}
+	s, err := c.Convert([]byte(spec), &Options{})
+	if err != nil {
+		t.Fatalf("convert failed: %v", err)
+	}
+
+	tool := findTool(s.Frontmatter.ToolDefinitions, "updatepet")
+	if tool == nil {
+		t.Fatalf("expected updatepet tool, got %v", s.Frontmatter.ToolDefinitions)
+	}
+	if tool.Description != "Update a pet" {
+		t.Errorf("expected summary as description, got %q", tool.Description)
+	}
+	if len(tool.Required) != 2 {
+		t.Errorf("expected petId and body to be required, got %v", tool.Required)
+	}
+
+	props := tool.Parameters["properties"].(map[string]interface{})
+	if petID := props["petId"].(map[string]interface{}); petID["type"] != "integer" {
+		t.Errorf("expected integer petId, got %v", petID)
+	}
+	if tags := props["tags"].(map[string]interface{}); tags["type"] != "array" {
+		t.Errorf("expected array tags, got %v", tags)
+	}
+	body := props["body"].(map[string]interface{})
+	status := body["properties"].(map[string]interface{})["status"].(map[string]interface{})
+	if enum, _ := status["enum"].([]interface{}); len(enum) != 2 {
+		t.Errorf("expected status enum from definition, got %v", status)
+	}
+}
+
+func TestOpenAPIConverter_ToolDefinitions_TypedEnums(t *testing.T) {
+	specs := map[string]string{
+		"openapi 3": `openapi: "3.0.0"
+info:
+  title: Page API
+  version: "1.0.0"
+paths:
+  /pages:
+    get:
+      operationId: listPages
+      parameters:
+        - name: size
+          in: query
+          schema:
+            type: integer
+            enum: [10, 20]
+      responses:
+        "200":
+          description: OK
+`,
+		"swagger 2": `swagger: "2.0"
+info:
+  title: Page API
+  version: "1.0.0"
+paths:
+  /pages:
+    get:
+      operationId: listPages
+      parameters:
+        - name: size
+          in: query
+          type: integer
+          enum: [10, 20]
+      responses:
+        "200":
+          description: OK
+`,
+	}
+	for name, spec := range specs {
+		c := &OpenAPIConverter{}
+		s, err := c.Convert([]byte(spec), &Options{})
+		if err != nil {
+			t.Fatalf("%s: convert failed: %v", name, err)
+		}
+		tool := findTool(s.Frontmatter.ToolDefinitions, "listpages")
+		if tool == nil {
+			t.Fatalf("%s: expected listpages tool, got %v", name, s.Frontmatter.ToolDefinitions)
+		}
+		size := tool.Parameters["properties"].(map[string]interface{})["size"].(map[string]interface{})
+		enum, _ := size["enum"].([]interface{})
+		if len(enum) != 2 || enum[0] != 10 || enum[1] != 20 {
+			t.Errorf("%s: expected integer enum values, got %#v", name, size["enum"])
+		}
+	}
+}
+
+func TestOpenAPIConverter_ToolDefinitions_Swagger2FormData(t *testing.T) {
+	spec := `swagger: "2.0"
+info:
+  title: Pet API
+  version: "1.0.0"
+consumes:
+  - application/x-www-form-urlencoded
+paths:
+  /pets/{petId}:
+    post:
+      operationId: renamePet
+      parameters:
+        - name: petId
+          in: path
+          required: true
+          type: integer
+        - name: name
+          in: formData
+          required: true
+          type: string
+      responses:
+        "200":
+          description: OK
+  /pets/{petId}/photo:
+    post:
+      operationId: uploadPhoto
+      parameters:
+        - name: petId
+          in: path
+          required: true
+          type: integer
+        - name: caption
+          in: formData
+          type: string
+        - name: file
+          in: formData
+          type: file
+      responses:
+        "200":
+          description: OK
+`
+	c := &OpenAPIConverter{}
+	s, err := c.Convert([]byte(spec), &Options{})
+	if err != nil {
+		t.Fatalf("convert failed: %v", err)
+	}
+
+	rename := findTool(s.Frontmatter.ToolDefinitions, "renamepet")
+	if rename == nil {
+		t.Fatalf("expected renamepet tool, got %v", s.Frontmatter.ToolDefinitions)
+	}
+	if rename.Location["name"] != "form" || rename.Location["petId"] != "path" {
+		t.Errorf("expected name to be sent URL-encoded, got %v", rename.Location)
+	}
+
+	upload := findTool(s.Frontmatter.ToolDefinitions, "uploadphoto")
+	if upload == nil {
+		t.Fatalf("expected uploadphoto tool, got %v", s.Frontmatter.ToolDefinitions)
+	}
+	if upload.Location["caption"] != "multipart" || upload.Location["file"] != "multipart" {
+		t.Errorf("expected a file upload to be sent as multipart, got %v", upload.Location)
+	}
+}
+
+func TestOpenAPIConverter_ToolDefinitions_NameCollisions(t *testing.T) {
+	spec := `openapi: "3.0.0"
+info:
+  title: Doc API
+  version: "1.0.0"
+paths:
+  /docs/{id}:
+    put:
+      operationId: updateDoc
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+        - name: id
+          in: query
+          schema:
+            type: string
+        - name: body
+          in: query
+          schema:
+            type: string
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+      responses:
+        "200":
+          description: OK
+`
+	c := &OpenAPIConverter{}
+	s, err := c.Convert([]byte(spec), &Options{})
+	if err != nil {
+		t.Fatalf("convert failed: %v", err)
+	}
+
+	tool := findTool(s.Frontmatter.ToolDefinitions, "updatedoc")
+	if tool == nil {
+		t.Fatalf("expected updatedoc tool, got %v", s.Frontmatter.ToolDefinitions)
+	}
+	props := tool.Parameters["properties"].(map[string]interface{})
+	if len(props) != 4 {
+		t.Errorf("expected 4 distinct arguments, got %v", props)
+	}
+	if body, _ := props["body"].(map[string]interface{}); body["type"] != "object" {
+		t.Errorf("expected body to stay the request body, got %v", props["body"])
+	}
+
+	want := map[string][2]string{
+		"path_id":    {"path", "id"},
+		"query_id":   {"query", "id"},
+		"query_body": {"query", "body"},
+	}
+	for arg, w := range want {
+		if props[arg] == nil {
+			t.Errorf("expected argument %s, got %v", arg, props)
+		}
+		if tool.Location[arg] != w[0] || tool.ParamName(arg) != w[1] {
+			t.Errorf("expected %s to be sent as %s %q, got %q %q", arg, w[0], w[1], tool.Location[arg], tool.ParamName(arg))
+		}
+	}
+	if tool.Location["body"] != "body" {
+		t.Errorf("expected JSON body location, got %v", tool.Location)
+	}
+	if len(tool.Required) != 1 || tool.Required[0] != "path_id" {
+		t.Errorf("expected path_id to be required, got %v", tool.Required)
+	}
+}
@@ -53,6 +53,7 @@ func (c *PlainTextConverter) Convert(content []byte, opts *Options) (*skill.Skil
 			if existingSkill.Frontmatter.Description != "" {
 				s.Frontmatter.Description = existingSkill.Frontmatter.Description
 			}
+			s.Frontmatter.Extensions = existingSkill.Frontmatter.Extensions
 			return s, nil
 		}
 	}
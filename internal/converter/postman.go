@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/sanixdarker/skill-md/pkg/skill"
@@ -67,6 +68,11 @@ type PostmanURL struct {
 		Description string `json:"description"`
 		Disabled    bool   `json:"disabled,omitempty"`
 	} `json:"query"`
+	Variable []struct {
+		Key         string `json:"key"`
+		Value       string `json:"value"`
+		Description string `json:"description"`
+	} `json:"variable,omitempty"`
 }
 
 type PostmanHeader struct {
@@ -155,6 +161,11 @@ func (c *PostmanConverter) buildSkill(col *PostmanCollection, opts *Options) *sk
 	endpointCount := c.countEndpoints(col.Items)
 	s.Frontmatter.EndpointCount = endpointCount
 
+	// Base URL, unless it is still a Postman variable placeholder
+	if baseURL := c.extractBaseURL(col); !strings.Contains(baseURL, "{{") {
+		s.Frontmatter.BaseURL = baseURL
+	}
+
 	// Extract auth methods
 	if col.Auth != nil {
 		s.Frontmatter.AuthMethods = []string{col.Auth.Type}
@@ -172,6 +183,11 @@ func (c *PostmanConverter) buildSkill(col *PostmanCollection, opts *Options) *sk
 	// Check for examples (responses)
 	s.Frontmatter.HasExamples = c.hasExamples(col.Items)
 
+	// MCP-compatible tool definitions, one per request
+	tools := c.buildToolDefinitions(col.Items, nil, map[string]int{})
+	s.Frontmatter.MCPCompatible = len(tools) > 0
+	s.Frontmatter.ToolDefinitions = tools
+
 	// Add Quick Start section
 	s.AddSection("Quick Start", 2, c.buildQuickStart(col))
 
@@ -191,6 +207,11 @@ func (c *PostmanConverter) buildSkill(col *PostmanCollection, opts *Options) *sk
 	// Add endpoints
 	s.AddSection("Endpoints", 2, c.buildEndpointsSection(col.Items, 0, col))
 
+	// Add tool definitions
+	if len(tools) > 0 {
+		s.AddSection("Tool Definitions", 2, renderToolDefinitionsSection(tools))
+	}
+
 	// Add best practices
 	s.AddSection("Best Practices", 2, c.buildBestPracticesSection())
 
@@ -210,6 +231,220 @@ func (c *PostmanConverter) countEndpoints(items []PostmanItem) int {
 	return count
 }
 
+// postmanVariableRegex matches {{name}} variables in URL path segments.
+var postmanVariableRegex = regexp.MustCompile(`\{\{\s*([^{}]+?)\s*\}\}`)
+
+// buildToolDefinitions walks the collection (including folders) and creates
+// one MCP tool per request. The seen map counts tool names so that repeated
+// requests to the same endpoint get a numeric suffix.
+func (c *PostmanConverter) buildToolDefinitions(items []PostmanItem, tools []skill.ToolDefinition, seen map[string]int) []skill.ToolDefinition {
+	if tools == nil {
+		tools = make([]skill.ToolDefinition, 0)
+	}
+
+	for i := range items {
+		item := &items[i]
+		if len(item.Items) > 0 {
+			tools = c.buildToolDefinitions(item.Items, tools, seen)
+		}
+		if item.Request == nil {
+			continue
+		}
+
+		tool := c.buildRequestTool(item)
+		seen[tool.Name]++
+		if n := seen[tool.Name]; n > 1 {
+			tool.Name = fmt.Sprintf("%s_%d", tool.Name, n)
+		}
+		tools = append(tools, tool)
+	}
+
+	return tools
+}
+
+func (c *PostmanConverter) buildRequestTool(item *PostmanItem) skill.ToolDefinition {
+	req := item.Request
+	props := map[string]interface{}{}
+	required := []string{}
+	location := map[string]string{}
+
+	variableDescriptions := make(map[string]string)
+	for _, v := range req.URL.Variable {
+		variableDescriptions[v.Key] = v.Description
+	}
+
+	// Parameters are keyed "in:name" and described before they are named,
+	// so arguments sharing a name across locations can be told apart
+	descriptions := map[string]string{}
+	var keys []string
+	addParam := func(in, name, desc string) {
+		key := in + ":" + name
+		if _, ok := descriptions[key]; ok {
+			return
+		}
+		descriptions[key] = desc
+		keys = append(keys, key)
+	}
+
+	// Path variables (":id" and "{{id}}" segments) are always required
+	var pathSegments []string
+	for _, segment := range req.URL.Path {
+		var names []string
+		if strings.HasPrefix(segment, ":") {
+			names = append(names, strings.TrimPrefix(segment, ":"))
+			segment = "{" + names[0] + "}"
+		} else {
+			segment = postmanVariableRegex.ReplaceAllStringFunc(segment, func(m string) string {
+				name := postmanVariableRegex.FindStringSubmatch(m)[1]
+				names = append(names, name)
+				return "{" + name + "}"
+			})
+		}
+		for _, name := range names {
+			desc := variableDescriptions[name]
+			if desc == "" {
+				desc = fmt.Sprintf("Path parameter %s", name)
+			}
+			addParam("path", name, desc)
+		}
+		pathSegments = append(pathSegments, segment)
+	}
+
+	for _, q := range req.URL.Query {
+		if q.Disabled {
+			continue
+		}
+		desc := q.Description
+		if desc == "" {
+			desc = fmt.Sprintf("Query parameter %s", q.Key)
+		}
+		addParam("query", q.Key, desc)
+	}
+
+	var body map[string]interface{}
+	bodyLocation := "body"
+	if req.Body != nil {
+		switch req.Body.Mode {
+		case "raw":
+			var sample interface{}
+			if err := json.Unmarshal([]byte(req.Body.Raw), &sample); err == nil {
+				body = jsonSchemaFromSample(sample)
+				body["description"] = "Request body"
+			} else if strings.TrimSpace(req.Body.Raw) != "" {
+				body = map[string]interface{}{
+					"type":        "string",
+					"description": "Raw request body",
+				}
+			}
+		case "formdata", "urlencoded":
+			fields := req.Body.FormData
+			if req.Body.Mode == "urlencoded" {
+				fields = req.Body.URLEncoded
+			}
+			formProps := map[string]interface{}{}
+			for _, f := range fields {
+				prop := map[string]interface{}{
+					"type": "string",
+				}
+				if f.Type == "file" {
+					prop["format"] = "binary"
+				}
+				if f.Description != "" {
+					prop["description"] = f.Description
+				}
+				formProps[f.Key] = prop
+			}
+			if len(formProps) > 0 {
+				body = map[string]interface{}{
+					"type":        "object",
+					"description": "Form fields",
+					"properties":  formProps,
+				}
+				bodyLocation = "form"
+				if req.Body.Mode == "formdata" {
+					bodyLocation = "multipart"
+				}
+			}
+		}
+	}
+
+	args, paramNames := argumentNames(keys, body != nil)
+	for _, key := range keys {
+		in, _, _ := strings.Cut(key, ":")
+		name := args[key]
+		props[name] = map[string]interface{}{
+			"type":        "string",
+			"description": descriptions[key],
+		}
+		location[name] = in
+		if in == "path" {
+			required = append(required, name)
+		}
+	}
+	if body != nil {
+		props["body"] = body
+		location["body"] = bodyLocation
+		required = append(required, "body")
+	}
+
+	path := "/" + strings.Join(pathSegments, "/")
+	name := skill.SanitizeToolName(strings.ReplaceAll(strings.Join(pathSegments, "/"), " ", "_"))
+	if name == "" {
+		name = skill.SanitizeToolName(strings.ReplaceAll(item.Name, " ", "_"))
+	}
+
+	desc := item.Name
+	if desc == "" {
+		desc = fmt.Sprintf("%s %s", req.Method, path)
+	}
+
+	return skill.ToolDefinition{
+		Name:        fmt.Sprintf("%s_%s", strings.ToLower(req.Method), name),
+		Description: truncate(desc, 200),
+		Parameters: map[string]interface{}{
+			"type":       "object",
+			"properties": props,
+		},
+		Required:   required,
+		Method:     strings.ToUpper(req.Method),
+		Path:       path,
+		Location:   location,
+		ParamNames: paramNames,
+	}
+}
+
+// jsonSchemaFromSample infers a JSON Schema from an example JSON value.
+func jsonSchemaFromSample(v interface{}) map[string]interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		props := map[string]interface{}{}
+		for k, child := range val {
+			props[k] = jsonSchemaFromSample(child)
+		}
+		return map[string]interface{}{
+			"type":       "object",
+			"properties": props,
+		}
+	case []interface{}:
+		schema := map[string]interface{}{"type": "array"}
+		if len(val) > 0 {
+			schema["items"] = jsonSchemaFromSample(val[0])
+		}
+		return schema
+	case string:
+		return map[string]interface{}{"type": "string"}
+	case float64:
+		if val == float64(int64(val)) {
+			return map[string]interface{}{"type": "integer"}
+		}
+		return map[string]interface{}{"type": "number"}
+	case bool:
+		return map[string]interface{}{"type": "boolean"}
+	default:
+		return map[string]interface{}{}
+	}
+}
+
 func (c *PostmanConverter) hasExamples(items []PostmanItem) bool {
 	for _, item := range items {
 		if len(item.Response) > 0 {
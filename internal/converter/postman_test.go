@@ -0,0 +1,145 @@
+package converter
+
+import "testing"
+
+func TestPostmanConverter_ToolDefinitions(t *testing.T) {
+	collection := `{
+  "info": {
+    "_postman_id": "pets",
+    "name": "Pets",
+    "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+  },
+  "item": [
+    {
+      "name": "Pets",
+      "item": [
+        {
+          "name": "Get pet",
+          "request": {
+            "method": "GET",
+            "url": {
+              "raw": "{{baseUrl}}/pets/:id?verbose=1",
+              "host": ["{{baseUrl}}"],
+              "path": ["pets", ":id"],
+              "query": [{"key": "verbose", "value": "1"}],
+              "variable": [{"key": "id", "description": "Pet identifier"}]
+            }
+          }
+        }
+      ]
+    },
+    {
+      "name": "Create pet",
+      "request": {
+        "method": "POST",
+        "url": {"raw": "{{baseUrl}}/pets", "host": ["{{baseUrl}}"], "path": ["pets"]},
+        "body": {"mode": "raw", "raw": "{\"name\": \"rex\", \"age\": 3, \"tags\": [\"good\"]}"}
+      }
+    },
+    {
+      "name": "Create pet again",
+      "request": {
+        "method": "POST",
+        "url": {"raw": "{{baseUrl}}/pets", "host": ["{{baseUrl}}"], "path": ["pets"]}
+      }
+    }
+  ]
+}`
+	c := &PostmanConverter{}
+	s, err := c.Convert([]byte(collection), &Options{})
+	if err != nil {
+		t.Fatalf("convert failed: %v", err)
+	}
+
+	if !s.Frontmatter.MCPCompatible {
+		t.Error("expected mcp_compatible to be true")
+	}
+	if len(s.Frontmatter.ToolDefinitions) != 3 {
+		t.Fatalf("expected 3 tools, got %d", len(s.Frontmatter.ToolDefinitions))
+	}
+
+	get := findTool(s.Frontmatter.ToolDefinitions, "get_pets_id")
+	if get == nil {
+		t.Fatal("expected get_pets_id tool")
+	}
+	id := get.Parameters["properties"].(map[string]interface{})["id"].(map[string]interface{})
+	if id["description"] != "Pet identifier" {
+		t.Errorf("expected variable description, got %v", id["description"])
+	}
+	if len(get.Required) != 1 || get.Required[0] != "id" {
+		t.Errorf("expected id to be required, got %v", get.Required)
+	}
+	if get.Location["id"] != "path" || get.Location["verbose"] != "query" {
+		t.Errorf("unexpected argument locations: %v", get.Location)
+	}
+
+	post := findTool(s.Frontmatter.ToolDefinitions, "post_pets")
+	if post == nil {
+		t.Fatal("expected post_pets tool")
+	}
+	body := post.Parameters["properties"].(map[string]interface{})["body"].(map[string]interface{})
+	bodyProps := body["properties"].(map[string]interface{})
+	if age := bodyProps["age"].(map[string]interface{}); age["type"] != "integer" {
+		t.Errorf("expected integer age inferred from sample, got %v", age)
+	}
+
+	if findTool(s.Frontmatter.ToolDefinitions, "post_pets_2") == nil {
+		t.Error("expected duplicate endpoint to get a numeric suffix")
+	}
+}
+
+func TestPostmanConverter_ToolDefinitions_NameCollisions(t *testing.T) {
+	collection := `{
+  "info": {"name": "Docs", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+  "item": [
+    {
+      "name": "Update doc",
+      "request": {
+        "method": "PUT",
+        "url": {
+          "raw": "{{baseUrl}}/orgs/{{orgId}}/docs/:id?id=2&body=full",
+          "host": ["{{baseUrl}}"],
+          "path": ["orgs", "{{orgId}}", "docs", ":id"],
+          "query": [{"key": "id", "value": "2"}, {"key": "body", "value": "full"}]
+        },
+        "body": {"mode": "raw", "raw": "{\"title\": \"Notes\"}"}
+      }
+    }
+  ]
+}`
+	c := &PostmanConverter{}
+	s, err := c.Convert([]byte(collection), &Options{})
+	if err != nil {
+		t.Fatalf("convert failed: %v", err)
+	}
+	if len(s.Frontmatter.ToolDefinitions) != 1 {
+		t.Fatalf("expected 1 tool, got %v", s.Frontmatter.ToolDefinitions)
+	}
+
+	tool := s.Frontmatter.ToolDefinitions[0]
+	if tool.Path != "/orgs/{orgId}/docs/{id}" {
+		t.Errorf("expected {{orgId}} to become a path placeholder, got %q", tool.Path)
+	}
+	props := tool.Parameters["properties"].(map[string]interface{})
+	if len(props) != 5 {
+		t.Errorf("expected 5 distinct arguments, got %v", props)
+	}
+	if body, _ := props["body"].(map[string]interface{}); body["type"] != "object" {
+		t.Errorf("expected body to stay the request body, got %v", props["body"])
+	}
+
+	want := map[string][2]string{
+		"orgId":      {"path", "orgId"},
+		"path_id":    {"path", "id"},
+		"query_id":   {"query", "id"},
+		"query_body": {"query", "body"},
+	}
+	for arg, w := range want {
+		if props[arg] == nil {
+			t.Errorf("expected argument %s, got %v", arg, props)
+		}
+		if tool.Location[arg] != w[0] || tool.ParamName(arg) != w[1] {
+			t.Errorf("expected %s to be sent as %s %q, got %q %q", arg, w[0], w[1], tool.Location[arg], tool.ParamName(arg))
+		}
+	}
+}
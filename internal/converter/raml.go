@@ -438,8 +438,8 @@ func (c *RAMLConverter) countEndpoints(spec *ramlSpec) int {
 
 func (c *RAMLConverter) extractAuthMethods(spec *ramlSpec) []string {
 	methods := make([]string, 0)
-	for _, scheme := range spec.SecuritySchemes {
-		methods = append(methods, scheme.Type)
+	for _, name := range sortedKeys(spec.SecuritySchemes) {
+		methods = append(methods, spec.SecuritySchemes[name].Type)
 	}
 	return methods
 }
@@ -471,16 +471,7 @@ func (c *RAMLConverter) buildQuickStart(spec *ramlSpec) string {
 	}
 
 	// Quick example
-	var firstPath string
-	var firstMethod string
-	for path, resource := range spec.Resources {
-		firstPath = path
-		for method := range resource.Methods {
-			firstMethod = method
-			break
-		}
-		break
-	}
+	firstPath, firstMethod := c.firstEndpoint(spec)
 
 	if firstPath != "" && firstMethod != "" {
 		b.WriteString("### Quick Example\n\n")
@@ -548,7 +539,8 @@ func (c *RAMLConverter) extractMediaTypes(spec *ramlSpec) []string {
 func (c *RAMLConverter) buildAuthSection(spec *ramlSpec) string {
 	var b strings.Builder
 
-	for name, scheme := range spec.SecuritySchemes {
+	for _, name := range sortedKeys(spec.SecuritySchemes) {
+		scheme := spec.SecuritySchemes[name]
 		b.WriteString(fmt.Sprintf("### %s\n\n", name))
 		b.WriteString(fmt.Sprintf("**Type**: %s\n", scheme.Type))
 
@@ -625,7 +617,8 @@ func (c *RAMLConverter) buildEndpointsSection(spec *ramlSpec) string {
 		// URI Parameters
 		if len(resource.URIParams) > 0 {
 			b.WriteString("**URI Parameters:**\n")
-			for pName, p := range resource.URIParams {
+			for _, pName := range sortedKeys(resource.URIParams) {
+				p := resource.URIParams[pName]
 				req := ""
 				if p.Required {
 					req = " (required)"
@@ -636,11 +629,8 @@ func (c *RAMLConverter) buildEndpointsSection(spec *ramlSpec) string {
 		}
 
 		// Methods
-		methodOrder := []string{"get", "post", "put", "patch", "delete", "head", "options"}
-		for _, method := range methodOrder {
-			if m, ok := resource.Methods[method]; ok {
-				c.writeMethod(&b, method, m, spec)
-			}
+		for _, method := range resource.sortedMethods() {
+			c.writeMethod(&b, method, resource.Methods[method], spec)
 		}
 
 		// Sub-resources
@@ -680,7 +670,8 @@ func (c *RAMLConverter) writeMethod(b *strings.Builder, method string, m *ramlMe
 		b.WriteString("**Query Parameters:**\n\n")
 		b.WriteString("| Name | Type | Required | Description |\n")
 		b.WriteString("|------|------|----------|-------------|\n")
-		for pName, p := range m.QueryParams {
+		for _, pName := range sortedKeys(m.QueryParams) {
+			p := m.QueryParams[pName]
 			req := "No"
 			if p.Required {
 				req = "Yes"
@@ -697,7 +688,8 @@ func (c *RAMLConverter) writeMethod(b *strings.Builder, method string, m *ramlMe
 	// Request Body
 	if len(m.Body) > 0 {
 		b.WriteString("**Request Body:**\n\n")
-		for contentType, body := range m.Body {
+		for _, contentType := range sortedKeys(m.Body) {
+			body := m.Body[contentType]
 			b.WriteString(fmt.Sprintf("Content-Type: `%s`\n\n", contentType))
 
 			if body.Type != "" {
@@ -717,14 +709,16 @@ func (c *RAMLConverter) writeMethod(b *strings.Builder, method string, m *ramlMe
 	// Responses
 	if len(m.Responses) > 0 {
 		b.WriteString("**Responses:**\n\n")
-		for status, resp := range m.Responses {
+		for _, status := range sortedKeys(m.Responses) {
+			resp := m.Responses[status]
 			b.WriteString(fmt.Sprintf("**%s**", status))
 			if resp.Description != "" {
 				b.WriteString(fmt.Sprintf(": %s", resp.Description))
 			}
 			b.WriteString("\n\n")
 
-			for contentType, body := range resp.Body {
+			for _, contentType := range sortedKeys(resp.Body) {
+				body := resp.Body[contentType]
 				if body.Example != nil {
 					b.WriteString(fmt.Sprintf("Content-Type: `%s`\n", contentType))
 					b.WriteString("```json\n")
@@ -768,7 +762,8 @@ func (c *RAMLConverter) buildTypesSection(spec *ramlSpec) string {
 			b.WriteString("| Property | Type | Required | Description |\n")
 			b.WriteString("|----------|------|----------|-------------|\n")
 
-			for propName, propDef := range props {
+			for _, propName := range sortedYAMLKeys(props) {
+				propDef := props[propName]
 				propMap, ok := propDef.(map[interface{}]interface{})
 				if !ok {
 					b.WriteString(fmt.Sprintf("| `%v` | `%v` | - | - |\n", propName, propDef))
@@ -811,7 +806,8 @@ func (c *RAMLConverter) buildTypesSection(spec *ramlSpec) string {
 func (c *RAMLConverter) buildTraitsSection(spec *ramlSpec) string {
 	var b strings.Builder
 
-	for name, trait := range spec.Traits {
+	for _, name := range sortedKeys(spec.Traits) {
+		trait := spec.Traits[name]
 		b.WriteString(fmt.Sprintf("### %s\n\n", name))
 
 		traitMap, ok := trait.(map[interface{}]interface{})
@@ -826,7 +822,8 @@ func (c *RAMLConverter) buildTraitsSection(spec *ramlSpec) string {
 		// Query parameters from trait
 		if params, ok := traitMap["queryParameters"].(map[interface{}]interface{}); ok {
 			b.WriteString("**Query Parameters:**\n")
-			for pName, pDef := range params {
+			for _, pName := range sortedYAMLKeys(params) {
+				pDef := params[pName]
 				pMap, _ := pDef.(map[interface{}]interface{})
 				desc := ""
 				if d, ok := pMap["description"]; ok {
@@ -845,16 +842,7 @@ func (c *RAMLConverter) buildCodeExamples(spec *ramlSpec) string {
 	var b strings.Builder
 
 	// Get first endpoint for examples
-	var endpoint string
-	var method string
-	for path, resource := range spec.Resources {
-		endpoint = path
-		for m := range resource.Methods {
-			method = m
-			break
-		}
-		break
-	}
+	endpoint, method := c.firstEndpoint(spec)
 
 	fullURL := spec.BaseURI + endpoint
 
@@ -911,7 +899,8 @@ func (c *RAMLConverter) buildToolDefinitions(spec *ramlSpec) []skill.ToolDefinit
 
 	var processResource func(path string, resource *ramlResource)
 	processResource = func(path string, resource *ramlResource) {
-		for method, m := range resource.Methods {
+		for _, method := range resource.sortedMethods() {
+			m := resource.Methods[method]
 			// Create tool name from path and method
 			toolName := c.pathToToolName(method, path)
 
@@ -929,6 +918,7 @@ func (c *RAMLConverter) buildToolDefinitions(spec *ramlSpec) []skill.ToolDefinit
 			pathParams := regexp.MustCompile(`\{(\w+)\}`).FindAllStringSubmatch(path, -1)
 			props := params["properties"].(map[string]interface{})
 			required := []string{}
+			location := map[string]string{}
 
 			for _, match := range pathParams {
 				paramName := match[1]
@@ -937,14 +927,17 @@ func (c *RAMLConverter) buildToolDefinitions(spec *ramlSpec) []skill.ToolDefinit
 					"description": fmt.Sprintf("Path parameter %s", paramName),
 				}
 				required = append(required, paramName)
+				location[paramName] = "path"
 			}
 
 			// Add query parameters
-			for qName, q := range m.QueryParams {
+			for _, qName := range sortedKeys(m.QueryParams) {
+				q := m.QueryParams[qName]
 				props[qName] = map[string]interface{}{
 					"type":        q.Type,
 					"description": q.Description,
 				}
+				location[qName] = "query"
 				if q.Required {
 					required = append(required, qName)
 				}
@@ -956,6 +949,7 @@ func (c *RAMLConverter) buildToolDefinitions(spec *ramlSpec) []skill.ToolDefinit
 					"type":        "object",
 					"description": "Request body",
 				}
+				location["body"] = "body"
 			}
 
 			tools = append(tools, skill.ToolDefinition{
@@ -963,21 +957,49 @@ func (c *RAMLConverter) buildToolDefinitions(spec *ramlSpec) []skill.ToolDefinit
 				Description: truncate(desc, 200),
 				Parameters:  params,
 				Required:    required,
+				Method:      strings.ToUpper(method),
+				Path:        path,
+				Location:    location,
 			})
 		}
 
-		for subPath, subResource := range resource.SubResources {
-			processResource(path+subPath, subResource)
+		for _, subPath := range sortedKeys(resource.SubResources) {
+			processResource(path+subPath, resource.SubResources[subPath])
 		}
 	}
 
-	for path, resource := range spec.Resources {
-		processResource(path, resource)
+	for _, path := range sortedKeys(spec.Resources) {
+		processResource(path, spec.Resources[path])
 	}
 
 	return tools
 }
 
+// ramlMethodOrder is the order methods of a resource are listed in.
+var ramlMethodOrder = []string{"get", "post", "put", "patch", "delete", "head", "options"}
+
+// sortedMethods returns the methods of r in ramlMethodOrder.
+func (r *ramlResource) sortedMethods() []string {
+	var methods []string
+	for _, method := range ramlMethodOrder {
+		if _, ok := r.Methods[method]; ok {
+			methods = append(methods, method)
+		}
+	}
+	return methods
+}
+
+// firstEndpoint returns the first path and method of the spec, used for the
+// examples.
+func (c *RAMLConverter) firstEndpoint(spec *ramlSpec) (string, string) {
+	for _, path := range sortedKeys(spec.Resources) {
+		if methods := spec.Resources[path].sortedMethods(); len(methods) > 0 {
+			return path, methods[0]
+		}
+	}
+	return "", ""
+}
+
 func (c *RAMLConverter) pathToToolName(method, path string) string {
 	// Convert /users/{id}/orders to users_id_orders
 	name := strings.ReplaceAll(path, "/", "_")
@@ -4,6 +4,8 @@ package shared
 import (
 	"fmt"
 	"strings"
+
+	"github.com/sanixdarker/skill-md/pkg/skill"
 )
 
 // QuickStartConfig holds configuration for generating Quick Start sections.
@@ -427,11 +429,5 @@ func Truncate(s string, maxLen int) string {
 
 // SanitizeToolName converts a path/name to a valid tool name.
 func SanitizeToolName(name string) string {
-	name = strings.ReplaceAll(name, "/", "_")
-	name = strings.ReplaceAll(name, "-", "_")
-	name = strings.ReplaceAll(name, ".", "_")
-	name = strings.ReplaceAll(name, "{", "")
-	name = strings.ReplaceAll(name, "}", "")
-	name = strings.Trim(name, "_")
-	return strings.ToLower(name)
+	return skill.SanitizeToolName(name)
 }
@@ -0,0 +1,196 @@
+package diff
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Semver parts a change set can require bumping.
+const (
+	BumpMajor = "major"
+	BumpMinor = "minor"
+	BumpPatch = "patch"
+)
+
+// bookkeepingFields are frontmatter fields that change between conversions
+// without changing the contract.
+var bookkeepingFields = map[string]bool{
+	"version": true, "source": true, "created_at": true, "updated_at": true,
+}
+
+// Report sorts the changes between two versions of an API by their impact on
+// clients.
+type Report struct {
+	Breaking    []Change `json:"breaking"`
+	NonBreaking []Change `json:"non_breaking"`
+	// Sections counts the documentation sections added, removed or
+	// modified. They never break clients on their own.
+	Sections int `json:"sections_changed"`
+	// Bump is the semver part the changes call for, "" when there are none.
+	Bump string `json:"bump,omitempty"`
+}
+
+// Classify sorts changes into breaking and non-breaking ones and works out
+// the version bump they require.
+func Classify(changes []Change) *Report {
+	report := &Report{Breaking: []Change{}, NonBreaking: []Change{}}
+	additions := false
+	for _, c := range changes {
+		switch {
+		case c.Kind == KindSection:
+			report.Sections++
+		case c.Kind == KindFrontmatter && bookkeepingFields[c.Name]:
+		case IsBreaking(c):
+			report.Breaking = append(report.Breaking, c)
+		default:
+			report.NonBreaking = append(report.NonBreaking, c)
+			if c.Action == ActionAdded && c.Kind != KindFrontmatter {
+				additions = true
+			}
+		}
+	}
+
+	switch {
+	case len(report.Breaking) > 0:
+		report.Bump = BumpMajor
+	case additions:
+		report.Bump = BumpMinor
+	case len(report.NonBreaking) > 0 || report.Sections > 0:
+		report.Bump = BumpPatch
+	}
+	return report
+}
+
+// IsBreaking reports whether a change can break existing clients: removed
+// tools, parameters or fields, moved endpoints, type changes, new required
+// inputs, narrowed enums, a new base URL or server list, and new
+// authentication requirements. Fields are treated as inputs, so a new
+// required field is breaking even in a response model.
+func IsBreaking(c Change) bool {
+	switch c.Kind {
+	case KindTool:
+		switch c.Field {
+		case "method", "path", "base_url", "location", "param_names":
+			return true
+		}
+		return c.Action == ActionRemoved
+	case KindParameter, KindField:
+		switch c.Action {
+		case ActionRemoved:
+			return true
+		case ActionAdded:
+			return c.Detail == "required"
+		}
+		switch c.Field {
+		case "type":
+			return true
+		case "required":
+			return c.To == true
+		case "schema":
+			return enumNarrowed(c.From, c.To)
+		}
+	case KindFrontmatter:
+		switch c.Name {
+		case "base_url":
+			return c.Action != ActionAdded
+		case "servers":
+			return len(missing(c.From, c.To)) > 0
+		case "auth_methods":
+			return len(missing(c.To, c.From)) > 0
+		}
+	}
+	return false
+}
+
+// enumNarrowed reports whether a parameter schema lost allowed values.
+func enumNarrowed(from, to interface{}) bool {
+	oldSchema, _ := from.(map[string]interface{})
+	newSchema, _ := to.(map[string]interface{})
+	newEnum, ok := newSchema["enum"]
+	if !ok {
+		return false
+	}
+	oldEnum, ok := oldSchema["enum"]
+	if !ok {
+		return true
+	}
+	return len(missing(oldEnum, newEnum)) > 0
+}
+
+// missing returns the items of list a that are not in list b.
+func missing(a, b interface{}) []interface{} {
+	var out []interface{}
+	listB := items(b)
+	for _, item := range items(a) {
+		found := false
+		for _, other := range listB {
+			if reflect.DeepEqual(item, other) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// items returns the elements of a slice value, nil for anything else.
+func items(v interface{}) []interface{} {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice {
+		return nil
+	}
+	out := make([]interface{}, rv.Len())
+	for i := range out {
+		out[i] = rv.Index(i).Interface()
+	}
+	return out
+}
+
+// NextVersion bumps part of a semver version, keeping a leading "v" and
+// dropping pre-release and build suffixes. Breaking changes to a 0.x
+// version bump the minor version, as semver reserves 1.0.0 for the first
+// stable API.
+func NextVersion(version, bump string) (string, error) {
+	if bump == "" {
+		return version, nil
+	}
+
+	prefix := ""
+	rest := strings.TrimSpace(version)
+	if strings.HasPrefix(rest, "v") {
+		prefix, rest = "v", rest[1:]
+	}
+	if i := strings.IndexAny(rest, "-+"); i >= 0 {
+		rest = rest[:i]
+	}
+
+	parts := strings.Split(rest, ".")
+	if len(parts) > 3 || rest == "" {
+		return "", fmt.Errorf("invalid version %q", version)
+	}
+	var nums [3]int
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return "", fmt.Errorf("invalid version %q", version)
+		}
+		nums[i] = n
+	}
+
+	switch {
+	case bump == BumpMajor && nums[0] > 0:
+		nums = [3]int{nums[0] + 1, 0, 0}
+	case bump == BumpMajor || bump == BumpMinor:
+		nums = [3]int{nums[0], nums[1] + 1, 0}
+	case bump == BumpPatch:
+		nums[2]++
+	default:
+		return "", fmt.Errorf("unknown version bump %q", bump)
+	}
+	return fmt.Sprintf("%s%d.%d.%d", prefix, nums[0], nums[1], nums[2]), nil
+}
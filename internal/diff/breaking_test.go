@@ -0,0 +1,128 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestClassify(t *testing.T) {
+	changes, err := CompareContent(oldSkill, newSkill)
+	if err != nil {
+		t.Fatal(err)
+	}
+	report := Classify(changes)
+
+	var breaking, nonBreaking []string
+	for _, c := range report.Breaking {
+		breaking = append(breaking, c.String())
+	}
+	for _, c := range report.NonBreaking {
+		nonBreaking = append(nonBreaking, c.String())
+	}
+
+	wantBreaking := []string{
+		`~ parameter get_pet.id: type "string" -> "integer"`,
+		"- parameter get_pet.verbose",
+		"+ parameter get_pet.fields: required",
+		"- tool delete_pet",
+	}
+	if got := strings.Join(breaking, "\n"); got != strings.Join(wantBreaking, "\n") {
+		t.Errorf("unexpected breaking changes:\n%s", got)
+	}
+	// The version bump itself is bookkeeping, not a change to report
+	wantNonBreaking := []string{`+ author: "Pet Team"`, "+ tool create_pet: Create a pet"}
+	if got := strings.Join(nonBreaking, "\n"); got != strings.Join(wantNonBreaking, "\n") {
+		t.Errorf("unexpected non-breaking changes:\n%s", got)
+	}
+	if report.Sections != 3 || report.Bump != BumpMajor {
+		t.Errorf("expected 3 section changes and a major bump, got %d and %q", report.Sections, report.Bump)
+	}
+}
+
+func TestClassify_Bump(t *testing.T) {
+	tests := []struct {
+		name    string
+		changes []Change
+		want    string
+	}{
+		{"none", nil, ""},
+		{"version only", []Change{{Kind: KindFrontmatter, Action: ActionChanged, Name: "version"}}, ""},
+		{"docs", []Change{{Kind: KindSection, Action: ActionChanged, Name: "Overview"}}, BumpPatch},
+		{"optional parameter", []Change{{Kind: KindParameter, Action: ActionAdded, Tool: "t", Name: "p"}}, BumpMinor},
+		{"required parameter", []Change{{Kind: KindParameter, Action: ActionAdded, Tool: "t", Name: "p", Detail: "required"}}, BumpMajor},
+		{"made optional", []Change{{Kind: KindField, Action: ActionChanged, Name: "f", Field: "required", From: true, To: false}}, BumpPatch},
+		{"made required", []Change{{Kind: KindField, Action: ActionChanged, Name: "f", Field: "required", From: false, To: true}}, BumpMajor},
+		{"enum widened", []Change{{Kind: KindParameter, Action: ActionChanged, Field: "schema",
+			From: map[string]interface{}{"enum": []interface{}{"a"}},
+			To:   map[string]interface{}{"enum": []interface{}{"a", "b"}}}}, BumpPatch},
+		{"enum narrowed", []Change{{Kind: KindParameter, Action: ActionChanged, Field: "schema",
+			From: map[string]interface{}{"enum": []interface{}{"a", "b"}},
+			To:   map[string]interface{}{"enum": []interface{}{"a"}}}}, BumpMajor},
+		{"new auth method", []Change{{Kind: KindFrontmatter, Action: ActionChanged, Name: "auth_methods",
+			From: []string{"apiKey"}, To: []string{"apiKey", "oauth2"}}}, BumpMajor},
+		{"server added", []Change{{Kind: KindFrontmatter, Action: ActionChanged, Name: "servers",
+			From: []string{"a"}, To: []string{"a", "b"}}}, BumpPatch},
+		{"endpoint moved", []Change{{Kind: KindTool, Action: ActionChanged, Name: "t", Field: "path", From: "/a", To: "/b"}}, BumpMajor},
+	}
+
+	for _, tt := range tests {
+		if got := Classify(tt.changes).Bump; got != tt.want {
+			t.Errorf("%s: bump = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCompare_FieldTables(t *testing.T) {
+	before := "## Messages\n\n### User\n\n| Field | Type | Description |\n|-------|------|-------------|\n" +
+		"| `id` | `string` | - |\n| `email` | `string` | - |\n| `age` | `int32` | - |\n\n" +
+		"## Data Models\n\n### Pet\n\n| Property | Type | Required | Description |\n|---|---|---|---|\n| `name` | `string` | No | Name |\n"
+	after := "## Messages\n\n### User\n\n| Field | Type | Description |\n|-------|------|-------------|\n" +
+		"| `id` | `int64` | - |\n| `age` | `int32` | - |\n| `nickname` | `string` | - |\n\n" +
+		"## Data Models\n\n### Pet\n\n| Property | Type | Required | Description |\n|---|---|---|---|\n| `name` | `string` | Yes | Name |\n"
+
+	changes, err := CompareContent(before, after)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `~ field Messages > User.id: type "string" -> "int64"
+- field Messages > User.email
++ field Messages > User.nickname
+~ section Messages > User (+2 -2 lines)
+~ field Data Models > Pet.name: required false -> true
+~ section Data Models > Pet (+1 -1 lines)
+`
+	if got := Text(changes); got != want {
+		t.Errorf("unexpected changes:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestNextVersion(t *testing.T) {
+	tests := []struct {
+		version, bump, want string
+	}{
+		{"1.2.3", BumpMajor, "2.0.0"},
+		{"1.2.3", BumpMinor, "1.3.0"},
+		{"1.2.3", BumpPatch, "1.2.4"},
+		{"1.2.3", "", "1.2.3"},
+		{"v2.0.0-beta.1", BumpPatch, "v2.0.1"},
+		{"0.4.1", BumpMajor, "0.5.0"},
+		{"1.0", BumpMinor, "1.1.0"},
+		{"3", BumpMajor, "4.0.0"},
+	}
+	for _, tt := range tests {
+		got, err := NextVersion(tt.version, tt.bump)
+		if err != nil {
+			t.Errorf("NextVersion(%q, %q) failed: %v", tt.version, tt.bump, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("NextVersion(%q, %q) = %q, want %q", tt.version, tt.bump, got, tt.want)
+		}
+	}
+
+	for _, version := range []string{"", "latest", "1.2.3.4", "1.x"} {
+		if _, err := NextVersion(version, BumpPatch); err == nil {
+			t.Errorf("expected an error for version %q", version)
+		}
+	}
+}
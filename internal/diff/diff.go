@@ -0,0 +1,216 @@
+// Package diff compares skills: line-based unified diffs of their text and
+// semantic diffs of their frontmatter, tools and sections.
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// contextLines is the number of unchanged lines shown around each change.
+const contextLines = 3
+
+type opKind int
+
+const (
+	opEqual opKind = iota
+	opDelete
+	opInsert
+)
+
+// op is a single edit. a and b are the positions in the old and new text at
+// which the edit applies.
+type op struct {
+	kind opKind
+	a, b int
+}
+
+// Unified returns a unified diff turning oldText into newText, labelled with
+// oldName and newName. It returns an empty string when the texts are equal.
+func Unified(oldName, newName, oldText, newText string) string {
+	if oldText == newText {
+		return ""
+	}
+
+	a, b := splitLines(oldText), splitLines(newText)
+	ops := editScript(a, b)
+
+	var sb strings.Builder
+	sb.WriteString("--- " + oldName + "\n")
+	sb.WriteString("+++ " + newName + "\n")
+
+	for _, h := range hunks(ops) {
+		writeHunk(&sb, ops[h[0]:h[1]], a, b)
+	}
+
+	return sb.String()
+}
+
+// splitLines splits text into lines, keeping line terminators.
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// editScript computes the shortest edit script between a and b using
+// Myers' O(ND) algorithm.
+func editScript(a, b []string) []op {
+	n, m := len(a), len(b)
+	max := n + m
+	offset := max + 1
+	v := make([]int, 2*max+3)
+	var trace [][]int
+
+search:
+	for d := 0; d <= max; d++ {
+		trace = append(trace, append([]int(nil), v...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				break search
+			}
+		}
+	}
+
+	// Walk the trace backwards to recover the edits
+	var ops []op
+	x, y := n, m
+	for d := len(trace) - 1; d > 0; d-- {
+		v := trace[d]
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			x--
+			y--
+			ops = append(ops, op{kind: opEqual, a: x, b: y})
+		}
+		if x == prevX {
+			y--
+			ops = append(ops, op{kind: opInsert, a: x, b: y})
+		} else {
+			x--
+			ops = append(ops, op{kind: opDelete, a: x, b: y})
+		}
+	}
+	for x > 0 && y > 0 {
+		x--
+		y--
+		ops = append(ops, op{kind: opEqual, a: x, b: y})
+	}
+
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+	return ops
+}
+
+// hunks groups changes that are close together, returning [start, end)
+// ranges into ops that include the surrounding context.
+func hunks(ops []op) [][2]int {
+	var result [][2]int
+	for i := 0; i < len(ops); i++ {
+		if ops[i].kind == opEqual {
+			continue
+		}
+
+		start := i - contextLines
+		if start < 0 {
+			start = 0
+		}
+		// Extend the hunk while the next change is within two context windows
+		end := i
+		for j := i; j < len(ops); j++ {
+			if ops[j].kind != opEqual {
+				end = j
+				continue
+			}
+			if j-end > 2*contextLines {
+				break
+			}
+		}
+		stop := end + contextLines + 1
+		if stop > len(ops) {
+			stop = len(ops)
+		}
+
+		if n := len(result); n > 0 && start <= result[n-1][1] {
+			result[n-1][1] = stop
+		} else {
+			result = append(result, [2]int{start, stop})
+		}
+		i = end
+	}
+	return result
+}
+
+func writeHunk(sb *strings.Builder, ops []op, a, b []string) {
+	aStart, bStart := ops[0].a, ops[0].b
+	aCount, bCount := 0, 0
+	for _, o := range ops {
+		switch o.kind {
+		case opEqual:
+			aCount++
+			bCount++
+		case opDelete:
+			aCount++
+		case opInsert:
+			bCount++
+		}
+	}
+
+	fmt.Fprintf(sb, "@@ -%s +%s @@\n", hunkRange(aStart, aCount), hunkRange(bStart, bCount))
+	for _, o := range ops {
+		switch o.kind {
+		case opEqual:
+			writeLine(sb, ' ', a[o.a])
+		case opDelete:
+			writeLine(sb, '-', a[o.a])
+		case opInsert:
+			writeLine(sb, '+', b[o.b])
+		}
+	}
+}
+
+// hunkRange formats a hunk range; empty ranges point at the line before.
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+func writeLine(sb *strings.Builder, prefix byte, line string) {
+	sb.WriteByte(prefix)
+	sb.WriteString(line)
+	if !strings.HasSuffix(line, "\n") {
+		sb.WriteString("\n\\ No newline at end of file\n")
+	}
+}
@@ -0,0 +1,61 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnified_Equal(t *testing.T) {
+	if got := Unified("a", "b", "same\n", "same\n"); got != "" {
+		t.Errorf("expected empty diff, got %q", got)
+	}
+}
+
+func TestUnified_Change(t *testing.T) {
+	oldText := "one\ntwo\nthree\nfour\nfive\nsix\nseven\neight\nnine\nten\n"
+	newText := "one\ntwo\nthree\nfour\nFIVE\nsix\nseven\neight\nnine\nten\neleven\n"
+
+	want := `--- old
++++ new
+@@ -2,9 +2,10 @@
+ two
+ three
+ four
+-five
++FIVE
+ six
+ seven
+ eight
+ nine
+ ten
++eleven
+`
+	if got := Unified("old", "new", oldText, newText); got != want {
+		t.Errorf("unexpected diff:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestUnified_SeparateHunks(t *testing.T) {
+	var oldLines, newLines []string
+	for i := 0; i < 30; i++ {
+		line := string(rune('a'+i%26)) + "\n"
+		oldLines = append(oldLines, line)
+		if i == 2 || i == 25 {
+			line = "changed\n"
+		}
+		newLines = append(newLines, line)
+	}
+
+	got := Unified("old", "new", strings.Join(oldLines, ""), strings.Join(newLines, ""))
+	if n := strings.Count(got, "@@ -"); n != 2 {
+		t.Errorf("expected 2 hunks, got %d:\n%s", n, got)
+	}
+}
+
+func TestUnified_NoTrailingNewline(t *testing.T) {
+	got := Unified("old", "new", "", "added")
+	want := "--- old\n+++ new\n@@ -0,0 +1 @@\n+added\n\\ No newline at end of file\n"
+	if got != want {
+		t.Errorf("unexpected diff:\n%q\nwant:\n%q", got, want)
+	}
+}
@@ -0,0 +1,533 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/sanixdarker/skill-md/pkg/skill"
+)
+
+// Kinds of semantic change.
+const (
+	KindFrontmatter = "frontmatter"
+	KindTool        = "tool"
+	KindParameter   = "parameter"
+	KindField       = "field"
+	KindSection     = "section"
+)
+
+// Actions of a semantic change.
+const (
+	ActionAdded   = "added"
+	ActionRemoved = "removed"
+	ActionChanged = "changed"
+)
+
+// Change is one semantic difference between two skills.
+type Change struct {
+	Kind   string `json:"kind"`
+	Action string `json:"action"`
+	// Name is the frontmatter key, the tool or parameter name, or the
+	// section's heading path ("Endpoints > GET /pets").
+	Name string `json:"name"`
+	// Tool is the tool a parameter belongs to.
+	Tool string `json:"tool,omitempty"`
+	// Section is the heading path of the section documenting a field.
+	Section string `json:"section,omitempty"`
+	// Field is the changed attribute of a tool, parameter or field, such as
+	// "description", "type" or "required".
+	Field  string      `json:"field,omitempty"`
+	From   interface{} `json:"from,omitempty"`
+	To     interface{} `json:"to,omitempty"`
+	Detail string      `json:"detail,omitempty"`
+}
+
+// Compare reports the semantic changes turning oldSkill into newSkill:
+// frontmatter fields, tools and their parameters, sections, and the fields
+// documented in their "| Field | Type |" tables. Sections are matched by
+// their heading path, so moving a subsection under another heading shows as
+// a removal and an addition.
+func Compare(oldSkill, newSkill *skill.Skill) []Change {
+	var changes []Change
+	changes = append(changes, compareFrontmatter(oldSkill.Frontmatter, newSkill.Frontmatter)...)
+	changes = append(changes, compareTools(oldSkill.Frontmatter.ToolDefinitions, newSkill.Frontmatter.ToolDefinitions)...)
+	changes = append(changes, compareSections(oldSkill, newSkill)...)
+	return changes
+}
+
+// CompareContent parses two SKILL.md documents and compares them.
+func CompareContent(oldContent, newContent string) ([]Change, error) {
+	oldSkill, err := skill.Parse(oldContent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse old skill: %w", err)
+	}
+	newSkill, err := skill.Parse(newContent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse new skill: %w", err)
+	}
+	return Compare(oldSkill, newSkill), nil
+}
+
+// compareFrontmatter compares every frontmatter field but the tools, in
+// declaration order. Empty values count as absent.
+func compareFrontmatter(a, b skill.Frontmatter) []Change {
+	var changes []Change
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	t := va.Type()
+	for i := 0; i < t.NumField(); i++ {
+		key, _, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ",")
+		if key == "" || key == "-" || key == "tools" {
+			continue
+		}
+		fa, fb := va.Field(i), vb.Field(i)
+		switch {
+		case isEmpty(fa) && isEmpty(fb):
+		case isEmpty(fa):
+			changes = append(changes, Change{Kind: KindFrontmatter, Action: ActionAdded, Name: key, To: fb.Interface()})
+		case isEmpty(fb):
+			changes = append(changes, Change{Kind: KindFrontmatter, Action: ActionRemoved, Name: key, From: fa.Interface()})
+		case !reflect.DeepEqual(fa.Interface(), fb.Interface()):
+			changes = append(changes, Change{Kind: KindFrontmatter, Action: ActionChanged, Name: key, From: fa.Interface(), To: fb.Interface()})
+		}
+	}
+	return append(changes, compareExtensions(a.Extensions, b.Extensions)...)
+}
+
+// compareExtensions reports the frontmatter keys Frontmatter does not know,
+// such as license, that were added, removed or changed, sorted by key.
+func compareExtensions(a, b map[string]interface{}) []Change {
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var changes []Change
+	for _, key := range keys {
+		va, inA := a[key]
+		vb, inB := b[key]
+		switch {
+		case !inA:
+			changes = append(changes, Change{Kind: KindFrontmatter, Action: ActionAdded, Name: key, To: vb})
+		case !inB:
+			changes = append(changes, Change{Kind: KindFrontmatter, Action: ActionRemoved, Name: key, From: va})
+		case !reflect.DeepEqual(va, vb):
+			changes = append(changes, Change{Kind: KindFrontmatter, Action: ActionChanged, Name: key, From: va, To: vb})
+		}
+	}
+	return changes
+}
+
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	}
+	return v.IsZero()
+}
+
+// compareTools reports removed and changed tools in their old order, then
+// added tools in their new order.
+func compareTools(a, b []skill.ToolDefinition) []Change {
+	byName := make(map[string]skill.ToolDefinition, len(b))
+	for _, tool := range b {
+		byName[tool.Name] = tool
+	}
+	seen := make(map[string]bool, len(a))
+
+	var changes []Change
+	for _, oldTool := range a {
+		seen[oldTool.Name] = true
+		newTool, ok := byName[oldTool.Name]
+		if !ok {
+			changes = append(changes, Change{Kind: KindTool, Action: ActionRemoved, Name: oldTool.Name})
+			continue
+		}
+		changes = append(changes, compareTool(oldTool, newTool)...)
+	}
+	for _, tool := range b {
+		if !seen[tool.Name] {
+			changes = append(changes, Change{Kind: KindTool, Action: ActionAdded, Name: tool.Name, Detail: tool.Description})
+		}
+	}
+	return changes
+}
+
+func compareTool(a, b skill.ToolDefinition) []Change {
+	var changes []Change
+	for _, f := range []struct {
+		field    string
+		from, to interface{}
+	}{
+		{"description", a.Description, b.Description},
+		{"method", a.Method, b.Method},
+		{"path", a.Path, b.Path},
+		{"base_url", a.BaseURL, b.BaseURL},
+		{"location", a.Location, b.Location},
+		{"param_names", a.ParamNames, b.ParamNames},
+		{"graphql", a.GraphQL, b.GraphQL},
+	} {
+		if !reflect.DeepEqual(f.from, f.to) && !(isEmpty(reflect.ValueOf(f.from)) && isEmpty(reflect.ValueOf(f.to))) {
+			changes = append(changes, Change{Kind: KindTool, Action: ActionChanged, Name: a.Name, Field: f.field, From: f.from, To: f.to})
+		}
+	}
+
+	oldProps, newProps := toolProperties(a), toolProperties(b)
+	oldRequired, newRequired := requiredSet(a), requiredSet(b)
+	param := func(action, name string) Change {
+		return Change{Kind: KindParameter, Action: action, Tool: a.Name, Name: name}
+	}
+
+	for _, name := range sortedNames(oldProps) {
+		newProp, ok := newProps[name]
+		if !ok {
+			changes = append(changes, param(ActionRemoved, name))
+			continue
+		}
+		oldProp := oldProps[name]
+
+		oldType, newType := schemaType(oldProp), schemaType(newProp)
+		if oldType != newType {
+			c := param(ActionChanged, name)
+			c.Field, c.From, c.To = "type", oldType, newType
+			changes = append(changes, c)
+		}
+		if oldRequired[name] != newRequired[name] {
+			c := param(ActionChanged, name)
+			c.Field, c.From, c.To = "required", oldRequired[name], newRequired[name]
+			changes = append(changes, c)
+		}
+		if !reflect.DeepEqual(withoutType(oldProp), withoutType(newProp)) {
+			c := param(ActionChanged, name)
+			c.Field, c.From, c.To = "schema", oldProp, newProp
+			changes = append(changes, c)
+		}
+	}
+	for _, name := range sortedNames(newProps) {
+		if _, ok := oldProps[name]; !ok {
+			c := param(ActionAdded, name)
+			c.To = newProps[name]
+			if newRequired[name] {
+				c.Detail = "required"
+			}
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
+
+// toolProperties returns the schemas of a tool's parameters by name.
+func toolProperties(tool skill.ToolDefinition) map[string]interface{} {
+	props, _ := tool.Parameters["properties"].(map[string]interface{})
+	return props
+}
+
+// requiredSet collects a tool's required parameters, whether listed on the
+// tool or inside its schema.
+func requiredSet(tool skill.ToolDefinition) map[string]bool {
+	required := make(map[string]bool)
+	for _, name := range tool.Required {
+		required[name] = true
+	}
+	switch list := tool.Parameters["required"].(type) {
+	case []interface{}:
+		for _, name := range list {
+			if s, ok := name.(string); ok {
+				required[s] = true
+			}
+		}
+	case []string:
+		for _, name := range list {
+			required[name] = true
+		}
+	}
+	return required
+}
+
+// schemaType returns the JSON Schema type of a parameter, "" if unset.
+func schemaType(schema interface{}) string {
+	m, _ := schema.(map[string]interface{})
+	switch t := m["type"].(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	default:
+		out, _ := json.Marshal(t)
+		return string(out)
+	}
+}
+
+// withoutType returns a parameter schema without its type, which is
+// compared on its own.
+func withoutType(schema interface{}) interface{} {
+	m, ok := schema.(map[string]interface{})
+	if !ok {
+		return schema
+	}
+	rest := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		if k != "type" {
+			rest[k] = v
+		}
+	}
+	return rest
+}
+
+func sortedNames(m map[string]interface{}) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// compareSections reports removed and modified sections in their old order,
+// then added sections in their new order.
+func compareSections(a, b *skill.Skill) []Change {
+	oldPaths, oldSections := sectionPaths(a)
+	newPaths, newSections := sectionPaths(b)
+
+	var changes []Change
+	for _, path := range oldPaths {
+		oldSec := oldSections[path]
+		newSec, ok := newSections[path]
+		if !ok {
+			changes = append(changes, Change{Kind: KindSection, Action: ActionRemoved, Name: path})
+			continue
+		}
+		if detail := sectionDelta(oldSec.Content, newSec.Content); detail != "" {
+			changes = append(changes, compareFields(path, oldSec.Content, newSec.Content)...)
+			changes = append(changes, Change{Kind: KindSection, Action: ActionChanged, Name: path, Detail: detail})
+		}
+	}
+	for _, path := range newPaths {
+		if _, ok := oldSections[path]; !ok {
+			changes = append(changes, Change{Kind: KindSection, Action: ActionAdded, Name: path})
+		}
+	}
+	return changes
+}
+
+// sectionPaths lists a skill's sections by heading path in document order.
+// A repeated path gets a "#2", "#3"... suffix.
+func sectionPaths(s *skill.Skill) ([]string, map[string]*skill.ParsedSection) {
+	var paths []string
+	sections := make(map[string]*skill.ParsedSection)
+	var walk func(nodes []*skill.ParsedSection, parent string)
+	walk = func(nodes []*skill.ParsedSection, parent string) {
+		for _, node := range nodes {
+			path := node.Title
+			if parent != "" {
+				path = parent + " > " + node.Title
+			}
+			key := path
+			for n := 2; sections[key] != nil; n++ {
+				key = fmt.Sprintf("%s #%d", path, n)
+			}
+			paths = append(paths, key)
+			sections[key] = node
+			walk(node.Children, path)
+		}
+	}
+	walk(s.Tree(), "")
+	return paths, sections
+}
+
+// sectionDelta summarizes how a section's own content changed, or returns
+// "" when it did not.
+func sectionDelta(oldContent, newContent string) string {
+	oldContent, newContent = strings.TrimSpace(oldContent), strings.TrimSpace(newContent)
+	if oldContent == newContent {
+		return ""
+	}
+
+	var added, removed int
+	for _, o := range editScript(splitLines(oldContent+"\n"), splitLines(newContent+"\n")) {
+		switch o.kind {
+		case opInsert:
+			added++
+		case opDelete:
+			removed++
+		}
+	}
+	detail := fmt.Sprintf("+%d -%d lines", added, removed)
+
+	parser := skill.NewASTParser()
+	oldAST, _ := parser.ParseAST([]byte(oldContent))
+	newAST, _ := parser.ParseAST([]byte(newContent))
+	if oldAST != nil && newAST != nil && len(oldAST.CodeBlocks) != len(newAST.CodeBlocks) {
+		detail += fmt.Sprintf(", code blocks %d -> %d", len(oldAST.CodeBlocks), len(newAST.CodeBlocks))
+	}
+	return detail
+}
+
+// field is a row of a field table.
+type field struct {
+	typ      string
+	required string // "yes", "no", or "" without a Required column
+}
+
+// fieldTables reads the fields documented in a section's markdown tables,
+// those whose first column is Field or Property and that have a Type
+// column, as the converters write them for messages, types and models.
+func fieldTables(content string) ([]string, map[string]field) {
+	var names []string
+	fields := make(map[string]field)
+	typeCol, requiredCol := -1, -1
+	inTable := false
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "|") {
+			inTable, typeCol, requiredCol = false, -1, -1
+			continue
+		}
+		cells := strings.Split(strings.Trim(line, "|"), "|")
+		for i := range cells {
+			cells[i] = strings.Trim(strings.TrimSpace(cells[i]), "`")
+		}
+
+		if !inTable {
+			inTable = true
+			first := strings.ToLower(cells[0])
+			if first != "field" && first != "property" {
+				continue
+			}
+			for i, cell := range cells {
+				switch strings.ToLower(cell) {
+				case "type":
+					typeCol = i
+				case "required":
+					requiredCol = i
+				}
+			}
+			continue
+		}
+		if typeCol < 0 || strings.HasPrefix(cells[0], "---") || typeCol >= len(cells) {
+			continue
+		}
+
+		f := field{typ: cells[typeCol]}
+		if requiredCol >= 0 && requiredCol < len(cells) {
+			f.required = strings.ToLower(cells[requiredCol])
+		}
+		if _, ok := fields[cells[0]]; !ok {
+			names = append(names, cells[0])
+		}
+		fields[cells[0]] = f
+	}
+	return names, fields
+}
+
+// compareFields reports the fields added, removed or retyped between two
+// versions of a section's field tables.
+func compareFields(path, oldContent, newContent string) []Change {
+	oldNames, oldFields := fieldTables(oldContent)
+	newNames, newFields := fieldTables(newContent)
+	fieldChange := func(action, name string) Change {
+		return Change{Kind: KindField, Action: action, Section: path, Name: name}
+	}
+
+	var changes []Change
+	for _, name := range oldNames {
+		oldField := oldFields[name]
+		newField, ok := newFields[name]
+		if !ok {
+			changes = append(changes, fieldChange(ActionRemoved, name))
+			continue
+		}
+		if oldField.typ != newField.typ {
+			c := fieldChange(ActionChanged, name)
+			c.Field, c.From, c.To = "type", oldField.typ, newField.typ
+			changes = append(changes, c)
+		}
+		if oldField.required != "" && newField.required != "" && oldField.required != newField.required {
+			c := fieldChange(ActionChanged, name)
+			c.Field, c.From, c.To = "required", oldField.required == "yes", newField.required == "yes"
+			changes = append(changes, c)
+		}
+	}
+	for _, name := range newNames {
+		if _, ok := oldFields[name]; !ok {
+			c := fieldChange(ActionAdded, name)
+			c.To = newFields[name].typ
+			if newFields[name].required == "yes" {
+				c.Detail = "required"
+			}
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
+
+// Text renders changes one per line, prefixed with +, - or ~. It returns an
+// empty string when there are no changes.
+func Text(changes []Change) string {
+	var b strings.Builder
+	for _, c := range changes {
+		b.WriteString(c.String())
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// String describes the change on one line.
+func (c Change) String() string {
+	sign := "~"
+	switch c.Action {
+	case ActionAdded:
+		sign = "+"
+	case ActionRemoved:
+		sign = "-"
+	}
+
+	name := c.Name
+	switch c.Kind {
+	case KindParameter:
+		name = c.Tool + "." + c.Name
+	case KindField:
+		name = c.Section + "." + c.Name
+	}
+
+	switch {
+	case c.Kind == KindFrontmatter && c.Action == ActionAdded:
+		return fmt.Sprintf("%s %s: %s", sign, name, formatValue(c.To))
+	case c.Kind == KindFrontmatter && c.Action == ActionRemoved:
+		return fmt.Sprintf("%s %s: %s", sign, name, formatValue(c.From))
+	case c.Kind == KindFrontmatter:
+		return fmt.Sprintf("%s %s: %s -> %s", sign, name, formatValue(c.From), formatValue(c.To))
+	case c.Field == "schema":
+		return fmt.Sprintf("%s %s %s: schema changed", sign, c.Kind, name)
+	case c.Field != "":
+		return fmt.Sprintf("%s %s %s: %s %s -> %s", sign, c.Kind, name, c.Field, formatValue(c.From), formatValue(c.To))
+	case c.Detail != "" && c.Kind == KindSection:
+		return fmt.Sprintf("%s %s %s (%s)", sign, c.Kind, name, c.Detail)
+	case c.Detail != "":
+		return fmt.Sprintf("%s %s %s: %s", sign, c.Kind, name, c.Detail)
+	}
+	return fmt.Sprintf("%s %s %s", sign, c.Kind, name)
+}
+
+// formatValue renders a value as compact JSON, or "none" when it is empty.
+func formatValue(v interface{}) string {
+	if v == nil || isEmpty(reflect.ValueOf(v)) {
+		if _, ok := v.(bool); ok {
+			return "false"
+		}
+		return "none"
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(out)
+}
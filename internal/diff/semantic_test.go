@@ -0,0 +1,189 @@
+package diff
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const oldSkill = `---
+name: "Pets"
+version: "1.0.0"
+description: "Pet store"
+tags:
+  - "pets"
+tools:
+  - name: "get_pet"
+    description: "Get a pet"
+    parameters:
+      type: "object"
+      properties:
+        id:
+          type: "string"
+        verbose:
+          type: "boolean"
+    required:
+      - "id"
+  - name: "delete_pet"
+    description: "Delete a pet"
+---
+
+## Overview
+
+Pets.
+
+## Endpoints
+
+### GET /pets/{id}
+
+Returns a pet.
+
+### DELETE /pets/{id}
+
+Deletes a pet.
+`
+
+const newSkill = `---
+name: "Pets"
+version: "1.1.0"
+description: "Pet store"
+author: "Pet Team"
+tags:
+  - "pets"
+tools:
+  - name: "get_pet"
+    description: "Get a pet"
+    parameters:
+      type: "object"
+      properties:
+        id:
+          type: "integer"
+        fields:
+          type: "string"
+    required:
+      - "id"
+      - "fields"
+  - name: "create_pet"
+    description: "Create a pet"
+---
+
+## Overview
+
+Pets.
+
+## Endpoints
+
+### GET /pets/{id}
+
+Returns a pet by ID.
+
+` + "```bash\ncurl /pets/1\n```" + `
+
+### POST /pets
+
+Creates a pet.
+`
+
+func TestCompareContent(t *testing.T) {
+	changes, err := CompareContent(oldSkill, newSkill)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `~ version: "1.0.0" -> "1.1.0"
++ author: "Pet Team"
+~ parameter get_pet.id: type "string" -> "integer"
+- parameter get_pet.verbose
++ parameter get_pet.fields: required
+- tool delete_pet
++ tool create_pet: Create a pet
+~ section Endpoints > GET /pets/{id} (+5 -1 lines, code blocks 0 -> 1)
+- section Endpoints > DELETE /pets/{id}
++ section Endpoints > POST /pets
+`
+	if got := Text(changes); got != want {
+		t.Errorf("unexpected changes:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestCompareContent_Equal(t *testing.T) {
+	changes, err := CompareContent(oldSkill, oldSkill)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("expected no changes, got:\n%s", Text(changes))
+	}
+}
+
+func TestCompare_RequiredChange(t *testing.T) {
+	changes, err := CompareContent(oldSkill, `---
+name: "Pets"
+version: "1.0.0"
+description: "Pet store"
+tags:
+  - "pets"
+tools:
+  - name: "get_pet"
+    description: "Get a pet"
+    parameters:
+      type: "object"
+      properties:
+        id:
+          type: "string"
+        verbose:
+          type: "boolean"
+    required:
+      - "id"
+      - "verbose"
+  - name: "delete_pet"
+    description: "Delete a pet"
+---
+
+## Overview
+
+Pets.
+
+## Endpoints
+
+### GET /pets/{id}
+
+Returns a pet.
+
+### DELETE /pets/{id}
+
+Deletes a pet.
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 {
+		t.Fatalf("expected 1 change, got:\n%s", Text(changes))
+	}
+
+	out, err := json.Marshal(changes[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"kind":"parameter","action":"changed","name":"verbose","tool":"get_pet","field":"required","from":false,"to":true}`
+	if string(out) != want {
+		t.Errorf("unexpected JSON:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestCompareContent_Extensions(t *testing.T) {
+	changes, err := CompareContent(
+		"---\nname: \"Pets\"\nlicense: \"MIT\"\nallowed-tools: [Read]\n---\n\n## Overview\n\nPets.\n",
+		"---\nname: \"Pets\"\nlicense: \"Apache-2.0\"\nmetadata: {owner: pets}\n---\n\n## Overview\n\nPets.\n",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `- allowed-tools: ["Read"]
+~ license: "MIT" -> "Apache-2.0"
++ metadata: {"owner":"pets"}
+`
+	if got := Text(changes); got != want {
+		t.Errorf("unexpected changes:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,71 @@
+package mcp
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// maxHTTPMessageBytes caps the size of a single JSON-RPC message posted over HTTP.
+const maxHTTPMessageBytes = 10 << 20
+
+// ServeHTTP implements the streamable HTTP transport. Each POST carries one
+// JSON-RPC message and is answered with a single JSON response; the server
+// never opens an SSE stream, so GET and DELETE are rejected.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Guard against DNS rebinding: browsers always send Origin on cross-site requests
+	if !sameOrigin(r) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r.Body, maxHTTPMessageBytes+1))
+	if err != nil {
+		http.Error(w, "Failed to read request", http.StatusBadRequest)
+		return
+	}
+	if len(data) > maxHTTPMessageBytes {
+		http.Error(w, "Request too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	// Responses and notifications from the client are acknowledged without a body
+	var probe struct {
+		ID     json.RawMessage `json:"id"`
+		Method string          `json:"method"`
+	}
+	if err := json.Unmarshal(data, &probe); err == nil && probe.Method == "" && len(probe.ID) > 0 {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	resp := s.HandleMessage(r.Context(), data)
+	if resp == nil {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(resp)
+}
+
+// sameOrigin reports whether the request's Origin header, if any, matches its Host.
+func sameOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(u.Host, r.Host)
+}
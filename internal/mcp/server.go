@@ -0,0 +1,209 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Provider supplies the tools and resources exposed by a Server.
+type Provider interface {
+	// ListTools returns the available tools.
+	ListTools(ctx context.Context) ([]Tool, error)
+	// CallTool runs a tool. Unknown tools should return an *Error with
+	// CodeInvalidParams; failures during execution belong in the result.
+	CallTool(ctx context.Context, name string, args map[string]interface{}) (*ToolResult, error)
+	// ListResources returns the available resources.
+	ListResources(ctx context.Context) ([]Resource, error)
+	// ReadResource returns the contents of a resource.
+	ReadResource(ctx context.Context, uri string) (*ResourceContents, error)
+}
+
+// Server dispatches MCP JSON-RPC messages to a Provider.
+type Server struct {
+	name     string
+	version  string
+	provider Provider
+}
+
+// NewServer creates a new MCP server.
+func NewServer(name, version string, provider Provider) *Server {
+	return &Server{
+		name:     name,
+		version:  version,
+		provider: provider,
+	}
+}
+
+// HandleMessage decodes a single JSON-RPC message and returns the encoded
+// response, or nil if the message was a notification.
+func (s *Server) HandleMessage(ctx context.Context, data []byte) []byte {
+	var req Request
+	if err := json.Unmarshal(data, &req); err != nil {
+		return encodeResponse(&Response{
+			JSONRPC: "2.0",
+			ID:      json.RawMessage("null"),
+			Error:   &Error{Code: CodeParseError, Message: "parse error"},
+		})
+	}
+
+	resp := s.Handle(ctx, &req)
+	if resp == nil {
+		return nil
+	}
+	return encodeResponse(resp)
+}
+
+// Handle dispatches a decoded request. It returns nil for notifications.
+func (s *Server) Handle(ctx context.Context, req *Request) *Response {
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		if req.IsNotification() {
+			return nil
+		}
+		return errorResponse(req.ID, &Error{Code: CodeInvalidRequest, Message: "invalid request"})
+	}
+
+	result, err := s.dispatch(ctx, req)
+	if req.IsNotification() {
+		return nil
+	}
+	if err != nil {
+		var rpcErr *Error
+		if !errors.As(err, &rpcErr) {
+			rpcErr = &Error{Code: CodeInternalError, Message: err.Error()}
+		}
+		return errorResponse(req.ID, rpcErr)
+	}
+
+	if result == nil {
+		result = map[string]interface{}{}
+	}
+	return &Response{JSONRPC: "2.0", ID: req.ID, Result: result}
+}
+
+func (s *Server) dispatch(ctx context.Context, req *Request) (interface{}, error) {
+	switch req.Method {
+	case "initialize":
+		var params initializeParams
+		if len(req.Params) > 0 {
+			if err := json.Unmarshal(req.Params, &params); err != nil {
+				return nil, &Error{Code: CodeInvalidParams, Message: "invalid initialize params"}
+			}
+		}
+		return map[string]interface{}{
+			"protocolVersion": negotiateVersion(params.ProtocolVersion),
+			"capabilities": map[string]interface{}{
+				"tools":     map[string]interface{}{},
+				"resources": map[string]interface{}{},
+			},
+			"serverInfo": map[string]interface{}{
+				"name":    s.name,
+				"version": s.version,
+			},
+		}, nil
+
+	case "ping":
+		return map[string]interface{}{}, nil
+
+	case "tools/list":
+		tools, err := s.provider.ListTools(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"tools": tools}, nil
+
+	case "tools/call":
+		var params callToolParams
+		if err := json.Unmarshal(req.Params, &params); err != nil || params.Name == "" {
+			return nil, &Error{Code: CodeInvalidParams, Message: "tools/call requires a tool name"}
+		}
+		if params.Arguments == nil {
+			params.Arguments = map[string]interface{}{}
+		}
+		result, err := s.provider.CallTool(ctx, params.Name, params.Arguments)
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
+
+	case "resources/list":
+		resources, err := s.provider.ListResources(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"resources": resources}, nil
+
+	case "resources/read":
+		var params readResourceParams
+		if err := json.Unmarshal(req.Params, &params); err != nil || params.URI == "" {
+			return nil, &Error{Code: CodeInvalidParams, Message: "resources/read requires a uri"}
+		}
+		contents, err := s.provider.ReadResource(ctx, params.URI)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"contents": []*ResourceContents{contents}}, nil
+
+	default:
+		// Client notifications such as notifications/initialized need no handling
+		if req.IsNotification() {
+			return nil, nil
+		}
+		return nil, &Error{Code: CodeMethodNotFound, Message: fmt.Sprintf("method not found: %s", req.Method)}
+	}
+}
+
+// ServeStdio reads newline-delimited JSON-RPC messages from r and writes
+// responses to w until r is exhausted or ctx is cancelled.
+func (s *Server) ServeStdio(ctx context.Context, r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+
+	for scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		resp := s.HandleMessage(ctx, line)
+		if resp == nil {
+			continue
+		}
+		if _, err := w.Write(append(resp, '\n')); err != nil {
+			return fmt.Errorf("failed to write response: %w", err)
+		}
+	}
+
+	return scanner.Err()
+}
+
+func negotiateVersion(requested string) string {
+	for _, v := range supportedProtocolVersions {
+		if v == requested {
+			return v
+		}
+	}
+	return LatestProtocolVersion
+}
+
+func errorResponse(id json.RawMessage, err *Error) *Response {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	return &Response{JSONRPC: "2.0", ID: id, Error: err}
+}
+
+func encodeResponse(resp *Response) []byte {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		data, _ = json.Marshal(errorResponse(resp.ID, &Error{Code: CodeInternalError, Message: "failed to encode response"}))
+	}
+	return data
+}
@@ -0,0 +1,421 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sanixdarker/skill-md/pkg/skill"
+)
+
+const testSkill = `---
+name: "Pet API"
+version: "1.0.0"
+base_url: "https://api.example.com"
+tools:
+  - name: "get_pets_id"
+    description: "Get a pet"
+    method: "GET"
+    path: "/pets/{id}"
+    parameters:
+      type: "object"
+      properties:
+        id:
+          type: "string"
+        verbose:
+          type: "boolean"
+    required:
+      - "id"
+  - name: "post_pets"
+    description: "Create a pet"
+    method: "POST"
+    path: "/pets"
+    parameters:
+      type: "object"
+      properties:
+        body:
+          type: "object"
+---
+
+## Overview
+
+Pets and owners.
+
+## Endpoints
+
+GET /pets/{id}
+`
+
+func newTestServer(t *testing.T, baseURL string) *Server {
+	t.Helper()
+	s, err := skill.Parse(testSkill)
+	if err != nil {
+		t.Fatalf("failed to parse skill: %v", err)
+	}
+	provider := NewSkillProvider(SkillProviderConfig{
+		Skill:   s,
+		BaseURL: baseURL,
+		Headers: http.Header{"Authorization": []string{"Bearer secret"}},
+	})
+	return NewServer("skillmd", "test", provider)
+}
+
+func call(t *testing.T, srv *Server, method string, params interface{}) map[string]interface{} {
+	t.Helper()
+	req := map[string]interface{}{"jsonrpc": "2.0", "id": 1, "method": method}
+	if params != nil {
+		req["params"] = params
+	}
+	data, _ := json.Marshal(req)
+
+	var resp map[string]interface{}
+	if err := json.Unmarshal(srv.HandleMessage(context.Background(), data), &resp); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	return resp
+}
+
+func TestServer_Initialize(t *testing.T) {
+	srv := newTestServer(t, "")
+
+	resp := call(t, srv, "initialize", map[string]interface{}{"protocolVersion": "2024-11-05"})
+	result := resp["result"].(map[string]interface{})
+	if result["protocolVersion"] != "2024-11-05" {
+		t.Errorf("expected requested version to be accepted, got %v", result["protocolVersion"])
+	}
+
+	resp = call(t, srv, "initialize", map[string]interface{}{"protocolVersion": "1999-01-01"})
+	result = resp["result"].(map[string]interface{})
+	if result["protocolVersion"] != LatestProtocolVersion {
+		t.Errorf("expected latest version for unknown request, got %v", result["protocolVersion"])
+	}
+}
+
+func TestServer_NotificationHasNoResponse(t *testing.T) {
+	srv := newTestServer(t, "")
+	resp := srv.HandleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","method":"notifications/initialized"}`))
+	if resp != nil {
+		t.Errorf("expected no response for notification, got %s", resp)
+	}
+}
+
+func TestServer_MethodNotFound(t *testing.T) {
+	srv := newTestServer(t, "")
+	resp := call(t, srv, "prompts/list", nil)
+	rpcErr, ok := resp["error"].(map[string]interface{})
+	if !ok || rpcErr["code"].(float64) != CodeMethodNotFound {
+		t.Errorf("expected method not found error, got %v", resp)
+	}
+}
+
+func TestServer_ToolsList(t *testing.T) {
+	srv := newTestServer(t, "")
+	resp := call(t, srv, "tools/list", nil)
+
+	tools := resp["result"].(map[string]interface{})["tools"].([]interface{})
+	if len(tools) != 2 {
+		t.Fatalf("expected 2 tools, got %d", len(tools))
+	}
+	first := tools[0].(map[string]interface{})
+	schema := first["inputSchema"].(map[string]interface{})
+	required, _ := schema["required"].([]interface{})
+	if len(required) != 1 || required[0] != "id" {
+		t.Errorf("expected required to be folded into input schema, got %v", schema)
+	}
+}
+
+func TestServer_ToolsCall(t *testing.T) {
+	var gotMethod, gotPath, gotQuery, gotAuth, gotBody string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		gotPath = r.URL.Path
+		gotQuery = r.URL.RawQuery
+		gotAuth = r.Header.Get("Authorization")
+		data, _ := io.ReadAll(r.Body)
+		gotBody = string(data)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"42"}`))
+	}))
+	defer upstream.Close()
+
+	srv := newTestServer(t, upstream.URL)
+
+	resp := call(t, srv, "tools/call", map[string]interface{}{
+		"name":      "get_pets_id",
+		"arguments": map[string]interface{}{"id": "42", "verbose": true},
+	})
+	result := resp["result"].(map[string]interface{})
+	if result["isError"] == true {
+		t.Fatalf("unexpected tool error: %v", result)
+	}
+	if gotMethod != "GET" || gotPath != "/pets/42" || gotQuery != "verbose=true" {
+		t.Errorf("unexpected upstream request: %s %s?%s", gotMethod, gotPath, gotQuery)
+	}
+	if gotAuth != "Bearer secret" {
+		t.Errorf("expected configured header, got %q", gotAuth)
+	}
+	text := result["content"].([]interface{})[0].(map[string]interface{})["text"].(string)
+	if !strings.Contains(text, `{"id":"42"}`) {
+		t.Errorf("expected upstream body in result, got %q", text)
+	}
+
+	call(t, srv, "tools/call", map[string]interface{}{
+		"name":      "post_pets",
+		"arguments": map[string]interface{}{"body": map[string]interface{}{"name": "rex"}},
+	})
+	if gotMethod != "POST" || gotBody != `{"name":"rex"}` {
+		t.Errorf("unexpected upstream request: %s body %s", gotMethod, gotBody)
+	}
+}
+
+func TestSkillProvider_ArgumentLocations(t *testing.T) {
+	var gotQuery, gotTrace, gotType, gotBody string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.RawQuery
+		gotTrace = r.Header.Get("X-Trace")
+		gotType = r.Header.Get("Content-Type")
+		data, _ := io.ReadAll(r.Body)
+		gotBody = string(data)
+	}))
+	defer upstream.Close()
+
+	provider := NewSkillProvider(SkillProviderConfig{
+		Skill: &skill.Skill{Frontmatter: skill.Frontmatter{
+			Name: "Login API",
+			ToolDefinitions: []skill.ToolDefinition{{
+				Name:     "post_login",
+				Method:   "POST",
+				Path:     "/login",
+				Location: map[string]string{"X-Trace": "header", "body": "form"},
+			}},
+		}},
+		BaseURL: upstream.URL,
+	})
+
+	result, err := provider.CallTool(context.Background(), "post_login", map[string]interface{}{
+		"X-Trace": "abc",
+		"body":    map[string]interface{}{"user": "rex", "pass": "s3cret"},
+	})
+	if err != nil || result.IsError {
+		t.Fatalf("unexpected call failure: %v %+v", err, result)
+	}
+	if gotQuery != "" {
+		t.Errorf("expected no query string, got %q", gotQuery)
+	}
+	if gotTrace != "abc" {
+		t.Errorf("expected header argument to be sent as a header, got %q", gotTrace)
+	}
+	if gotType != "application/x-www-form-urlencoded" || gotBody != "pass=s3cret&user=rex" {
+		t.Errorf("expected URL-encoded form body, got %s %q", gotType, gotBody)
+	}
+}
+
+func TestSkillProvider_RenamedArguments(t *testing.T) {
+	var gotPath, gotQuery, gotBody string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotQuery = r.URL.RawQuery
+		data, _ := io.ReadAll(r.Body)
+		gotBody = string(data)
+	}))
+	defer upstream.Close()
+
+	provider := NewSkillProvider(SkillProviderConfig{
+		Skill: &skill.Skill{Frontmatter: skill.Frontmatter{
+			Name: "Doc API",
+			ToolDefinitions: []skill.ToolDefinition{{
+				Name:       "updatedoc",
+				Method:     "PUT",
+				Path:       "/docs/{id}",
+				Location:   map[string]string{"path_id": "path", "query_id": "query", "query_body": "query", "body": "body"},
+				ParamNames: map[string]string{"path_id": "id", "query_id": "id", "query_body": "body"},
+			}},
+		}},
+		BaseURL: upstream.URL,
+	})
+
+	result, err := provider.CallTool(context.Background(), "updatedoc", map[string]interface{}{
+		"path_id":    "a1",
+		"query_id":   "b2",
+		"query_body": "full",
+		"body":       map[string]interface{}{"title": "Notes"},
+	})
+	if err != nil || result.IsError {
+		t.Fatalf("unexpected call failure: %v %+v", err, result)
+	}
+	if gotPath != "/docs/a1" {
+		t.Errorf("expected path_id in the path, got %q", gotPath)
+	}
+	if gotQuery != "body=full&id=b2" {
+		t.Errorf("expected query arguments under their wire names, got %q", gotQuery)
+	}
+	if gotBody != `{"title":"Notes"}` {
+		t.Errorf("expected JSON body, got %q", gotBody)
+	}
+}
+
+func TestSkillProvider_FormFieldArguments(t *testing.T) {
+	var gotType, gotBody string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotType = r.Header.Get("Content-Type")
+		data, _ := io.ReadAll(r.Body)
+		gotBody = string(data)
+	}))
+	defer upstream.Close()
+
+	provider := NewSkillProvider(SkillProviderConfig{
+		Skill: &skill.Skill{Frontmatter: skill.Frontmatter{
+			Name: "Pet API",
+			ToolDefinitions: []skill.ToolDefinition{{
+				Name:     "renamepet",
+				Method:   "POST",
+				Path:     "/pets/{petId}",
+				Location: map[string]string{"petId": "path", "name": "form", "tag": "form"},
+			}},
+		}},
+		BaseURL: upstream.URL,
+	})
+
+	result, err := provider.CallTool(context.Background(), "renamepet", map[string]interface{}{
+		"petId": 7,
+		"name":  "Rex",
+		"tag":   "dog",
+	})
+	if err != nil || result.IsError {
+		t.Fatalf("unexpected call failure: %v %+v", err, result)
+	}
+	if gotType != "application/x-www-form-urlencoded" || gotBody != "name=Rex&tag=dog" {
+		t.Errorf("expected both fields in one form body, got %q %q", gotType, gotBody)
+	}
+}
+
+func TestSkillProvider_GraphQLTool(t *testing.T) {
+	var gotMethod, gotPath, gotBody string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		gotPath = r.URL.Path
+		data, _ := io.ReadAll(r.Body)
+		gotBody = string(data)
+		w.Write([]byte(`{"data":{"user":{"id":"1"}}}`))
+	}))
+	defer upstream.Close()
+
+	provider := NewSkillProvider(SkillProviderConfig{
+		Skill: &skill.Skill{Frontmatter: skill.Frontmatter{
+			Name: "Users",
+			ToolDefinitions: []skill.ToolDefinition{{
+				Name:     "query_user",
+				Method:   "POST",
+				GraphQL:  "query User($id: ID!) { user(id: $id) { id } }",
+				Required: []string{"id"},
+			}},
+		}},
+		BaseURL: upstream.URL + "/graphql",
+	})
+
+	result, err := provider.CallTool(context.Background(), "query_user", map[string]interface{}{"id": "1"})
+	if err != nil || result.IsError {
+		t.Fatalf("unexpected call failure: %v %+v", err, result)
+	}
+	if gotMethod != "POST" || gotPath != "/graphql" {
+		t.Errorf("expected a POST to the endpoint, got %s %s", gotMethod, gotPath)
+	}
+	if gotBody != `{"query":"query User($id: ID!) { user(id: $id) { id } }","variables":{"id":"1"}}` {
+		t.Errorf("unexpected GraphQL request body: %s", gotBody)
+	}
+}
+
+func TestServer_ToolsCall_Errors(t *testing.T) {
+	srv := newTestServer(t, "")
+
+	resp := call(t, srv, "tools/call", map[string]interface{}{"name": "nope"})
+	if _, ok := resp["error"]; !ok {
+		t.Errorf("expected protocol error for unknown tool, got %v", resp)
+	}
+
+	resp = call(t, srv, "tools/call", map[string]interface{}{"name": "get_pets_id", "arguments": map[string]interface{}{}})
+	result := resp["result"].(map[string]interface{})
+	if result["isError"] != true {
+		t.Errorf("expected tool error for missing argument, got %v", result)
+	}
+}
+
+func TestServer_Resources(t *testing.T) {
+	srv := newTestServer(t, "")
+
+	resp := call(t, srv, "resources/list", nil)
+	resources := resp["result"].(map[string]interface{})["resources"].([]interface{})
+	if len(resources) != 3 {
+		t.Fatalf("expected skill plus 2 sections, got %d", len(resources))
+	}
+	overview := resources[1].(map[string]interface{})
+	if overview["name"] != "Overview" {
+		t.Errorf("expected Overview section resource, got %v", overview)
+	}
+
+	resp = call(t, srv, "resources/read", map[string]interface{}{"uri": overview["uri"]})
+	contents := resp["result"].(map[string]interface{})["contents"].([]interface{})
+	text := contents[0].(map[string]interface{})["text"].(string)
+	if !strings.Contains(text, "Pets and owners.") {
+		t.Errorf("expected section content, got %q", text)
+	}
+}
+
+func TestServer_ServeStdio(t *testing.T) {
+	srv := newTestServer(t, "")
+
+	in := strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"ping"}
+{"jsonrpc":"2.0","method":"notifications/initialized"}
+not json
+`)
+	var out bytes.Buffer
+	if err := srv.ServeStdio(context.Background(), in, &out); err != nil {
+		t.Fatalf("serve failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 responses, got %d: %q", len(lines), out.String())
+	}
+	if !strings.Contains(lines[1], `"code":-32700`) {
+		t.Errorf("expected parse error for invalid line, got %s", lines[1])
+	}
+}
+
+func TestSkillProvider_PerToolBaseURL(t *testing.T) {
+	var hits []string
+	newUpstream := func(name string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			hits = append(hits, name+" "+r.URL.Path)
+		}))
+	}
+	users := newUpstream("users")
+	defer users.Close()
+	products := newUpstream("products")
+	defer products.Close()
+
+	provider := NewSkillProvider(SkillProviderConfig{
+		Skill: &skill.Skill{Frontmatter: skill.Frontmatter{
+			Name:    "Shop",
+			BaseURL: users.URL,
+			ToolDefinitions: []skill.ToolDefinition{
+				{Name: "list_users", Method: "GET", Path: "/users"},
+				{Name: "list_products", Method: "GET", Path: "/products", BaseURL: products.URL},
+			},
+		}},
+	})
+
+	for _, name := range []string{"list_users", "list_products"} {
+		if result, err := provider.CallTool(context.Background(), name, nil); err != nil || result.IsError {
+			t.Fatalf("unexpected call failure for %s: %v %+v", name, err, result)
+		}
+	}
+	if strings.Join(hits, ",") != "users /users,products /products" {
+		t.Errorf("expected each tool to use its own base URL, got %v", hits)
+	}
+}
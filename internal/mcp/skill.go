@@ -0,0 +1,357 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sanixdarker/skill-md/pkg/skill"
+)
+
+// maxResponseBytes caps how much of an upstream response is returned to the model.
+const maxResponseBytes = 1 << 20
+
+// SkillProviderConfig holds configuration for a SkillProvider.
+type SkillProviderConfig struct {
+	Skill *skill.Skill
+	// BaseURL overrides Frontmatter.BaseURL and per-tool base URLs when set.
+	BaseURL string
+	// Headers are added to every upstream request (e.g. Authorization).
+	Headers http.Header
+	Client  *http.Client
+}
+
+// SkillProvider exposes a single SKILL.md: its tool definitions become
+// callable tools backed by HTTP requests, and its sections become resources.
+type SkillProvider struct {
+	skill   *skill.Skill
+	baseURL string
+	// pinned is set when the base URL came from the config, in which case it
+	// also overrides per-tool base URLs.
+	pinned  bool
+	headers http.Header
+	client  *http.Client
+	slug    string
+}
+
+// NewSkillProvider creates a provider for the given skill.
+func NewSkillProvider(cfg SkillProviderConfig) *SkillProvider {
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = cfg.Skill.Frontmatter.BaseURL
+	}
+
+	client := cfg.Client
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	return &SkillProvider{
+		skill:   cfg.Skill,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		pinned:  cfg.BaseURL != "",
+		headers: cfg.Headers,
+		client:  client,
+		slug:    slugify(cfg.Skill.Frontmatter.Name),
+	}
+}
+
+// ListTools returns the skill's tool definitions.
+func (p *SkillProvider) ListTools(ctx context.Context) ([]Tool, error) {
+	tools := make([]Tool, 0, len(p.skill.Frontmatter.ToolDefinitions))
+	for _, def := range p.skill.Frontmatter.ToolDefinitions {
+		tools = append(tools, ToolFromDefinition(def))
+	}
+	return tools, nil
+}
+
+// CallTool performs the HTTP request or GraphQL operation recorded for the
+// tool.
+func (p *SkillProvider) CallTool(ctx context.Context, name string, args map[string]interface{}) (*ToolResult, error) {
+	var def *skill.ToolDefinition
+	for i := range p.skill.Frontmatter.ToolDefinitions {
+		if p.skill.Frontmatter.ToolDefinitions[i].Name == name {
+			def = &p.skill.Frontmatter.ToolDefinitions[i]
+			break
+		}
+	}
+	if def == nil {
+		return nil, &Error{Code: CodeInvalidParams, Message: fmt.Sprintf("unknown tool: %s", name)}
+	}
+
+	if def.Method == "" || (def.Path == "" && def.GraphQL == "") {
+		return TextResult(fmt.Sprintf("tool %s records no HTTP operation or GraphQL document to call", name), true), nil
+	}
+	if p.toolBaseURL(def) == "" {
+		return TextResult("no base URL configured; set base_url in the skill or pass --base-url", true), nil
+	}
+
+	for _, req := range def.Required {
+		if _, ok := args[req]; !ok {
+			return TextResult(fmt.Sprintf("missing required argument: %s", req), true), nil
+		}
+	}
+
+	httpReq, err := p.buildRequest(ctx, def, args)
+	if err != nil {
+		return TextResult(err.Error(), true), nil
+	}
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return TextResult(fmt.Sprintf("request failed: %v", err), true), nil
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return TextResult(fmt.Sprintf("failed to read response: %v", err), true), nil
+	}
+
+	text := fmt.Sprintf("HTTP %d\n\n%s", resp.StatusCode, string(body))
+	return TextResult(text, resp.StatusCode >= 400), nil
+}
+
+// buildRequest maps tool arguments onto the recorded operation. Each argument
+// goes where def.Location says, under the name def.ParamNames gives it;
+// arguments without a recorded location fill a matching {placeholder}, become
+// the JSON body when named "body", and are otherwise sent as query
+// parameters. A GraphQL tool sends its document with the arguments as
+// variables instead.
+func (p *SkillProvider) buildRequest(ctx context.Context, def *skill.ToolDefinition, args map[string]interface{}) (*http.Request, error) {
+	path := def.Path
+	query := url.Values{}
+	headers := http.Header{}
+	var body io.Reader
+	var contentType string
+
+	if def.GraphQL != "" {
+		data, err := json.Marshal(map[string]interface{}{"query": def.GraphQL, "variables": args})
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode variables: %w", err)
+		}
+		body = bytes.NewReader(data)
+		contentType = "application/json"
+		args = nil
+	}
+
+	// Form and multipart arguments are either a whole object body or, as in
+	// Swagger 2 formData, one field each
+	var form url.Values
+	multipartForm := false
+
+	for arg, value := range args {
+		key := def.ParamName(arg)
+		location := def.Location[arg]
+		if location == "" {
+			switch {
+			case strings.Contains(path, "{"+key+"}"):
+				location = "path"
+			case key == "body":
+				location = "body"
+			default:
+				location = "query"
+			}
+		}
+
+		switch location {
+		case "path":
+			path = strings.ReplaceAll(path, "{"+key+"}", url.PathEscape(fmt.Sprint(value)))
+		case "header":
+			headers.Set(key, fmt.Sprint(value))
+		case "body":
+			data, err := json.Marshal(value)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode body: %w", err)
+			}
+			body = bytes.NewReader(data)
+			contentType = "application/json"
+		case "form", "multipart":
+			if form == nil {
+				form = url.Values{}
+			}
+			if fields, ok := value.(map[string]interface{}); ok {
+				for field, v := range fields {
+					addValues(form, field, v)
+				}
+			} else {
+				addValues(form, key, value)
+			}
+			multipartForm = multipartForm || location == "multipart"
+		default:
+			addValues(query, key, value)
+		}
+	}
+
+	switch {
+	case form != nil && multipartForm:
+		data, boundary, err := multipartBody(form)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode form: %w", err)
+		}
+		body = bytes.NewReader(data)
+		contentType = "multipart/form-data; boundary=" + boundary
+	case form != nil:
+		body = strings.NewReader(form.Encode())
+		contentType = "application/x-www-form-urlencoded"
+	}
+
+	if strings.Contains(path, "{") {
+		return nil, fmt.Errorf("unresolved path parameters in %s", path)
+	}
+
+	target := p.toolBaseURL(def) + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, strings.ToUpper(def.Method), target, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	for key, values := range headers {
+		req.Header[key] = values
+	}
+	for key, values := range p.headers {
+		for _, v := range values {
+			req.Header.Add(key, v)
+		}
+	}
+
+	return req, nil
+}
+
+// toolBaseURL returns the base URL requests for def are sent to.
+func (p *SkillProvider) toolBaseURL(def *skill.ToolDefinition) string {
+	if def.BaseURL != "" && !p.pinned {
+		return strings.TrimSuffix(def.BaseURL, "/")
+	}
+	return p.baseURL
+}
+
+// addValues adds a scalar or list argument to v.
+func addValues(v url.Values, key string, value interface{}) {
+	if list, ok := value.([]interface{}); ok {
+		for _, item := range list {
+			v.Add(key, fmt.Sprint(item))
+		}
+		return
+	}
+	v.Set(key, fmt.Sprint(value))
+}
+
+// multipartBody encodes form fields as multipart/form-data. Fields are
+// written in key order so the body is reproducible.
+func multipartBody(values url.Values) ([]byte, string, error) {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	for _, key := range keys {
+		for _, v := range values[key] {
+			if err := w.WriteField(key, v); err != nil {
+				return nil, "", err
+			}
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), w.Boundary(), nil
+}
+
+// ListResources returns the full skill followed by one resource per section.
+func (p *SkillProvider) ListResources(ctx context.Context) ([]Resource, error) {
+	resources := []Resource{{
+		URI:         p.skillURI(),
+		Name:        p.skill.Frontmatter.Name,
+		Description: p.skill.Frontmatter.Description,
+		MimeType:    "text/markdown",
+	}}
+
+	for i, section := range p.skill.Sections {
+		resources = append(resources, Resource{
+			URI:      fmt.Sprintf("%s/sections/%d", p.skillURI(), i),
+			Name:     section.Title,
+			MimeType: "text/markdown",
+		})
+	}
+
+	return resources, nil
+}
+
+// ReadResource returns the rendered skill or a single section.
+func (p *SkillProvider) ReadResource(ctx context.Context, uri string) (*ResourceContents, error) {
+	if uri == p.skillURI() {
+		return &ResourceContents{URI: uri, MimeType: "text/markdown", Text: skill.Render(p.skill)}, nil
+	}
+
+	for i, section := range p.skill.Sections {
+		if uri == fmt.Sprintf("%s/sections/%d", p.skillURI(), i) {
+			text := fmt.Sprintf("%s %s\n\n%s", strings.Repeat("#", section.Level), section.Title, section.Content)
+			return &ResourceContents{URI: uri, MimeType: "text/markdown", Text: text}, nil
+		}
+	}
+
+	return nil, &Error{Code: CodeInvalidParams, Message: fmt.Sprintf("resource not found: %s", uri)}
+}
+
+func (p *SkillProvider) skillURI() string {
+	return "skill://" + p.slug
+}
+
+// ToolFromDefinition converts a SKILL.md tool definition into an MCP tool,
+// folding the required list into the input schema.
+func ToolFromDefinition(def skill.ToolDefinition) Tool {
+	schema := make(map[string]interface{}, len(def.Parameters)+1)
+	for k, v := range def.Parameters {
+		schema[k] = v
+	}
+	if _, ok := schema["type"]; !ok {
+		schema["type"] = "object"
+	}
+	if len(def.Required) > 0 {
+		schema["required"] = def.Required
+	}
+
+	return Tool{
+		Name:        def.Name,
+		Description: def.Description,
+		InputSchema: schema,
+	}
+}
+
+// slugify lowercases a name and keeps only letters, digits and dashes.
+func slugify(name string) string {
+	slug := strings.ToLower(strings.ReplaceAll(name, " ", "-"))
+	slug = strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' {
+			return r
+		}
+		return -1
+	}, slug)
+	for strings.Contains(slug, "--") {
+		slug = strings.ReplaceAll(slug, "--", "-")
+	}
+	slug = strings.Trim(slug, "-")
+	if slug == "" {
+		slug = "skill"
+	}
+	return slug
+}
@@ -1,6 +1,7 @@
 package merger
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/sanixdarker/skill-md/pkg/skill"
@@ -20,6 +21,33 @@ const (
 	Combine
 )
 
+// strategyNames maps the names accepted by ParseStrategy to strategies.
+var strategyNames = map[string]ConflictStrategy{
+	"first":   KeepFirst,
+	"last":    KeepLast,
+	"longer":  KeepLonger,
+	"combine": Combine,
+}
+
+// ParseStrategy parses a strategy name: first, last, longer or combine.
+func ParseStrategy(name string) (ConflictStrategy, error) {
+	strategy, ok := strategyNames[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return 0, fmt.Errorf("unknown conflict strategy %q (use first, last, longer or combine)", name)
+	}
+	return strategy, nil
+}
+
+// String returns the name ParseStrategy accepts for s.
+func (s ConflictStrategy) String() string {
+	for name, strategy := range strategyNames {
+		if strategy == s {
+			return name
+		}
+	}
+	return fmt.Sprintf("ConflictStrategy(%d)", int(s))
+}
+
 // ConflictResolver handles merge conflicts.
 type ConflictResolver struct {
 	strategy ConflictStrategy
@@ -100,79 +128,114 @@ func (r *ConflictResolver) ResolveSections(sections []skill.Section) skill.Secti
 	return result
 }
 
-// Conflict represents a merge conflict.
+// Conflict represents a merge conflict. Values holds the competing values
+// and Sources the input each one came from.
 type Conflict struct {
-	Field    string
-	Values   []string
-	Resolved string
+	Field    string   `json:"field"`
+	Values   []string `json:"values"`
+	Sources  []string `json:"sources,omitempty"`
+	Resolved string   `json:"resolved,omitempty"`
 }
 
 // DetectConflicts detects potential conflicts between skills.
 func DetectConflicts(skills []*skill.Skill) []Conflict {
-	var conflicts []Conflict
+	sources := sourceNames(skills, nil)
+	conflicts := fieldConflicts(skills, sources)
 
-	// Check name conflicts
-	names := make(map[string]int)
+	var baseURL string
 	for _, s := range skills {
-		if s.Frontmatter.Name != "" {
-			names[s.Frontmatter.Name]++
+		if baseURL = s.Frontmatter.BaseURL; baseURL != "" {
+			break
 		}
 	}
-	if len(names) > 1 {
-		values := make([]string, 0, len(names))
-		for name := range names {
-			values = append(values, name)
-		}
-		conflicts = append(conflicts, Conflict{
-			Field:  "name",
-			Values: values,
-		})
+	if c := baseURLConflict(skills, sources, baseURL); c != nil {
+		c.Resolved = ""
+		conflicts = append(conflicts, *c)
 	}
 
-	// Check version conflicts
-	versions := make(map[string]int)
-	for _, s := range skills {
-		if s.Frontmatter.Version != "" {
-			versions[s.Frontmatter.Version]++
-		}
+	_, extensionConflicts := mergeExtensions(skills, sources, nil)
+	for _, c := range extensionConflicts {
+		c.Resolved = ""
+		conflicts = append(conflicts, c)
 	}
-	if len(versions) > 1 {
-		values := make([]string, 0, len(versions))
-		for ver := range versions {
-			values = append(values, ver)
+
+	var walk func(nodes []*sectionNode)
+	walk = func(nodes []*sectionNode) {
+		for _, node := range nodes {
+			if c := sectionConflict(node); c != nil {
+				conflicts = append(conflicts, *c)
+			}
+			walk(node.children)
 		}
-		conflicts = append(conflicts, Conflict{
-			Field:  "version",
-			Values: values,
-		})
 	}
+	walk(sectionTree(skills, sources))
 
-	// Check section title conflicts (same title, different content)
-	sectionsByTitle := make(map[string][]string)
-	for _, s := range skills {
-		for _, sec := range s.Sections {
-			key := strings.ToLower(sec.Title)
-			sectionsByTitle[key] = append(sectionsByTitle[key], sec.Content)
+	return conflicts
+}
+
+// fieldConflicts reports frontmatter fields the skills disagree on.
+func fieldConflicts(skills []*skill.Skill, sources []string) []Conflict {
+	var conflicts []Conflict
+	fields := []struct {
+		name  string
+		value func(*skill.Skill) string
+	}{
+		{"name", func(s *skill.Skill) string { return s.Frontmatter.Name }},
+		{"version", func(s *skill.Skill) string { return s.Frontmatter.Version }},
+	}
+
+	for _, field := range fields {
+		c := Conflict{Field: field.name}
+		seen := make(map[string]bool)
+		for i, s := range skills {
+			v := field.value(s)
+			if v == "" || seen[v] {
+				continue
+			}
+			seen[v] = true
+			c.Values = append(c.Values, v)
+			c.Sources = append(c.Sources, sources[i])
+		}
+		if len(c.Values) > 1 {
+			conflicts = append(conflicts, c)
 		}
 	}
 
-	for title, contents := range sectionsByTitle {
-		if len(contents) <= 1 {
+	return conflicts
+}
+
+// sectionConflict reports a section node whose bodies differ, or nil.
+func sectionConflict(node *sectionNode) *Conflict {
+	c := &Conflict{Field: "section:" + node.path}
+	seen := make(map[string]bool)
+	for _, sec := range node.group {
+		content := strings.TrimSpace(sec.section.Content)
+		if content == "" || seen[content] {
 			continue
 		}
+		seen[content] = true
+		c.Values = append(c.Values, content)
+		c.Sources = append(c.Sources, sec.source)
+	}
+	if len(c.Values) <= 1 {
+		return nil
+	}
+	return c
+}
 
-		// Check if contents differ
-		unique := make(map[string]bool)
-		for _, c := range contents {
-			unique[c] = true
-		}
-		if len(unique) > 1 {
-			conflicts = append(conflicts, Conflict{
-				Field:  "section:" + title,
-				Values: contents,
-			})
-		}
+// FormatReport renders conflicts as a markdown list for review.
+func FormatReport(conflicts []Conflict) string {
+	if len(conflicts) == 0 {
+		return "No conflicts detected.\n"
 	}
 
-	return conflicts
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("# Merge conflicts (%d)\n\n", len(conflicts)))
+	for _, c := range conflicts {
+		b.WriteString(fmt.Sprintf("- **%s**: %s\n", c.Field, strings.Join(c.Sources, ", ")))
+		if c.Resolved != "" {
+			b.WriteString(fmt.Sprintf("  - resolution: %s\n", c.Resolved))
+		}
+	}
+	return b.String()
 }
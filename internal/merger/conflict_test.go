@@ -0,0 +1,92 @@
+package merger
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sanixdarker/skill-md/pkg/skill"
+)
+
+func TestParseStrategy(t *testing.T) {
+	for _, strategy := range []ConflictStrategy{KeepFirst, KeepLast, KeepLonger, Combine} {
+		parsed, err := ParseStrategy(strategy.String())
+		if err != nil || parsed != strategy {
+			t.Errorf("round trip of %v failed: %v %v", strategy, parsed, err)
+		}
+	}
+	if _, err := ParseStrategy("newest"); err == nil {
+		t.Error("expected an error for an unknown strategy")
+	}
+}
+
+func TestConflictResolver_ResolveString(t *testing.T) {
+	values := []string{"short", "", "the longest value", "last"}
+	tests := map[ConflictStrategy]string{
+		KeepFirst:  "short",
+		KeepLast:   "last",
+		KeepLonger: "the longest value",
+		Combine:    "short\n\nthe longest value\n\nlast",
+	}
+	for strategy, want := range tests {
+		if got := NewConflictResolver(strategy).ResolveString(values); got != want {
+			t.Errorf("%v: expected %q, got %q", strategy, want, got)
+		}
+	}
+}
+
+func TestDetectConflicts(t *testing.T) {
+	skill1 := skill.NewSkill("API 1", "")
+	skill1.Sections = []skill.Section{
+		{Title: "Overview", Level: 2, Content: "Same"},
+		{Title: "Usage", Level: 2, Content: "Call it"},
+	}
+	skill2 := skill.NewSkill("API 2", "")
+	skill2.Sections = []skill.Section{
+		{Title: "Overview", Level: 2, Content: "Same"},
+		{Title: "Usage", Level: 2, Content: "Call it differently"},
+	}
+
+	conflicts := DetectConflicts([]*skill.Skill{skill1, skill2})
+	var fields []string
+	for _, c := range conflicts {
+		fields = append(fields, c.Field)
+	}
+	if strings.Join(fields, ",") != "name,section:Usage" {
+		t.Fatalf("unexpected conflicts: %v", fields)
+	}
+	if got := conflicts[1].Sources; len(got) != 2 || got[0] != "API 1" || got[1] != "API 2" {
+		t.Errorf("expected conflict sources, got %v", got)
+	}
+
+	skill1.Frontmatter.Extensions = map[string]interface{}{"license": "MIT", "tags": []interface{}{"a"}}
+	skill2.Frontmatter.Extensions = map[string]interface{}{"license": "Apache-2.0", "tags": []interface{}{"b"}}
+	conflicts = DetectConflicts([]*skill.Skill{skill1, skill2})
+	if len(conflicts) != 3 || conflicts[1].Field != "extension:license" || conflicts[1].Resolved != "" {
+		t.Errorf("expected a license conflict, got %+v", conflicts)
+	}
+}
+
+func TestMerger_MergeWithReport_Strategy(t *testing.T) {
+	skill1 := skill.NewSkill("API 1", "")
+	skill1.Sections = []skill.Section{{Title: "Usage", Level: 2, Content: "First usage"}}
+	skill2 := skill.NewSkill("API 2", "")
+	skill2.Sections = []skill.Section{{Title: "Usage", Level: 2, Content: "Second usage"}}
+
+	result, conflicts, err := New().MergeWithReport([]*skill.Skill{skill1, skill2}, &Options{
+		Resolver: NewConflictResolver(KeepLast),
+		Sources:  []string{"a.md", "b.md"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Sections[0].Content != "Second usage" {
+		t.Errorf("expected the last body to be kept, got %q", result.Sections[0].Content)
+	}
+
+	report := FormatReport(conflicts)
+	for _, want := range []string{"**section:Usage**: a.md, b.md", "resolution: last: kept b.md", "**name**"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("expected report to contain %q:\n%s", want, report)
+		}
+	}
+}
@@ -36,35 +36,41 @@ func (d *Deduplicator) DeduplicateSections(sections []skill.Section) []skill.Sec
 		return sections
 	}
 
-	// Compute signatures for each section
-	type sectionWithSig struct {
-		section   skill.Section
-		signature []uint64
-		index     int
-	}
+	duplicates := d.duplicateSections(sections)
 
-	items := make([]sectionWithSig, len(sections))
+	// Filter out duplicates
+	result := make([]skill.Section, 0, len(sections)-len(duplicates))
 	for i, sec := range sections {
-		items[i] = sectionWithSig{
-			section:   sec,
-			signature: d.computeSignature(sec.Content),
-			index:     i,
+		if !duplicates[i] {
+			result = append(result, sec)
 		}
 	}
 
+	return result
+}
+
+// duplicateSections returns the indices of sections that near-duplicate a
+// longer section.
+func (d *Deduplicator) duplicateSections(sections []skill.Section) map[int]bool {
+	// Compute signatures for each section
+	signatures := make([][]uint64, len(sections))
+	for i, sec := range sections {
+		signatures[i] = d.computeSignature(sec.Content)
+	}
+
 	// Find duplicates
 	duplicates := make(map[int]bool)
-	for i := 0; i < len(items); i++ {
+	for i := 0; i < len(sections); i++ {
 		if duplicates[i] {
 			continue
 		}
-		for j := i + 1; j < len(items); j++ {
+		for j := i + 1; j < len(sections); j++ {
 			if duplicates[j] {
 				continue
 			}
-			if d.similarity(items[i].signature, items[j].signature) >= similarityThreshold {
+			if d.similarity(signatures[i], signatures[j]) >= similarityThreshold {
 				// Keep the longer content
-				if len(items[i].section.Content) >= len(items[j].section.Content) {
+				if len(sections[i].Content) >= len(sections[j].Content) {
 					duplicates[j] = true
 				} else {
 					duplicates[i] = true
@@ -74,15 +80,7 @@ func (d *Deduplicator) DeduplicateSections(sections []skill.Section) []skill.Sec
 		}
 	}
 
-	// Filter out duplicates
-	result := make([]skill.Section, 0, len(sections)-len(duplicates))
-	for i, item := range items {
-		if !duplicates[i] {
-			result = append(result, item.section)
-		}
-	}
-
-	return result
+	return duplicates
 }
 
 // computeSignature computes a MinHash signature for text.
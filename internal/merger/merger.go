@@ -2,8 +2,8 @@
 package merger
 
 import (
+	"fmt"
 	"strings"
-	"time"
 
 	"github.com/sanixdarker/skill-md/pkg/skill"
 )
@@ -13,6 +13,15 @@ type Options struct {
 	Name        string
 	Description string
 	Deduplicate bool
+	// Resolver picks the body of sections that share a title but differ.
+	// When nil, every distinct body is kept.
+	Resolver *ConflictResolver
+	// Sources labels the input skills in conflict reports, in order. Skill
+	// names are used for missing labels.
+	Sources []string
+	// OmitCreatedAt leaves created_at unset, so merging the same inputs
+	// always renders the same bytes.
+	OmitCreatedAt bool
 }
 
 // Merger merges multiple skills into one.
@@ -27,15 +36,36 @@ func New() *Merger {
 	}
 }
 
+// sourcedSection is a section tagged with the input it came from.
+type sourcedSection struct {
+	section skill.Section
+	source  string
+}
+
 // Merge combines multiple skills into a single skill.
 func (m *Merger) Merge(skills []*skill.Skill, opts *Options) (*skill.Skill, error) {
+	result, _, err := m.MergeWithReport(skills, opts)
+	return result, err
+}
+
+// MergeWithReport combines skills like Merge and also returns every conflict
+// it resolved, with the resolution chosen.
+func (m *Merger) MergeWithReport(skills []*skill.Skill, opts *Options) (*skill.Skill, []Conflict, error) {
 	if len(skills) == 0 {
-		return nil, nil
+		return nil, nil, nil
 	}
 
 	if len(skills) == 1 {
-		return skills[0], nil
+		return skills[0], nil, nil
+	}
+
+	var resolver *ConflictResolver
+	var labels []string
+	if opts != nil {
+		resolver = opts.Resolver
+		labels = opts.Sources
 	}
+	sources := sourceNames(skills, labels)
 
 	// Determine name
 	name := "Merged Skill"
@@ -60,7 +90,9 @@ func (m *Merger) Merge(skills []*skill.Skill, opts *Options) (*skill.Skill, erro
 
 	// Create merged skill
 	result := skill.NewSkill(name, "")
-	result.Frontmatter.CreatedAt = time.Now().Format(time.RFC3339)
+	if opts != nil && opts.OmitCreatedAt {
+		result.Frontmatter.CreatedAt = ""
+	}
 
 	// Collect descriptions
 	var descriptions []string
@@ -75,48 +107,177 @@ func (m *Merger) Merge(skills []*skill.Skill, opts *Options) (*skill.Skill, erro
 		result.Frontmatter.Description = strings.Join(descriptions, " ")
 	}
 
-	// Merge tags
-	tagSet := make(map[string]bool)
+	mergeMetadata(result, skills)
+	result.Frontmatter.ToolDefinitions = mergeTools(skills, result.Frontmatter.BaseURL)
+	if n := len(result.Frontmatter.ToolDefinitions); n > 0 {
+		result.Frontmatter.MCPCompatible = true
+		result.Frontmatter.EndpointCount = n
+	}
+
+	// Merge tags in source order
 	for _, s := range skills {
-		for _, tag := range s.Frontmatter.Tags {
-			tagSet[tag] = true
-		}
+		result.Frontmatter.Tags = appendUnique(result.Frontmatter.Tags, s.Frontmatter.Tags...)
 	}
-	for tag := range tagSet {
-		result.Frontmatter.Tags = append(result.Frontmatter.Tags, tag)
+
+	conflicts := fieldConflicts(skills, sources)
+	for i := range conflicts {
+		switch conflicts[i].Field {
+		case "name":
+			conflicts[i].Resolved = fmt.Sprintf("named %q", result.Frontmatter.Name)
+		case "version":
+			conflicts[i].Resolved = fmt.Sprintf("set to %s", result.Frontmatter.Version)
+		}
 	}
 
-	// Merge sections
-	allSections := make([]skill.Section, 0)
-	for _, s := range skills {
-		allSections = append(allSections, s.Sections...)
+	if c := baseURLConflict(skills, sources, result.Frontmatter.BaseURL); c != nil {
+		conflicts = append(conflicts, *c)
 	}
 
-	// Deduplicate if requested
+	extensions, extensionConflicts := mergeExtensions(skills, sources, resolver)
+	result.Frontmatter.Extensions = extensions
+	conflicts = append(conflicts, extensionConflicts...)
+
+	// Merge sections along their heading paths, so subsections stay with
+	// the section they belong to
+	kept := make([][]skill.Section, len(skills))
+	for i, s := range skills {
+		kept[i] = s.Sections
+	}
 	if opts != nil && opts.Deduplicate {
-		allSections = m.dedup.DeduplicateSections(allSections)
+		kept = m.dedupSections(kept)
 	}
 
-	// Group sections by title
-	sectionGroups := make(map[string][]skill.Section)
-	sectionOrder := make([]string, 0)
+	var tree []*sectionNode
+	for i, sections := range kept {
+		tree = addSections(tree, skill.BuildTree(sections), sources[i], "")
+	}
+	conflicts = append(conflicts, m.mergeTree(result, tree, resolver)...)
+
+	return result, conflicts, nil
+}
 
-	for _, sec := range allSections {
-		key := strings.ToLower(sec.Title)
-		if _, exists := sectionGroups[key]; !exists {
-			sectionOrder = append(sectionOrder, key)
+// sourceNames labels each skill for conflict reports.
+func sourceNames(skills []*skill.Skill, labels []string) []string {
+	names := make([]string, len(skills))
+	for i, s := range skills {
+		switch {
+		case i < len(labels) && labels[i] != "":
+			names[i] = labels[i]
+		case s.Frontmatter.Name != "":
+			names[i] = s.Frontmatter.Name
+		default:
+			names[i] = fmt.Sprintf("skill %d", i+1)
 		}
-		sectionGroups[key] = append(sectionGroups[key], sec)
 	}
+	return names
+}
+
+// sectionNode is a heading in the merged section tree. It holds every source
+// section found at its heading path.
+type sectionNode struct {
+	key      string
+	path     string
+	group    []sourcedSection
+	children []*sectionNode
+}
 
-	// Merge grouped sections
-	for _, key := range sectionOrder {
-		sections := sectionGroups[key]
-		merged := m.mergeSections(sections)
-		result.Sections = append(result.Sections, merged)
+// addSections adds a skill's section tree to nodes. Sections match by their
+// heading path, compared case-insensitively, and keep the order in which
+// each path first appears.
+func addSections(nodes []*sectionNode, tree []*skill.ParsedSection, source, parent string) []*sectionNode {
+	for _, sec := range tree {
+		key := strings.ToLower(strings.TrimSpace(sec.Title))
+
+		var node *sectionNode
+		for _, n := range nodes {
+			if n.key == key {
+				node = n
+				break
+			}
+		}
+		if node == nil {
+			path := sec.Title
+			if parent != "" {
+				path = parent + " > " + sec.Title
+			}
+			node = &sectionNode{key: key, path: path}
+			nodes = append(nodes, node)
+		}
+
+		node.group = append(node.group, sourcedSection{
+			section: skill.Section{Title: sec.Title, Level: sec.Level, Content: sec.Content},
+			source:  source,
+		})
+		node.children = addSections(node.children, sec.Children, source, node.path)
+	}
+	return nodes
+}
+
+// sectionTree builds the merged section tree of skills.
+func sectionTree(skills []*skill.Skill, sources []string) []*sectionNode {
+	var tree []*sectionNode
+	for i, s := range skills {
+		tree = addSections(tree, s.Tree(), sources[i], "")
+	}
+	return tree
+}
+
+// mergeTree appends the merged sections of nodes to result in document order
+// and returns the conflicts it resolved.
+func (m *Merger) mergeTree(result *skill.Skill, nodes []*sectionNode, resolver *ConflictResolver) []Conflict {
+	var conflicts []Conflict
+	for _, node := range nodes {
+		conflict := sectionConflict(node)
+		if conflict == nil || resolver == nil {
+			sections := make([]skill.Section, len(node.group))
+			for i, sec := range node.group {
+				sections[i] = sec.section
+			}
+			result.Sections = append(result.Sections, m.mergeSections(sections))
+			if conflict != nil {
+				conflict.Resolved = Combine.String()
+				conflicts = append(conflicts, *conflict)
+			}
+		} else {
+			merged := node.group[0].section
+			merged.Content = resolver.ResolveString(conflict.Values)
+			result.Sections = append(result.Sections, merged)
+
+			conflict.Resolved = resolver.strategy.String()
+			for i, v := range conflict.Values {
+				if v == merged.Content {
+					conflict.Resolved += ": kept " + conflict.Sources[i]
+					break
+				}
+			}
+			conflicts = append(conflicts, *conflict)
+		}
+
+		conflicts = append(conflicts, m.mergeTree(result, node.children, resolver)...)
 	}
+	return conflicts
+}
 
-	return result, nil
+// dedupSections drops sections that near-duplicate a longer section of any
+// skill. Sections nested under a dropped heading move up to its parent.
+func (m *Merger) dedupSections(perSkill [][]skill.Section) [][]skill.Section {
+	var all []skill.Section
+	for _, sections := range perSkill {
+		all = append(all, sections...)
+	}
+	duplicates := m.dedup.duplicateSections(all)
+
+	kept := make([][]skill.Section, len(perSkill))
+	offset := 0
+	for i, sections := range perSkill {
+		for j, sec := range sections {
+			if !duplicates[offset+j] {
+				kept[i] = append(kept[i], sec)
+			}
+		}
+		offset += len(sections)
+	}
+	return kept
 }
 
 // mergeSections merges sections with the same title.
@@ -1,6 +1,8 @@
 package merger
 
 import (
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/sanixdarker/skill-md/pkg/skill"
@@ -142,9 +144,9 @@ func TestMerger_Merge_TagDeduplication(t *testing.T) {
 		}
 	}
 
-	// Should have exactly 4 unique tags: api, users, products, common
-	if len(result.Frontmatter.Tags) != 4 {
-		t.Errorf("expected 4 unique tags, got %d", len(result.Frontmatter.Tags))
+	// Should have exactly 4 unique tags, in the order they first appear
+	if got := strings.Join(result.Frontmatter.Tags, ","); got != "api,users,common,products" {
+		t.Errorf("expected tags api,users,common,products, got %s", got)
 	}
 }
 
@@ -352,6 +354,34 @@ func TestMerger_Merge_SetsCreatedAt(t *testing.T) {
 	}
 }
 
+func TestMerger_Merge_Deterministic(t *testing.T) {
+	m := New()
+
+	skill1 := skill.NewSkill("API 1", "First")
+	skill1.Frontmatter.Tags = []string{"b", "a", "c"}
+	skill2 := skill.NewSkill("API 2", "Second")
+	skill2.Frontmatter.Tags = []string{"d", "a"}
+
+	first, err := m.Merge([]*skill.Skill{skill1, skill2}, &Options{OmitCreatedAt: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first.Frontmatter.CreatedAt != "" {
+		t.Errorf("expected CreatedAt to be omitted, got %q", first.Frontmatter.CreatedAt)
+	}
+
+	want := skill.Render(first)
+	for i := 0; i < 5; i++ {
+		again, err := m.Merge([]*skill.Skill{skill1, skill2}, &Options{OmitCreatedAt: true})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := skill.Render(again); got != want {
+			t.Fatalf("merge output changed between runs:\n%s\n---\n%s", want, got)
+		}
+	}
+}
+
 func TestMerger_Merge_SectionOrderPreserved(t *testing.T) {
 	m := New()
 
@@ -382,3 +412,260 @@ func TestMerger_Merge_SectionOrderPreserved(t *testing.T) {
 		}
 	}
 }
+
+func TestMerger_Merge_ToolDefinitions(t *testing.T) {
+	m := New()
+
+	users := skill.NewSkill("User API", "")
+	users.Frontmatter.BaseURL = "https://users.example.com"
+	users.Frontmatter.AuthMethods = []string{"bearer"}
+	users.Frontmatter.ToolDefinitions = []skill.ToolDefinition{
+		{Name: "list_items", Method: "GET", Path: "/users"},
+		{Name: "ping", Method: "GET", Path: "/ping"},
+	}
+
+	products := skill.NewSkill("Product API", "")
+	products.Frontmatter.BaseURL = "https://products.example.com"
+	products.Frontmatter.AuthMethods = []string{"bearer", "apiKey"}
+	products.Frontmatter.Servers = []string{"https://products.example.com"}
+	products.Frontmatter.ToolDefinitions = []skill.ToolDefinition{
+		{Name: "list_items", Method: "GET", Path: "/products"},
+		{Name: "get_product", Method: "GET", Path: "/products/{id}"},
+	}
+
+	result, err := m.Merge([]*skill.Skill{users, products}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	fm := result.Frontmatter
+	var names []string
+	for _, tool := range fm.ToolDefinitions {
+		names = append(names, tool.Name)
+	}
+	expected := []string{"user_api_list_items", "ping", "product_api_list_items", "get_product"}
+	if strings.Join(names, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected tools %v, got %v", expected, names)
+	}
+
+	if fm.BaseURL != "https://users.example.com" {
+		t.Errorf("expected first base URL to be kept, got %q", fm.BaseURL)
+	}
+	for _, tool := range fm.ToolDefinitions {
+		want := ""
+		if strings.HasPrefix(tool.Path, "/products") {
+			want = "https://products.example.com"
+		}
+		if tool.BaseURL != want {
+			t.Errorf("tool %s: expected base URL %q, got %q", tool.Name, want, tool.BaseURL)
+		}
+	}
+
+	if strings.Join(fm.AuthMethods, ",") != "bearer,apiKey" {
+		t.Errorf("expected unioned auth methods, got %v", fm.AuthMethods)
+	}
+	if len(fm.Servers) != 1 {
+		t.Errorf("expected servers to be kept, got %v", fm.Servers)
+	}
+	if !fm.MCPCompatible || fm.EndpointCount != 4 {
+		t.Errorf("expected mcp_compatible with 4 endpoints, got %v %d", fm.MCPCompatible, fm.EndpointCount)
+	}
+}
+
+func TestMerger_MergeWithReport_MissingBaseURL(t *testing.T) {
+	m := New()
+
+	users := skill.NewSkill("User API", "")
+	users.Frontmatter.BaseURL = "https://users.example.com"
+	users.Frontmatter.ToolDefinitions = []skill.ToolDefinition{{Name: "list_users", Method: "GET", Path: "/users"}}
+
+	local := skill.NewSkill("Local API", "")
+	local.Frontmatter.ToolDefinitions = []skill.ToolDefinition{{Name: "get_health", Method: "GET", Path: "/health"}}
+
+	result, conflicts, err := m.MergeWithReport([]*skill.Skill{users, local}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Frontmatter.BaseURL != "https://users.example.com" {
+		t.Errorf("expected the only base URL to be kept, got %q", result.Frontmatter.BaseURL)
+	}
+
+	var found *Conflict
+	for i := range conflicts {
+		if conflicts[i].Field == "base_url" {
+			found = &conflicts[i]
+		}
+	}
+	if found == nil {
+		t.Fatalf("expected a base_url conflict, got %+v", conflicts)
+	}
+	if strings.Join(found.Sources, ",") != "User API,Local API" || strings.Join(found.Values, ",") != "https://users.example.com," {
+		t.Errorf("unexpected conflict: %+v", found)
+	}
+	if !strings.Contains(found.Resolved, "Local API") {
+		t.Errorf("expected the resolution to name the source without a base URL, got %q", found.Resolved)
+	}
+
+	detected := DetectConflicts([]*skill.Skill{users, local})
+	if len(detected) != 2 || detected[1].Field != "base_url" || detected[1].Resolved != "" {
+		t.Errorf("expected DetectConflicts to report the name and the base URL, got %+v", detected)
+	}
+
+	// A source whose tools carry their own base URL is not a conflict
+	local.Frontmatter.ToolDefinitions[0].BaseURL = "http://localhost:8080"
+	if _, conflicts, _ := m.MergeWithReport([]*skill.Skill{users, local}, nil); len(conflicts) != 1 || conflicts[0].Field != "name" {
+		t.Errorf("expected only the name conflict, got %+v", conflicts)
+	}
+}
+
+func TestMerger_Merge_IdenticalToolsKeptOnce(t *testing.T) {
+	m := New()
+
+	tool := skill.ToolDefinition{Name: "get_status", Method: "GET", Path: "/status"}
+	skill1 := skill.NewSkill("API 1", "")
+	skill1.Frontmatter.ToolDefinitions = []skill.ToolDefinition{tool}
+	skill2 := skill.NewSkill("API 2", "")
+	skill2.Frontmatter.ToolDefinitions = []skill.ToolDefinition{tool}
+
+	result, err := m.Merge([]*skill.Skill{skill1, skill2}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Frontmatter.ToolDefinitions) != 1 || result.Frontmatter.ToolDefinitions[0].Name != "get_status" {
+		t.Errorf("expected a single get_status tool, got %+v", result.Frontmatter.ToolDefinitions)
+	}
+}
+
+func TestMerger_Merge_AgentMetadata(t *testing.T) {
+	m := New()
+
+	skill1 := skill.NewSkill("API 1", "")
+	skill1.Frontmatter.Protocol = "http"
+	skill1.Frontmatter.RateLimits = &skill.RateLimitInfo{RequestsPerMinute: 100, BurstLimit: 10}
+	skill1.Frontmatter.RetryStrategy = &skill.RetryStrategy{MaxRetries: 3, BackoffType: "exponential"}
+
+	skill2 := skill.NewSkill("API 2", "")
+	skill2.Frontmatter.Protocol = "http"
+	skill2.Frontmatter.RateLimits = &skill.RateLimitInfo{RequestsPerMinute: 60, RequestsPerDay: 1000}
+
+	result, err := m.Merge([]*skill.Skill{skill1, skill2}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	fm := result.Frontmatter
+	if fm.Protocol != "http" {
+		t.Errorf("expected shared protocol, got %q", fm.Protocol)
+	}
+	if fm.RetryStrategy == nil || fm.RetryStrategy.MaxRetries != 3 {
+		t.Errorf("expected retry strategy to be kept, got %+v", fm.RetryStrategy)
+	}
+	limits := fm.RateLimits
+	if limits == nil || limits.RequestsPerMinute != 60 || limits.BurstLimit != 10 || limits.RequestsPerDay != 1000 {
+		t.Errorf("expected strictest rate limits, got %+v", limits)
+	}
+
+	skill2.Frontmatter.Protocol = "grpc"
+	result, _ = m.Merge([]*skill.Skill{skill1, skill2}, nil)
+	if result.Frontmatter.Protocol != "" {
+		t.Errorf("expected mixed protocols to be left unset, got %q", result.Frontmatter.Protocol)
+	}
+}
+
+func TestMerger_Merge_SubsectionsStayWithTheirParent(t *testing.T) {
+	m := New()
+
+	skill1, _ := skill.Parse("## GET /users\n\nList users.\n\n### Example\n\nGET /users\n\n## GET /orders\n\nList orders.\n\n### Example\n\nGET /orders\n")
+	skill2, _ := skill.Parse("## GET /orders\n\n### Example\n\nGET /orders?page=2\n\n## GET /users\n\n### Errors\n\n404 when missing\n")
+
+	result, conflicts, err := m.MergeWithReport([]*skill.Skill{skill1, skill2}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got []string
+	for _, sec := range result.Sections {
+		got = append(got, sec.Title+": "+sec.Content)
+	}
+	expected := []string{
+		"GET /users: List users.",
+		"Example: GET /users",
+		"Errors: 404 when missing",
+		"GET /orders: List orders.",
+		"Example: GET /orders\n\nGET /orders?page=2",
+	}
+	if strings.Join(got, "|") != strings.Join(expected, "|") {
+		t.Errorf("unexpected merged sections:\n got %q\nwant %q", got, expected)
+	}
+
+	if len(conflicts) != 1 || conflicts[0].Field != "section:GET /orders > Example" {
+		t.Errorf("expected a single conflict on the orders example, got %+v", conflicts)
+	}
+}
+
+func TestMerger_Merge_Extensions(t *testing.T) {
+	skill1, err := skill.Parse("---\nname: API 1\nlicense: MIT\nallowed-tools: [Read, Grep]\nmetadata:\n  owner: pets\n  labels: {tier: gold}\n---\n\n## Usage\n\nUse it.\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	skill2, err := skill.Parse("---\nname: API 2\nlicense: Apache-2.0\nallowed-tools: [Grep, Bash]\nmetadata:\n  labels: {team: store}\nx-vendor: true\n---\n\n## Usage\n\nUse it.\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, conflicts, err := New().MergeWithReport([]*skill.Skill{skill1, skill2}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ext := result.Frontmatter.Extensions
+	if ext["license"] != "MIT" || ext["x-vendor"] != true {
+		t.Errorf("unexpected scalar extensions: %#v", ext)
+	}
+	if got := ext["allowed-tools"]; !reflect.DeepEqual(got, []interface{}{"Read", "Grep", "Bash"}) {
+		t.Errorf("expected lists to be unioned in source order, got %#v", got)
+	}
+	want := map[string]interface{}{
+		"owner":  "pets",
+		"labels": map[string]interface{}{"tier": "gold", "team": "store"},
+	}
+	if !reflect.DeepEqual(ext["metadata"], want) {
+		t.Errorf("expected maps to be merged key by key, got %#v", ext["metadata"])
+	}
+
+	var found bool
+	for _, c := range conflicts {
+		if c.Field == "extension:license" {
+			found = true
+			if c.Resolved != "first: kept API 1" || strings.Join(c.Values, ",") != "MIT,Apache-2.0" {
+				t.Errorf("unexpected license conflict: %+v", c)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("expected a license conflict, got %+v", conflicts)
+	}
+
+	// The merged skill does not share maps with its sources
+	ext["metadata"].(map[string]interface{})["owner"] = "changed"
+	if skill1.Frontmatter.Extensions["metadata"].(map[string]interface{})["owner"] != "pets" {
+		t.Error("expected merged extensions to be copied")
+	}
+
+	rendered, err := skill.Parse(skill.Render(result))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(rendered.Frontmatter.Extensions, result.Frontmatter.Extensions) {
+		t.Errorf("expected extensions to round-trip, got %#v", rendered.Frontmatter.Extensions)
+	}
+
+	result, _, _ = New().MergeWithReport([]*skill.Skill{skill1, skill2}, &Options{Resolver: NewConflictResolver(KeepLast)})
+	if result.Frontmatter.Extensions["license"] != "Apache-2.0" {
+		t.Errorf("expected the last license with KeepLast, got %v", result.Frontmatter.Extensions["license"])
+	}
+	result, _, _ = New().MergeWithReport([]*skill.Skill{skill1, skill2}, &Options{Resolver: NewConflictResolver(Combine)})
+	if result.Frontmatter.Extensions["license"] != "MIT" {
+		t.Errorf("expected the first license with Combine, got %v", result.Frontmatter.Extensions["license"])
+	}
+}
@@ -0,0 +1,388 @@
+package merger
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/sanixdarker/skill-md/pkg/skill"
+)
+
+// difficultyRank orders difficulty levels so the merged skill reports the
+// hardest of its sources.
+var difficultyRank = map[string]int{"novice": 1, "intermediate": 2, "advanced": 3}
+
+// mergeMetadata combines the agent and protocol metadata of skills into
+// result. Lists are unioned in source order, counts are summed and limits
+// keep the most conservative value.
+func mergeMetadata(result *skill.Skill, skills []*skill.Skill) {
+	fm := &result.Frontmatter
+	protocols := make(map[string]bool)
+
+	for _, s := range skills {
+		src := s.Frontmatter
+
+		fm.AuthMethods = appendUnique(fm.AuthMethods, src.AuthMethods...)
+		fm.Servers = appendUnique(fm.Servers, src.Servers...)
+		if fm.BaseURL == "" {
+			fm.BaseURL = src.BaseURL
+		}
+
+		fm.EndpointCount += src.EndpointCount
+		fm.ChannelCount += src.ChannelCount
+		fm.ServiceCount += src.ServiceCount
+		fm.MessageCount += src.MessageCount
+		fm.HasExamples = fm.HasExamples || src.HasExamples
+		fm.MCPCompatible = fm.MCPCompatible || src.MCPCompatible
+		fm.MaxTokensPerCall = minPositive(fm.MaxTokensPerCall, src.MaxTokensPerCall)
+
+		if difficultyRank[src.Difficulty] > difficultyRank[fm.Difficulty] {
+			fm.Difficulty = src.Difficulty
+		}
+		if src.Protocol != "" {
+			protocols[src.Protocol] = true
+			fm.Protocol = src.Protocol
+		}
+		if fm.RetryStrategy == nil && src.RetryStrategy != nil {
+			retry := *src.RetryStrategy
+			fm.RetryStrategy = &retry
+		}
+		if src.RateLimits != nil {
+			fm.RateLimits = mergeRateLimits(fm.RateLimits, src.RateLimits)
+		}
+	}
+
+	// A single protocol is kept; mixed sources leave it unset rather than
+	// claiming one transport for every tool.
+	if len(protocols) > 1 {
+		fm.Protocol = ""
+	}
+}
+
+// extensionValue is the value one source gives an extension key.
+type extensionValue struct {
+	value  interface{}
+	source string
+}
+
+// mergeExtensions combines the frontmatter keys Frontmatter does not know,
+// such as license or metadata. Maps are merged key by key and lists are
+// unioned in source order. Other values that differ are a conflict: the
+// resolver picks one, and the first value is kept without a resolver or
+// with Combine, as they cannot be combined.
+func mergeExtensions(skills []*skill.Skill, sources []string, resolver *ConflictResolver) (map[string]interface{}, []Conflict) {
+	maps := make([]extensionValue, 0, len(skills))
+	for i, s := range skills {
+		if len(s.Frontmatter.Extensions) > 0 {
+			maps = append(maps, extensionValue{value: s.Frontmatter.Extensions, source: sources[i]})
+		}
+	}
+	if len(maps) == 0 {
+		return nil, nil
+	}
+	merged, conflicts := mergeExtensionMaps("", maps, resolver)
+	return merged, conflicts
+}
+
+// mergeExtensionMaps merges values that are all maps, key by key in sorted
+// order.
+func mergeExtensionMaps(path string, values []extensionValue, resolver *ConflictResolver) (map[string]interface{}, []Conflict) {
+	var keys []string
+	seen := make(map[string]bool)
+	for _, v := range values {
+		for key := range v.value.(map[string]interface{}) {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+
+	merged := make(map[string]interface{}, len(keys))
+	var conflicts []Conflict
+	for _, key := range keys {
+		var group []extensionValue
+		for _, v := range values {
+			if value, ok := v.value.(map[string]interface{})[key]; ok {
+				group = append(group, extensionValue{value: value, source: v.source})
+			}
+		}
+		keyPath := key
+		if path != "" {
+			keyPath = path + "." + key
+		}
+		value, c := mergeExtensionValue(keyPath, group, resolver)
+		merged[key] = value
+		conflicts = append(conflicts, c...)
+	}
+	return merged, conflicts
+}
+
+// mergeExtensionValue merges the values sources give the extension key at
+// path. Null values only count when every source has one.
+func mergeExtensionValue(path string, values []extensionValue, resolver *ConflictResolver) (interface{}, []Conflict) {
+	var set []extensionValue
+	for _, v := range values {
+		if v.value != nil {
+			set = append(set, v)
+		}
+	}
+	if len(set) == 0 {
+		return nil, nil
+	}
+
+	allMaps, allLists := true, true
+	for _, v := range set {
+		_, isMap := v.value.(map[string]interface{})
+		_, isList := v.value.([]interface{})
+		allMaps = allMaps && isMap
+		allLists = allLists && isList
+	}
+	switch {
+	case allMaps:
+		return mergeExtensionMaps(path, set, resolver)
+	case allLists:
+		var list []interface{}
+		for _, v := range set {
+			for _, item := range v.value.([]interface{}) {
+				if !containsValue(list, item) {
+					list = append(list, copyValue(item))
+				}
+			}
+		}
+		return list, nil
+	}
+
+	c := Conflict{Field: "extension:" + path}
+	var distinct []interface{}
+	for _, v := range set {
+		if containsValue(distinct, v.value) {
+			continue
+		}
+		distinct = append(distinct, v.value)
+		c.Values = append(c.Values, extensionString(v.value))
+		c.Sources = append(c.Sources, v.source)
+	}
+	if len(distinct) == 1 {
+		return copyValue(distinct[0]), nil
+	}
+
+	strategy, kept := KeepFirst, 0
+	if resolver != nil && resolver.strategy != Combine {
+		strategy = resolver.strategy
+		chosen := resolver.ResolveString(c.Values)
+		for i, v := range c.Values {
+			if v == chosen {
+				kept = i
+				break
+			}
+		}
+	}
+	c.Resolved = fmt.Sprintf("%s: kept %s", strategy, c.Sources[kept])
+	return copyValue(distinct[kept]), []Conflict{c}
+}
+
+// extensionString formats an extension value for a conflict report.
+func extensionString(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// copyValue deep-copies the maps and lists of a frontmatter value, so the
+// merged skill does not share them with its sources.
+func copyValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			m[key] = copyValue(item)
+		}
+		return m
+	case []interface{}:
+		list := make([]interface{}, len(v))
+		for i, item := range v {
+			list[i] = copyValue(item)
+		}
+		return list
+	}
+	return value
+}
+
+func containsValue(list []interface{}, value interface{}) bool {
+	for _, existing := range list {
+		if reflect.DeepEqual(existing, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeRateLimits keeps the strictest limit of each kind.
+func mergeRateLimits(a, b *skill.RateLimitInfo) *skill.RateLimitInfo {
+	if a == nil {
+		limits := *b
+		return &limits
+	}
+	return &skill.RateLimitInfo{
+		RequestsPerMinute: minPositive(a.RequestsPerMinute, b.RequestsPerMinute),
+		RequestsPerHour:   minPositive(a.RequestsPerHour, b.RequestsPerHour),
+		RequestsPerDay:    minPositive(a.RequestsPerDay, b.RequestsPerDay),
+		BurstLimit:        minPositive(a.BurstLimit, b.BurstLimit),
+		RetryAfterHeader:  firstNonEmpty(a.RetryAfterHeader, b.RetryAfterHeader),
+	}
+}
+
+// mergeTools unions the tool definitions of skills. Tools whose source base
+// URL differs from baseURL record it on the tool. Identical tools are kept
+// once; tools that share a name but differ are prefixed with the name of the
+// skill they came from.
+func mergeTools(skills []*skill.Skill, baseURL string) []skill.ToolDefinition {
+	type candidate struct {
+		tool   skill.ToolDefinition
+		source string
+	}
+
+	var candidates []candidate
+	variants := make(map[string]int)
+	for _, s := range skills {
+		for _, tool := range s.Frontmatter.ToolDefinitions {
+			if tool.BaseURL == "" && s.Frontmatter.BaseURL != baseURL {
+				tool.BaseURL = s.Frontmatter.BaseURL
+			}
+
+			duplicate := false
+			for _, c := range candidates {
+				if reflect.DeepEqual(c.tool, tool) {
+					duplicate = true
+					break
+				}
+			}
+			if duplicate {
+				continue
+			}
+
+			variants[tool.Name]++
+			candidates = append(candidates, candidate{tool: tool, source: s.Frontmatter.Name})
+		}
+	}
+
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	tools := make([]skill.ToolDefinition, 0, len(candidates))
+	names := make(map[string]int)
+	for _, c := range candidates {
+		tool := c.tool
+		if variants[tool.Name] > 1 {
+			tool.Name = toolPrefix(c.source) + "_" + tool.Name
+		}
+		names[tool.Name]++
+		if n := names[tool.Name]; n > 1 {
+			tool.Name = fmt.Sprintf("%s_%d", tool.Name, n)
+		}
+		tools = append(tools, tool)
+	}
+	return tools
+}
+
+// baseURLConflict reports sources whose tools have no base URL when the
+// merged skill has one, taken from another source: those tools now resolve
+// against that host, though nothing says their API lives there. It returns
+// nil when every tool has a base URL of its own.
+func baseURLConflict(skills []*skill.Skill, sources []string, baseURL string) *Conflict {
+	if baseURL == "" {
+		return nil
+	}
+
+	c := &Conflict{Field: "base_url"}
+	var missing []string
+	for i, s := range skills {
+		if s.Frontmatter.BaseURL == baseURL && len(c.Values) == 0 {
+			c.Values = append(c.Values, baseURL)
+			c.Sources = append(c.Sources, sources[i])
+			continue
+		}
+		if s.Frontmatter.BaseURL != "" {
+			continue
+		}
+		for _, tool := range s.Frontmatter.ToolDefinitions {
+			if tool.BaseURL == "" {
+				missing = append(missing, sources[i])
+				break
+			}
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	for _, source := range missing {
+		c.Values = append(c.Values, "")
+		c.Sources = append(c.Sources, source)
+	}
+	c.Resolved = fmt.Sprintf("tools from %s use %s", strings.Join(missing, ", "), baseURL)
+	return c
+}
+
+// toolPrefix turns a skill name into a snake_case tool name prefix.
+func toolPrefix(name string) string {
+	prefix := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + ('a' - 'A')
+		}
+		return '_'
+	}, name)
+	prefix = strings.Trim(prefix, "_")
+	for strings.Contains(prefix, "__") {
+		prefix = strings.ReplaceAll(prefix, "__", "_")
+	}
+	if prefix == "" {
+		prefix = "skill"
+	}
+	return prefix
+}
+
+func appendUnique(list []string, values ...string) []string {
+	for _, v := range values {
+		found := false
+		for _, existing := range list {
+			if existing == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+func minPositive(a, b int) int {
+	if a <= 0 || (b > 0 && b < a) {
+		return b
+	}
+	return a
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
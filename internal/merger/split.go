@@ -0,0 +1,392 @@
+package merger
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/sanixdarker/skill-md/pkg/skill"
+)
+
+// SplitMode selects how Split partitions a skill.
+type SplitMode string
+
+const (
+	// SplitAuto picks a mode from the skill's source type.
+	SplitAuto SplitMode = "auto"
+	// SplitSection makes one part per top-level section.
+	SplitSection SplitMode = "section"
+	// SplitTag groups OpenAPI operations by their first tag.
+	SplitTag SplitMode = "tag"
+	// SplitType groups GraphQL operations by root type.
+	SplitType SplitMode = "type"
+	// SplitService groups gRPC methods by service.
+	SplitService SplitMode = "service"
+)
+
+// ParseSplitMode parses a split mode name.
+func ParseSplitMode(name string) (SplitMode, error) {
+	switch mode := SplitMode(strings.ToLower(strings.TrimSpace(name))); mode {
+	case "":
+		return SplitAuto, nil
+	case SplitAuto, SplitSection, SplitTag, SplitType, SplitService:
+		return mode, nil
+	}
+	return "", fmt.Errorf("unknown split mode %q (use auto, section, tag, type or service)", name)
+}
+
+// SplitOptions holds split options.
+type SplitOptions struct {
+	Mode SplitMode
+}
+
+// SplitPart is one skill produced by Split. Slug names the directory the
+// index links it under.
+type SplitPart struct {
+	Slug  string
+	Title string
+	Skill *skill.Skill
+}
+
+// SplitResult holds the parts of a split skill and the index skill linking
+// them. The index links each part as <slug>/SKILL.md.
+type SplitResult struct {
+	Index *skill.Skill
+	Parts []SplitPart
+}
+
+var tagsLineRegex = regexp.MustCompile(`\*\*Tags\*\*:\s*([^,\n]+)`)
+
+// rootTypePrefixes maps GraphQL operation section titles to the prefix of
+// their tool names.
+var rootTypePrefixes = map[string]string{
+	"queries":       "query",
+	"mutations":     "mutation",
+	"subscriptions": "subscription",
+}
+
+// splitNode is a section tree node with its parent, so operations can bring
+// their enclosing sections into a part.
+type splitNode struct {
+	section  skill.Section
+	parent   *splitNode
+	children []*splitNode
+	tool     int
+}
+
+// Split partitions a skill into smaller skills, the inverse of Merge. Each
+// part carries the tool definitions of the operations it documents; tools
+// that match no operation stay on the index.
+func (m *Merger) Split(s *skill.Skill, opts *SplitOptions) (*SplitResult, error) {
+	if s == nil {
+		return nil, fmt.Errorf("no skill to split")
+	}
+
+	mode := SplitAuto
+	if opts != nil && opts.Mode != "" {
+		mode = opts.Mode
+	}
+	if mode == SplitAuto {
+		mode = autoSplitMode(s.Frontmatter.SourceType)
+	}
+
+	// Converters may keep subsections inside a section body; re-parse the
+	// rendered skill so every heading is its own node
+	normalized, err := skill.Parse(skill.Render(s))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse skill: %w", err)
+	}
+	tree := newSplitTree(normalized.Tree(), nil, s.Frontmatter.ToolDefinitions)
+
+	var groups []*splitGroup
+	if mode == SplitSection {
+		groups = sectionGroups(tree)
+	} else {
+		groups, err = operationGroups(tree, mode)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(groups) < 2 {
+		return nil, fmt.Errorf("skill has fewer than two parts when split by %s", mode)
+	}
+
+	result := &SplitResult{}
+	used := make(map[int]bool)
+	slugs := make(map[string]int)
+	for _, g := range groups {
+		part := splitSkill(s, g.title, mode)
+		part.Sections = g.sections
+		for _, i := range g.tools {
+			if used[i] {
+				continue
+			}
+			part.Frontmatter.ToolDefinitions = append(part.Frontmatter.ToolDefinitions, s.Frontmatter.ToolDefinitions[i])
+			used[i] = true
+		}
+		part.Frontmatter.EndpointCount = len(part.Frontmatter.ToolDefinitions)
+		part.Frontmatter.MCPCompatible = len(part.Frontmatter.ToolDefinitions) > 0
+
+		slug := splitSlug(g.title)
+		slugs[slug]++
+		if n := slugs[slug]; n > 1 {
+			slug = fmt.Sprintf("%s-%d", slug, n)
+		}
+		result.Parts = append(result.Parts, SplitPart{Slug: slug, Title: g.title, Skill: part})
+	}
+
+	result.Index = splitIndex(s, tree, mode, result.Parts, used)
+	return result, nil
+}
+
+// autoSplitMode picks the natural split mode for a source format.
+func autoSplitMode(sourceType string) SplitMode {
+	switch sourceType {
+	case "openapi", "swagger":
+		return SplitTag
+	case "graphql":
+		return SplitType
+	case "proto", "grpc":
+		return SplitService
+	}
+	return SplitSection
+}
+
+func newSplitTree(tree []*skill.ParsedSection, parent *splitNode, tools []skill.ToolDefinition) []*splitNode {
+	nodes := make([]*splitNode, 0, len(tree))
+	for _, sec := range tree {
+		node := &splitNode{
+			section: skill.Section{Title: sec.Title, Level: sec.Level, Content: sec.Content},
+			parent:  parent,
+			tool:    -1,
+		}
+		node.tool = matchTool(node, tools)
+		node.children = newSplitTree(sec.Children, node, tools)
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+// matchTool returns the index of the tool a section documents, or -1. A
+// section matches a tool by "METHOD /path" heading, by a heading that
+// normalizes to the tool name (gRPC "Service.Method"), or by a GraphQL field
+// under a root type section.
+func matchTool(node *splitNode, tools []skill.ToolDefinition) int {
+	title := strings.TrimSpace(node.section.Title)
+	key := toolPrefix(title)
+	prefix := ""
+	if node.parent != nil {
+		prefix = rootTypePrefixes[strings.ToLower(node.parent.section.Title)]
+	}
+
+	for i, tool := range tools {
+		switch {
+		case tool.Method != "" && strings.EqualFold(title, tool.Method+" "+tool.Path):
+			return i
+		case key == tool.Name:
+			return i
+		case prefix != "" && prefix+"_"+title == tool.Name:
+			return i
+		}
+	}
+	return -1
+}
+
+// splitGroup collects the sections and tools of one part.
+type splitGroup struct {
+	title    string
+	sections []skill.Section
+	tools    []int
+	added    map[*splitNode]bool
+}
+
+// sectionGroups makes one group per top-level section. A document with a
+// single root heading is split by that heading's children.
+func sectionGroups(tree []*splitNode) []*splitGroup {
+	top := tree
+	if len(tree) == 1 {
+		top = tree[0].children
+	}
+
+	groups := make([]*splitGroup, 0, len(top))
+	for _, node := range top {
+		// Tool listings live in the parts' frontmatter
+		if strings.EqualFold(node.section.Title, "Tool Definitions") {
+			continue
+		}
+		g := &splitGroup{title: node.section.Title}
+		addSubtree(g, node)
+		groups = append(groups, g)
+	}
+	return groups
+}
+
+// operationGroups groups the sections that document a tool by the key mode
+// selects. Each group also gets the sections enclosing its operations and a
+// copy of the Authentication section.
+func operationGroups(tree []*splitNode, mode SplitMode) ([]*splitGroup, error) {
+	var ops []*splitNode
+	var auth *splitNode
+	var walk func(nodes []*splitNode)
+	walk = func(nodes []*splitNode) {
+		for _, node := range nodes {
+			if node.tool >= 0 {
+				ops = append(ops, node)
+				continue
+			}
+			if auth == nil && strings.EqualFold(node.section.Title, "Authentication") {
+				auth = node
+			}
+			walk(node.children)
+		}
+	}
+	walk(tree)
+	if len(ops) == 0 {
+		return nil, fmt.Errorf("no sections match the skill's tool definitions, cannot split by %s", mode)
+	}
+
+	var groups []*splitGroup
+	byKey := make(map[string]*splitGroup)
+	for _, op := range ops {
+		key := operationKey(op, mode)
+		g, ok := byKey[strings.ToLower(key)]
+		if !ok {
+			g = &splitGroup{title: key, added: make(map[*splitNode]bool)}
+			if auth != nil {
+				addSubtree(g, auth)
+			}
+			byKey[strings.ToLower(key)] = g
+			groups = append(groups, g)
+		}
+
+		// Enclosing sections, outermost first
+		var chain []*splitNode
+		for p := op.parent; p != nil; p = p.parent {
+			chain = append([]*splitNode{p}, chain...)
+		}
+		for _, p := range chain {
+			if !g.added[p] {
+				g.added[p] = true
+				g.sections = append(g.sections, p.section)
+			}
+		}
+		addSubtree(g, op)
+	}
+	return groups, nil
+}
+
+// operationKey returns the part an operation belongs to.
+func operationKey(op *splitNode, mode SplitMode) string {
+	parent := "Operations"
+	if op.parent != nil {
+		parent = op.parent.section.Title
+	}
+
+	switch mode {
+	case SplitTag:
+		if m := tagsLineRegex.FindStringSubmatch(op.section.Content); m != nil {
+			return strings.TrimSpace(m[1])
+		}
+		return "default"
+	case SplitService:
+		if i := strings.LastIndex(op.section.Title, "."); i > 0 {
+			return op.section.Title[:i]
+		}
+	}
+	return parent
+}
+
+// addSubtree adds node and its descendants to g, with their tools.
+func addSubtree(g *splitGroup, node *splitNode) {
+	g.sections = append(g.sections, node.section)
+	if node.tool >= 0 {
+		g.tools = append(g.tools, node.tool)
+	}
+	for _, child := range node.children {
+		addSubtree(g, child)
+	}
+}
+
+// splitSkill returns a skill for one part, keeping the source's connection
+// and agent metadata.
+func splitSkill(s *skill.Skill, title string, mode SplitMode) *skill.Skill {
+	kind := "operations"
+	if mode == SplitSection {
+		kind = "section"
+	}
+
+	fm := s.Frontmatter
+	fm.Name = fmt.Sprintf("%s - %s", s.Frontmatter.Name, title)
+	fm.Description = fmt.Sprintf("%s %s of %s.", title, kind, s.Frontmatter.Name)
+	if s.Frontmatter.Description != "" {
+		fm.Description += " " + s.Frontmatter.Description
+	}
+	fm.ToolDefinitions = nil
+	fm.ChannelCount, fm.ServiceCount, fm.MessageCount = 0, 0, 0
+	return &skill.Skill{Frontmatter: fm}
+}
+
+// splitIndex builds the index skill: the sections no part took, the tools
+// no section matched, and a list of links to the parts.
+func splitIndex(s *skill.Skill, tree []*splitNode, mode SplitMode, parts []SplitPart, used map[int]bool) *skill.Skill {
+	index := &skill.Skill{Frontmatter: s.Frontmatter}
+	index.Frontmatter.ToolDefinitions = nil
+	for i, tool := range s.Frontmatter.ToolDefinitions {
+		if !used[i] {
+			index.Frontmatter.ToolDefinitions = append(index.Frontmatter.ToolDefinitions, tool)
+		}
+	}
+	index.Frontmatter.MCPCompatible = len(index.Frontmatter.ToolDefinitions) > 0
+	index.Frontmatter.EndpointCount = len(index.Frontmatter.ToolDefinitions)
+
+	level := 2
+	switch {
+	case mode == SplitSection && len(tree) == 1:
+		index.Sections = append(index.Sections, tree[0].section)
+		level = tree[0].section.Level + 1
+	case mode == SplitSection:
+		if len(tree) > 0 {
+			level = tree[0].section.Level
+		}
+	default:
+		var walk func(nodes []*splitNode)
+		walk = func(nodes []*splitNode) {
+			for _, node := range nodes {
+				// Tool listings now live in the parts' frontmatter
+				if node.tool >= 0 || strings.EqualFold(node.section.Title, "Tool Definitions") {
+					continue
+				}
+				index.Sections = append(index.Sections, node.section)
+				walk(node.children)
+			}
+		}
+		walk(tree)
+		if len(tree) > 0 {
+			level = tree[0].section.Level
+		}
+	}
+
+	var b strings.Builder
+	for _, part := range parts {
+		b.WriteString(fmt.Sprintf("- [%s](%s/SKILL.md)", part.Title, part.Slug))
+		switch n := len(part.Skill.Frontmatter.ToolDefinitions); n {
+		case 0:
+		case 1:
+			b.WriteString(": 1 tool")
+		default:
+			b.WriteString(fmt.Sprintf(": %d tools", n))
+		}
+		b.WriteString("\n")
+	}
+	index.Sections = append(index.Sections, skill.Section{
+		Title:   "Parts",
+		Level:   level,
+		Content: strings.TrimSpace(b.String()),
+	})
+	return index
+}
+
+// splitSlug turns a part title into a directory name.
+func splitSlug(title string) string {
+	return strings.ReplaceAll(toolPrefix(title), "_", "-")
+}
@@ -0,0 +1,172 @@
+package merger
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sanixdarker/skill-md/pkg/skill"
+)
+
+const splitDoc = `---
+name: "Shop API"
+version: "2.0.0"
+source_type: "openapi"
+base_url: "https://shop.example.com"
+tools:
+  - name: "get_users"
+    description: "List users"
+    method: "GET"
+    path: "/users"
+  - name: "get_orders"
+    description: "List orders"
+    method: "GET"
+    path: "/orders"
+  - name: "post_orders"
+    description: "Create an order"
+    method: "POST"
+    path: "/orders"
+  - name: "ping"
+    description: "Health check"
+---
+
+## Authentication
+
+Use a bearer token.
+
+## Endpoints
+
+All endpoints return JSON.
+
+### GET /users
+
+**Tags**: users
+
+#### 200 - OK
+
+### GET /orders
+
+**Tags**: orders, admin
+
+### POST /orders
+
+**Tags**: orders
+
+## Tool Definitions
+
+Listing.
+`
+
+func TestMerger_Split_ByTag(t *testing.T) {
+	s, err := skill.Parse(splitDoc)
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+
+	result, err := New().Split(s, nil)
+	if err != nil {
+		t.Fatalf("split failed: %v", err)
+	}
+	if len(result.Parts) != 2 {
+		t.Fatalf("expected 2 parts, got %d", len(result.Parts))
+	}
+
+	users, orders := result.Parts[0], result.Parts[1]
+	if users.Slug != "users" || orders.Slug != "orders" {
+		t.Errorf("unexpected slugs: %s, %s", users.Slug, orders.Slug)
+	}
+
+	var titles []string
+	for _, sec := range orders.Skill.Sections {
+		titles = append(titles, sec.Title)
+	}
+	if strings.Join(titles, ",") != "Authentication,Endpoints,GET /orders,POST /orders" {
+		t.Errorf("unexpected orders sections: %v", titles)
+	}
+	if n := len(orders.Skill.Frontmatter.ToolDefinitions); n != 2 || orders.Skill.Frontmatter.EndpointCount != 2 {
+		t.Errorf("expected 2 order tools, got %d", n)
+	}
+	if got := users.Skill.Sections[len(users.Skill.Sections)-1].Title; got != "200 - OK" {
+		t.Errorf("expected subsections to follow their operation, got %q", got)
+	}
+	if users.Skill.Frontmatter.BaseURL != "https://shop.example.com" || users.Skill.Frontmatter.Version != "2.0.0" {
+		t.Errorf("expected connection metadata on parts, got %+v", users.Skill.Frontmatter)
+	}
+
+	index := result.Index
+	if len(index.Frontmatter.ToolDefinitions) != 1 || index.Frontmatter.ToolDefinitions[0].Name != "ping" {
+		t.Errorf("expected the unmatched tool on the index, got %+v", index.Frontmatter.ToolDefinitions)
+	}
+	rendered := skill.Render(index)
+	for _, want := range []string{"[users](users/SKILL.md): 1 tool", "[orders](orders/SKILL.md): 2 tools", "All endpoints return JSON."} {
+		if !strings.Contains(rendered, want) {
+			t.Errorf("expected index to contain %q:\n%s", want, rendered)
+		}
+	}
+	if strings.Contains(rendered, "GET /orders") || strings.Contains(rendered, "Tool Definitions") {
+		t.Errorf("expected operations to move out of the index:\n%s", rendered)
+	}
+}
+
+func TestMerger_Split_BySection(t *testing.T) {
+	s, _ := skill.Parse(splitDoc)
+
+	result, err := New().Split(s, &SplitOptions{Mode: SplitSection})
+	if err != nil {
+		t.Fatalf("split failed: %v", err)
+	}
+
+	var slugs []string
+	for _, part := range result.Parts {
+		slugs = append(slugs, part.Slug)
+	}
+	if strings.Join(slugs, ",") != "authentication,endpoints" {
+		t.Errorf("unexpected parts: %v", slugs)
+	}
+	if n := len(result.Parts[1].Skill.Frontmatter.ToolDefinitions); n != 3 {
+		t.Errorf("expected the endpoints part to carry 3 tools, got %d", n)
+	}
+}
+
+func TestMerger_Split_GraphQLAndGRPC(t *testing.T) {
+	graphql := skill.NewSkill("Schema", "")
+	graphql.Frontmatter.SourceType = "graphql"
+	graphql.Frontmatter.ToolDefinitions = []skill.ToolDefinition{{Name: "query_users"}, {Name: "mutation_createUser"}}
+	graphql.Sections = []skill.Section{
+		{Title: "Queries", Level: 2, Content: "### users\n\nList users."},
+		{Title: "Mutations", Level: 2, Content: "### createUser\n\nCreate a user."},
+	}
+
+	result, err := New().Split(graphql, nil)
+	if err != nil {
+		t.Fatalf("graphql split failed: %v", err)
+	}
+	if len(result.Parts) != 2 || result.Parts[1].Title != "Mutations" || len(result.Parts[1].Skill.Frontmatter.ToolDefinitions) != 1 {
+		t.Errorf("expected parts by root type, got %+v", result.Parts)
+	}
+
+	grpc := skill.NewSkill("Services", "")
+	grpc.Frontmatter.SourceType = "proto"
+	grpc.Frontmatter.ToolDefinitions = []skill.ToolDefinition{{Name: "userservice_getuser"}, {Name: "orderservice_getorder"}}
+	grpc.Sections = []skill.Section{
+		{Title: "Methods", Level: 2},
+		{Title: "UserService.GetUser", Level: 3},
+		{Title: "OrderService.GetOrder", Level: 3},
+	}
+
+	result, err = New().Split(grpc, nil)
+	if err != nil {
+		t.Fatalf("grpc split failed: %v", err)
+	}
+	if len(result.Parts) != 2 || result.Parts[0].Slug != "userservice" || result.Parts[1].Slug != "orderservice" {
+		t.Errorf("expected parts by service, got %+v", result.Parts)
+	}
+}
+
+func TestMerger_Split_NothingToSplit(t *testing.T) {
+	s := skill.NewSkill("Tiny", "")
+	s.Sections = []skill.Section{{Title: "Only", Level: 2, Content: "One section."}}
+
+	if _, err := New().Split(s, &SplitOptions{Mode: SplitSection}); err == nil {
+		t.Error("expected an error for a single-section skill")
+	}
+}
@@ -17,12 +17,18 @@ type Repository struct {
 	db *sql.DB
 }
 
+// querier is implemented by both *sql.DB and *sql.Tx.
+type querier interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	QueryRow(query string, args ...any) *sql.Row
+}
+
 // NewRepository creates a new Repository.
 func NewRepository(db *sql.DB) *Repository {
 	return &Repository{db: db}
 }
 
-// Create inserts a new skill.
+// Create inserts a new skill and records it as revision 1 of its history.
 func (r *Repository) Create(s *skill.StoredSkill) error {
 	if s.ID == "" {
 		s.ID = uuid.New().String()
@@ -34,10 +40,16 @@ func (r *Repository) Create(s *skill.StoredSkill) error {
 	s.CreatedAt = time.Now()
 	s.UpdatedAt = s.CreatedAt
 
-	_, err := r.db.Exec(`
-		INSERT INTO skills (id, slug, name, version, description, content, content_hash, source_format, view_count, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-	`, s.ID, s.Slug, s.Name, s.Version, s.Description, s.Content, s.ContentHash, s.SourceFormat, s.ViewCount, s.CreatedAt, s.UpdatedAt)
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		INSERT INTO skills (id, slug, name, version, description, content, content_hash, source_format, owner, view_count, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, s.ID, s.Slug, s.Name, s.Version, s.Description, s.Content, s.ContentHash, s.SourceFormat, s.Owner, s.ViewCount, s.CreatedAt, s.UpdatedAt)
 
 	if err != nil {
 		return fmt.Errorf("failed to create skill: %w", err)
@@ -45,11 +57,22 @@ func (r *Repository) Create(s *skill.StoredSkill) error {
 
 	// Add tags
 	if len(s.Tags) > 0 {
-		if err := r.setTags(s.ID, s.Tags); err != nil {
+		if err := r.setTags(tx, s.ID, s.Tags); err != nil {
 			return fmt.Errorf("failed to set tags: %w", err)
 		}
 	}
 
+	if err := r.setFiles(tx, s.ID, s.Files); err != nil {
+		return fmt.Errorf("failed to set files: %w", err)
+	}
+
+	if err := r.createVersion(tx, s); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit skill: %w", err)
+	}
 	return nil
 }
 
@@ -57,9 +80,9 @@ func (r *Repository) Create(s *skill.StoredSkill) error {
 func (r *Repository) GetByID(id string) (*skill.StoredSkill, error) {
 	s := &skill.StoredSkill{}
 	err := r.db.QueryRow(`
-		SELECT id, slug, name, version, description, content, content_hash, source_format, view_count, created_at, updated_at
+		SELECT id, slug, name, version, description, content, content_hash, source_format, owner, view_count, created_at, updated_at
 		FROM skills WHERE id = ?
-	`, id).Scan(&s.ID, &s.Slug, &s.Name, &s.Version, &s.Description, &s.Content, &s.ContentHash, &s.SourceFormat, &s.ViewCount, &s.CreatedAt, &s.UpdatedAt)
+	`, id).Scan(&s.ID, &s.Slug, &s.Name, &s.Version, &s.Description, &s.Content, &s.ContentHash, &s.SourceFormat, &s.Owner, &s.ViewCount, &s.CreatedAt, &s.UpdatedAt)
 
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -69,6 +92,10 @@ func (r *Repository) GetByID(id string) (*skill.StoredSkill, error) {
 	}
 
 	s.Tags, _ = r.getTags(s.ID)
+	s.Files, err = r.getFiles(s.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get skill files: %w", err)
+	}
 	return s, nil
 }
 
@@ -76,9 +103,9 @@ func (r *Repository) GetByID(id string) (*skill.StoredSkill, error) {
 func (r *Repository) GetBySlug(slug string) (*skill.StoredSkill, error) {
 	s := &skill.StoredSkill{}
 	err := r.db.QueryRow(`
-		SELECT id, slug, name, version, description, content, content_hash, source_format, view_count, created_at, updated_at
+		SELECT id, slug, name, version, description, content, content_hash, source_format, owner, view_count, created_at, updated_at
 		FROM skills WHERE slug = ?
-	`, slug).Scan(&s.ID, &s.Slug, &s.Name, &s.Version, &s.Description, &s.Content, &s.ContentHash, &s.SourceFormat, &s.ViewCount, &s.CreatedAt, &s.UpdatedAt)
+	`, slug).Scan(&s.ID, &s.Slug, &s.Name, &s.Version, &s.Description, &s.Content, &s.ContentHash, &s.SourceFormat, &s.Owner, &s.ViewCount, &s.CreatedAt, &s.UpdatedAt)
 
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -88,15 +115,26 @@ func (r *Repository) GetBySlug(slug string) (*skill.StoredSkill, error) {
 	}
 
 	s.Tags, _ = r.getTags(s.ID)
+	s.Files, err = r.getFiles(s.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get skill files: %w", err)
+	}
 	return s, nil
 }
 
-// Update updates an existing skill.
+// Update updates an existing skill and records the new state as a revision,
+// in one transaction so the skill and its history cannot diverge.
 func (r *Repository) Update(s *skill.StoredSkill) error {
 	s.ContentHash = r.hashContent(s.Content)
 	s.UpdatedAt = time.Now()
 
-	_, err := r.db.Exec(`
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
 		UPDATE skills SET name = ?, version = ?, description = ?, content = ?, content_hash = ?, source_format = ?, updated_at = ?
 		WHERE id = ?
 	`, s.Name, s.Version, s.Description, s.Content, s.ContentHash, s.SourceFormat, s.UpdatedAt, s.ID)
@@ -105,10 +143,21 @@ func (r *Repository) Update(s *skill.StoredSkill) error {
 		return fmt.Errorf("failed to update skill: %w", err)
 	}
 
-	if err := r.setTags(s.ID, s.Tags); err != nil {
+	if err := r.setTags(tx, s.ID, s.Tags); err != nil {
 		return fmt.Errorf("failed to update tags: %w", err)
 	}
 
+	if err := r.setFiles(tx, s.ID, s.Files); err != nil {
+		return fmt.Errorf("failed to update files: %w", err)
+	}
+
+	if err := r.createVersion(tx, s); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit skill: %w", err)
+	}
 	return nil
 }
 
@@ -129,7 +178,7 @@ func (r *Repository) List(offset, limit int) ([]*skill.StoredSkill, int, error)
 
 	// Get skills
 	rows, err := r.db.Query(`
-		SELECT id, slug, name, version, description, content, content_hash, source_format, view_count, created_at, updated_at
+		SELECT id, slug, name, version, description, content, content_hash, source_format, owner, view_count, created_at, updated_at
 		FROM skills ORDER BY created_at DESC LIMIT ? OFFSET ?
 	`, limit, offset)
 	if err != nil {
@@ -140,7 +189,7 @@ func (r *Repository) List(offset, limit int) ([]*skill.StoredSkill, int, error)
 	var skills []*skill.StoredSkill
 	for rows.Next() {
 		s := &skill.StoredSkill{}
-		err := rows.Scan(&s.ID, &s.Slug, &s.Name, &s.Version, &s.Description, &s.Content, &s.ContentHash, &s.SourceFormat, &s.ViewCount, &s.CreatedAt, &s.UpdatedAt)
+		err := rows.Scan(&s.ID, &s.Slug, &s.Name, &s.Version, &s.Description, &s.Content, &s.ContentHash, &s.SourceFormat, &s.Owner, &s.ViewCount, &s.CreatedAt, &s.UpdatedAt)
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to scan skill: %w", err)
 		}
@@ -189,7 +238,7 @@ func (r *Repository) Search(query string, offset, limit int) ([]*skill.StoredSki
 
 	// Get matching skills
 	rows, err := r.db.Query(`
-		SELECT s.id, s.slug, s.name, s.version, s.description, s.content, s.content_hash, s.source_format, s.view_count, s.created_at, s.updated_at
+		SELECT s.id, s.slug, s.name, s.version, s.description, s.content, s.content_hash, s.source_format, s.owner, s.view_count, s.created_at, s.updated_at
 		FROM skills s
 		JOIN skills_fts fts ON s.rowid = fts.rowid
 		WHERE skills_fts MATCH ?
@@ -204,7 +253,7 @@ func (r *Repository) Search(query string, offset, limit int) ([]*skill.StoredSki
 	var skills []*skill.StoredSkill
 	for rows.Next() {
 		s := &skill.StoredSkill{}
-		err := rows.Scan(&s.ID, &s.Slug, &s.Name, &s.Version, &s.Description, &s.Content, &s.ContentHash, &s.SourceFormat, &s.ViewCount, &s.CreatedAt, &s.UpdatedAt)
+		err := rows.Scan(&s.ID, &s.Slug, &s.Name, &s.Version, &s.Description, &s.Content, &s.ContentHash, &s.SourceFormat, &s.Owner, &s.ViewCount, &s.CreatedAt, &s.UpdatedAt)
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to scan skill: %w", err)
 		}
@@ -231,7 +280,7 @@ func (r *Repository) ListByTag(tag string, offset, limit int) ([]*skill.StoredSk
 
 	// Get skills
 	rows, err := r.db.Query(`
-		SELECT s.id, s.slug, s.name, s.version, s.description, s.content, s.content_hash, s.source_format, s.view_count, s.created_at, s.updated_at
+		SELECT s.id, s.slug, s.name, s.version, s.description, s.content, s.content_hash, s.source_format, s.owner, s.view_count, s.created_at, s.updated_at
 		FROM skills s
 		JOIN skill_tags st ON s.id = st.skill_id
 		JOIN tags t ON st.tag_id = t.id
@@ -247,7 +296,7 @@ func (r *Repository) ListByTag(tag string, offset, limit int) ([]*skill.StoredSk
 	var skills []*skill.StoredSkill
 	for rows.Next() {
 		s := &skill.StoredSkill{}
-		err := rows.Scan(&s.ID, &s.Slug, &s.Name, &s.Version, &s.Description, &s.Content, &s.ContentHash, &s.SourceFormat, &s.ViewCount, &s.CreatedAt, &s.UpdatedAt)
+		err := rows.Scan(&s.ID, &s.Slug, &s.Name, &s.Version, &s.Description, &s.Content, &s.ContentHash, &s.SourceFormat, &s.Owner, &s.ViewCount, &s.CreatedAt, &s.UpdatedAt)
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to scan skill: %w", err)
 		}
@@ -291,6 +340,78 @@ func (r *Repository) GetAllTags() (map[string]int, error) {
 	return tags, nil
 }
 
+// createVersion records the current state of a skill as a new revision.
+// Nothing is recorded when the latest revision has the same content hash and
+// version. The revision number is computed by the insert itself so it is
+// assigned under the transaction's write lock.
+func (r *Repository) createVersion(q querier, s *skill.StoredSkill) error {
+	var hash, version string
+	err := q.QueryRow(`
+		SELECT content_hash, version FROM skill_versions
+		WHERE skill_id = ? ORDER BY revision DESC LIMIT 1
+	`, s.ID).Scan(&hash, &version)
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("failed to get latest version: %w", err)
+	}
+	if err == nil && hash == s.ContentHash && version == s.Version {
+		return nil
+	}
+
+	_, err = q.Exec(`
+		INSERT INTO skill_versions (skill_id, revision, version, name, description, content, content_hash, source_format, created_at)
+		SELECT ?, COALESCE(MAX(revision), 0) + 1, ?, ?, ?, ?, ?, ?, ?
+		FROM skill_versions WHERE skill_id = ?
+	`, s.ID, s.Version, s.Name, s.Description, s.Content, s.ContentHash, s.SourceFormat, time.Now(), s.ID)
+	if err != nil {
+		return fmt.Errorf("failed to create version: %w", err)
+	}
+
+	return nil
+}
+
+// ListVersions retrieves the revisions of a skill, newest first. Content is
+// not loaded; use GetVersion for a single revision.
+func (r *Repository) ListVersions(skillID string) ([]*skill.SkillVersion, error) {
+	rows, err := r.db.Query(`
+		SELECT id, skill_id, revision, version, name, description, content_hash, source_format, created_at
+		FROM skill_versions WHERE skill_id = ? ORDER BY revision DESC
+	`, skillID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list versions: %w", err)
+	}
+	defer rows.Close()
+
+	var versions []*skill.SkillVersion
+	for rows.Next() {
+		v := &skill.SkillVersion{}
+		err := rows.Scan(&v.ID, &v.SkillID, &v.Revision, &v.Version, &v.Name, &v.Description, &v.ContentHash, &v.SourceFormat, &v.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan version: %w", err)
+		}
+		versions = append(versions, v)
+	}
+
+	return versions, nil
+}
+
+// GetVersion retrieves a single revision of a skill.
+func (r *Repository) GetVersion(skillID string, revision int) (*skill.SkillVersion, error) {
+	v := &skill.SkillVersion{}
+	err := r.db.QueryRow(`
+		SELECT id, skill_id, revision, version, name, description, content, content_hash, source_format, created_at
+		FROM skill_versions WHERE skill_id = ? AND revision = ?
+	`, skillID, revision).Scan(&v.ID, &v.SkillID, &v.Revision, &v.Version, &v.Name, &v.Description, &v.Content, &v.ContentHash, &v.SourceFormat, &v.CreatedAt)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get version: %w", err)
+	}
+
+	return v, nil
+}
+
 func (r *Repository) getTags(skillID string) ([]string, error) {
 	rows, err := r.db.Query(`
 		SELECT t.name FROM tags t
@@ -314,9 +435,9 @@ func (r *Repository) getTags(skillID string) ([]string, error) {
 	return tags, nil
 }
 
-func (r *Repository) setTags(skillID string, tags []string) error {
+func (r *Repository) setTags(q querier, skillID string, tags []string) error {
 	// Remove existing tags
-	_, err := r.db.Exec("DELETE FROM skill_tags WHERE skill_id = ?", skillID)
+	_, err := q.Exec("DELETE FROM skill_tags WHERE skill_id = ?", skillID)
 	if err != nil {
 		return err
 	}
@@ -325,9 +446,9 @@ func (r *Repository) setTags(skillID string, tags []string) error {
 	for _, tagName := range tags {
 		// Get or create tag
 		var tagID int64
-		err := r.db.QueryRow("SELECT id FROM tags WHERE name = ?", tagName).Scan(&tagID)
+		err := q.QueryRow("SELECT id FROM tags WHERE name = ?", tagName).Scan(&tagID)
 		if err == sql.ErrNoRows {
-			result, err := r.db.Exec("INSERT INTO tags (name) VALUES (?)", tagName)
+			result, err := q.Exec("INSERT INTO tags (name) VALUES (?)", tagName)
 			if err != nil {
 				return err
 			}
@@ -337,7 +458,7 @@ func (r *Repository) setTags(skillID string, tags []string) error {
 		}
 
 		// Link skill to tag
-		_, err = r.db.Exec("INSERT OR IGNORE INTO skill_tags (skill_id, tag_id) VALUES (?, ?)", skillID, tagID)
+		_, err = q.Exec("INSERT OR IGNORE INTO skill_tags (skill_id, tag_id) VALUES (?, ?)", skillID, tagID)
 		if err != nil {
 			return err
 		}
@@ -346,6 +467,44 @@ func (r *Repository) setTags(skillID string, tags []string) error {
 	return nil
 }
 
+// getFiles retrieves the folder of a skill, SKILL.md first. Skills not
+// published as a folder have none.
+func (r *Repository) getFiles(skillID string) ([]skill.File, error) {
+	rows, err := r.db.Query(`
+		SELECT path, content FROM skill_files
+		WHERE skill_id = ? ORDER BY path = 'SKILL.md' DESC, path
+	`, skillID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var files []skill.File
+	for rows.Next() {
+		var f skill.File
+		if err := rows.Scan(&f.Path, &f.Content); err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+
+	return files, rows.Err()
+}
+
+func (r *Repository) setFiles(q querier, skillID string, files []skill.File) error {
+	if _, err := q.Exec("DELETE FROM skill_files WHERE skill_id = ?", skillID); err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		if _, err := q.Exec("INSERT INTO skill_files (skill_id, path, content) VALUES (?, ?, ?)", skillID, f.Path, f.Content); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (r *Repository) generateSlug(name string) string {
 	slug := strings.ToLower(name)
 	slug = strings.ReplaceAll(slug, " ", "-")
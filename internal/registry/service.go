@@ -2,11 +2,17 @@
 package registry
 
 import (
+	"errors"
 	"fmt"
 
+	"github.com/sanixdarker/skill-md/internal/bundle"
+	"github.com/sanixdarker/skill-md/internal/diff"
 	"github.com/sanixdarker/skill-md/pkg/skill"
 )
 
+// ErrVersionNotFound is returned when a skill revision does not exist.
+var ErrVersionNotFound = errors.New("version not found")
+
 // Service provides business logic for the skill registry.
 type Service struct {
 	repo *Repository
@@ -19,6 +25,26 @@ func NewService(repo *Repository) *Service {
 
 // CreateSkill creates a new skill in the registry.
 func (s *Service) CreateSkill(sk *skill.Skill) (*skill.StoredSkill, error) {
+	return s.CreateSkillAs(sk, "")
+}
+
+// CreateSkillAs creates a new skill owned by owner. An empty owner leaves
+// the skill unowned, so only admins can modify it when auth is enforced.
+func (s *Service) CreateSkillAs(sk *skill.Skill, owner string) (*skill.StoredSkill, error) {
+	return s.createSkill(sk, nil, owner)
+}
+
+// CreateBundleAs creates a skill from an Agent Skills folder owned by owner.
+// The folder is stored as is, and Content holds it assembled into one file.
+func (s *Service) CreateBundleAs(files []skill.File, owner string) (*skill.StoredSkill, error) {
+	sk, err := bundle.Assemble(files)
+	if err != nil {
+		return nil, fmt.Errorf("failed to assemble skill folder: %w", err)
+	}
+	return s.createSkill(sk, files, owner)
+}
+
+func (s *Service) createSkill(sk *skill.Skill, files []skill.File, owner string) (*skill.StoredSkill, error) {
 	stored := &skill.StoredSkill{
 		Name:         sk.Frontmatter.Name,
 		Version:      sk.Frontmatter.Version,
@@ -26,6 +52,8 @@ func (s *Service) CreateSkill(sk *skill.Skill) (*skill.StoredSkill, error) {
 		Content:      skill.Render(sk),
 		SourceFormat: sk.Frontmatter.SourceType,
 		Tags:         sk.Frontmatter.Tags,
+		Owner:        owner,
+		Files:        files,
 	}
 
 	if err := s.repo.Create(stored); err != nil {
@@ -50,8 +78,22 @@ func (s *Service) GetSkill(idOrSlug string) (*skill.StoredSkill, error) {
 	return s.repo.GetBySlug(idOrSlug)
 }
 
-// UpdateSkill updates an existing skill.
+// UpdateSkill updates an existing skill. A skill stored as a folder is
+// stored as a single file from then on.
 func (s *Service) UpdateSkill(id string, sk *skill.Skill) (*skill.StoredSkill, error) {
+	return s.updateSkill(id, sk, nil)
+}
+
+// UpdateBundle replaces an existing skill with an Agent Skills folder.
+func (s *Service) UpdateBundle(id string, files []skill.File) (*skill.StoredSkill, error) {
+	sk, err := bundle.Assemble(files)
+	if err != nil {
+		return nil, fmt.Errorf("failed to assemble skill folder: %w", err)
+	}
+	return s.updateSkill(id, sk, files)
+}
+
+func (s *Service) updateSkill(id string, sk *skill.Skill, files []skill.File) (*skill.StoredSkill, error) {
 	existing, err := s.repo.GetByID(id)
 	if err != nil {
 		return nil, err
@@ -66,14 +108,126 @@ func (s *Service) UpdateSkill(id string, sk *skill.Skill) (*skill.StoredSkill, e
 	existing.Content = skill.Render(sk)
 	existing.SourceFormat = sk.Frontmatter.SourceType
 	existing.Tags = sk.Frontmatter.Tags
+	existing.Files = files
 
-	if err := s.repo.Update(existing); err != nil {
-		return nil, fmt.Errorf("failed to update skill: %w", err)
+	if err := s.saveRevision(existing); err != nil {
+		return nil, err
 	}
 
 	return existing, nil
 }
 
+// Files returns a skill as an Agent Skills folder: the folder it was
+// published as, or one laid out from its content.
+func (s *Service) Files(stored *skill.StoredSkill) ([]skill.File, error) {
+	if len(stored.Files) > 0 {
+		return stored.Files, nil
+	}
+	sk, err := skill.Parse(stored.Content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse skill: %w", err)
+	}
+	return bundle.Build(sk), nil
+}
+
+// saveRevision writes a skill and snapshots it in the version history.
+func (s *Service) saveRevision(stored *skill.StoredSkill) error {
+	if err := s.repo.Update(stored); err != nil {
+		return fmt.Errorf("failed to update skill: %w", err)
+	}
+	return nil
+}
+
+// ListVersions retrieves the revision history of a skill, newest first.
+func (s *Service) ListVersions(skillID string) ([]*skill.SkillVersion, error) {
+	return s.repo.ListVersions(skillID)
+}
+
+// GetVersion retrieves a single revision of a skill.
+func (s *Service) GetVersion(skillID string, revision int) (*skill.SkillVersion, error) {
+	return s.repo.GetVersion(skillID, revision)
+}
+
+// DiffVersions returns a unified diff between two revisions of a skill.
+func (s *Service) DiffVersions(skillID string, from, to int) (string, error) {
+	fromVersion, err := s.requireVersion(skillID, from)
+	if err != nil {
+		return "", err
+	}
+	toVersion, err := s.requireVersion(skillID, to)
+	if err != nil {
+		return "", err
+	}
+
+	return diff.Unified(
+		fmt.Sprintf("revision %d (v%s)", from, fromVersion.Version),
+		fmt.Sprintf("revision %d (v%s)", to, toVersion.Version),
+		fromVersion.Content,
+		toVersion.Content,
+	), nil
+}
+
+// CompareVersions returns the semantic changes between two revisions of a
+// skill.
+func (s *Service) CompareVersions(skillID string, from, to int) ([]diff.Change, error) {
+	fromVersion, err := s.requireVersion(skillID, from)
+	if err != nil {
+		return nil, err
+	}
+	toVersion, err := s.requireVersion(skillID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return diff.CompareContent(fromVersion.Content, toVersion.Content)
+}
+
+// RollbackSkill restores a skill to an earlier revision. The restored
+// content is recorded as a new revision, so later history is kept.
+func (s *Service) RollbackSkill(skillID string, revision int) (*skill.StoredSkill, error) {
+	existing, err := s.repo.GetByID(skillID)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, fmt.Errorf("skill not found: %s", skillID)
+	}
+
+	v, err := s.requireVersion(skillID, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	existing.Name = v.Name
+	existing.Version = v.Version
+	existing.Description = v.Description
+	existing.Content = v.Content
+	existing.SourceFormat = v.SourceFormat
+	// Revisions only keep the assembled content, not the folder
+	existing.Files = nil
+	// Tags are not snapshotted separately; recover them from the frontmatter
+	if sk, err := skill.Parse(v.Content); err == nil {
+		existing.Tags = sk.Frontmatter.Tags
+	}
+
+	if err := s.saveRevision(existing); err != nil {
+		return nil, err
+	}
+
+	return existing, nil
+}
+
+func (s *Service) requireVersion(skillID string, revision int) (*skill.SkillVersion, error) {
+	v, err := s.repo.GetVersion(skillID, revision)
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return nil, fmt.Errorf("%w: revision %d", ErrVersionNotFound, revision)
+	}
+	return v, nil
+}
+
 // DeleteSkill removes a skill from the registry.
 func (s *Service) DeleteSkill(id string) error {
 	return s.repo.Delete(id)
@@ -169,10 +323,15 @@ func (s *Service) GetAllTags() (map[string]int, error) {
 
 // ImportSkill imports a SKILL.md file into the registry.
 func (s *Service) ImportSkill(content string) (*skill.StoredSkill, error) {
+	return s.ImportSkillAs(content, "")
+}
+
+// ImportSkillAs imports a SKILL.md file owned by owner.
+func (s *Service) ImportSkillAs(content, owner string) (*skill.StoredSkill, error) {
 	sk, err := skill.Parse(content)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse skill: %w", err)
 	}
 
-	return s.CreateSkill(sk)
+	return s.CreateSkillAs(sk, owner)
 }
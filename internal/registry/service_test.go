@@ -0,0 +1,132 @@
+package registry
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/sanixdarker/skill-md/internal/storage"
+	"github.com/sanixdarker/skill-md/pkg/skill"
+)
+
+func newTestService(t *testing.T) *Service {
+	t.Helper()
+	db, err := storage.NewDB(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("failed to open db: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := storage.Migrate(db); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	return NewService(NewRepository(db))
+}
+
+func TestService_ConcurrentUpdatesRecordEveryRevision(t *testing.T) {
+	svc := newTestService(t)
+
+	stored, err := svc.CreateSkill(skill.NewSkill("Pets", "Pet store"))
+	if err != nil {
+		t.Fatalf("failed to create skill: %v", err)
+	}
+
+	const updates = 8
+	var wg sync.WaitGroup
+	errs := make(chan error, updates)
+	for i := 0; i < updates; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sk := skill.NewSkill("Pets", fmt.Sprintf("Pet store %d", i))
+			if _, err := svc.UpdateSkill(stored.ID, sk); err != nil {
+				errs <- err
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("update failed: %v", err)
+	}
+
+	versions, err := svc.ListVersions(stored.ID)
+	if err != nil {
+		t.Fatalf("failed to list versions: %v", err)
+	}
+	if len(versions) != updates+1 {
+		t.Fatalf("expected %d revisions, got %d", updates+1, len(versions))
+	}
+	for i, v := range versions {
+		if want := updates + 1 - i; v.Revision != want {
+			t.Errorf("expected revision %d at position %d, got %d", want, i, v.Revision)
+		}
+	}
+}
+
+func TestService_StoresSkillFolders(t *testing.T) {
+	svc := newTestService(t)
+
+	files := []skill.File{
+		{Path: "SKILL.md", Content: "---\nname: \"Pets\"\nversion: \"1.0.0\"\n---\n\n## Guides\n\n- [Setup](reference/setup.md)\n"},
+		{Path: "reference/setup.md", Content: "# Setup\n\nFeed the pets."},
+		{Path: "scripts/feed.sh", Content: "#!/bin/sh\n"},
+	}
+	stored, err := svc.CreateBundleAs(files, "")
+	if err != nil {
+		t.Fatalf("failed to create skill: %v", err)
+	}
+	if !strings.Contains(stored.Content, "### Setup\n\nFeed the pets.") {
+		t.Errorf("content should hold the assembled folder:\n%s", stored.Content)
+	}
+
+	got, err := svc.GetSkill(stored.Slug)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Files) != 3 || got.Files[0].Path != "SKILL.md" || got.Files[2].Content != "#!/bin/sh\n" {
+		t.Fatalf("folder was not stored: %+v", got.Files)
+	}
+	folder, err := svc.Files(got)
+	if err != nil || len(folder) != 3 {
+		t.Errorf("expected the stored folder back, got %d files (%v)", len(folder), err)
+	}
+
+	// A single-file update replaces the folder
+	if _, err := svc.UpdateSkill(stored.ID, skill.NewSkill("Pets", "Pet store")); err != nil {
+		t.Fatal(err)
+	}
+	got, _ = svc.GetSkill(stored.ID)
+	if len(got.Files) != 0 {
+		t.Errorf("expected the folder to be dropped, got %+v", got.Files)
+	}
+	folder, err = svc.Files(got)
+	if err != nil || len(folder) == 0 || folder[0].Path != "SKILL.md" {
+		t.Errorf("expected a folder laid out from the content, got %+v (%v)", folder, err)
+	}
+
+	if _, err := svc.CreateBundleAs([]skill.File{{Path: "notes.md"}}, ""); err == nil {
+		t.Error("expected an error for a folder without SKILL.md")
+	}
+}
+
+func TestService_ImportSkillKeepsExtensions(t *testing.T) {
+	svc := newTestService(t)
+
+	content := "---\nname: Pets\nlicense: MIT\nallowed-tools: [Read, Grep]\nmetadata:\n  owner: {team: pets}\n---\n\n## Usage\n\nFeed the pets.\n"
+	stored, err := svc.ImportSkill(content)
+	if err != nil {
+		t.Fatalf("failed to import skill: %v", err)
+	}
+
+	got, err := skill.Parse(stored.Content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := skill.Parse(content)
+	if !reflect.DeepEqual(got.Frontmatter.Extensions, want.Frontmatter.Extensions) {
+		t.Errorf("expected extensions to survive the import, got %#v", got.Frontmatter.Extensions)
+	}
+}
@@ -0,0 +1,607 @@
+package handlers
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sanixdarker/skill-md/internal/app"
+	"github.com/sanixdarker/skill-md/internal/bundle"
+	"github.com/sanixdarker/skill-md/internal/converter"
+	"github.com/sanixdarker/skill-md/internal/merger"
+	"github.com/sanixdarker/skill-md/internal/server/middleware"
+	"github.com/sanixdarker/skill-md/internal/sources"
+	"github.com/sanixdarker/skill-md/pkg/skill"
+)
+
+// API limits
+const (
+	maxAPIBodySize    = 10 << 20 // 10MB
+	defaultAPIPerPage = 20
+	maxAPIPerPage     = 100
+	maxAPIMergeSkills = 10
+	maxAPIBundleFiles = 500
+	apiSourceTimeout  = 30 * time.Second
+)
+
+//go:embed openapi_v1.json
+var openAPIDocument []byte
+
+// APIError is the error envelope returned by every /api/v1 endpoint.
+type APIError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// APIHandler serves the versioned JSON API under /api/v1.
+type APIHandler struct {
+	app *app.App
+}
+
+// NewAPIHandler creates a new APIHandler.
+func NewAPIHandler(application *app.App) *APIHandler {
+	return &APIHandler{app: application}
+}
+
+// OpenAPI serves the OpenAPI document describing /api/v1.
+func (h *APIHandler) OpenAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(openAPIDocument)
+}
+
+// SkillSchema serves the JSON Schema of SKILL.md frontmatter.
+func (h *APIHandler) SkillSchema(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/schema+json")
+	w.Write(skill.FrontmatterSchema)
+}
+
+// Convert converts a spec to a skill.
+func (h *APIHandler) Convert(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Content  string `json:"content"`
+		URL      string `json:"url"`
+		Format   string `json:"format"`
+		Filename string `json:"filename"`
+		Name     string `json:"name"`
+	}
+	if !h.decode(w, r, &req) {
+		return
+	}
+
+	content, filename, format := []byte(req.Content), req.Filename, req.Format
+	switch {
+	case req.URL != "":
+		if msg := middleware.ValidateURL(req.URL); msg != "" {
+			writeAPIError(w, http.StatusBadRequest, "invalid_url", msg)
+			return
+		}
+		content, filename, format = []byte(req.URL), req.URL, "url"
+	case req.Content == "":
+		writeAPIError(w, http.StatusBadRequest, "invalid_request", "content or url is required")
+		return
+	case format == "url":
+		writeAPIError(w, http.StatusBadRequest, "invalid_request", "use the url field to convert from a URL")
+		return
+	}
+
+	if format == "" || format == "auto" {
+		format = h.app.ConverterManager.DetectFormat(filename, content)
+	}
+	if !h.supportsFormat(format) {
+		writeAPIError(w, http.StatusBadRequest, "unsupported_format", "unsupported format: "+format)
+		return
+	}
+
+	result, err := h.app.ConverterManager.Convert(format, content, &converter.Options{
+		Name:       req.Name,
+		SourcePath: filename,
+	})
+	if err != nil {
+		h.app.Logger.Error("api conversion failed", "format", format, "error", err)
+		writeAPIError(w, http.StatusUnprocessableEntity, "conversion_failed", "conversion failed, check the input format")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"format":   format,
+		"skill":    result,
+		"markdown": skill.Render(result),
+	})
+}
+
+// Detect reports the format of a spec.
+func (h *APIHandler) Detect(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Content  string `json:"content"`
+		Filename string `json:"filename"`
+	}
+	if !h.decode(w, r, &req) {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{
+		"format": h.app.ConverterManager.DetectFormat(req.Filename, []byte(req.Content)),
+	})
+}
+
+// Merge combines skills given inline or as references.
+func (h *APIHandler) Merge(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Skills      []string   `json:"skills"`
+		Refs        []SkillRef `json:"refs"`
+		Name        string     `json:"name"`
+		Description string     `json:"description"`
+		Deduplicate bool       `json:"deduplicate"`
+		Strategy    string     `json:"strategy"`
+	}
+	if !h.decode(w, r, &req) {
+		return
+	}
+
+	strategy := merger.Combine
+	if req.Strategy != "" {
+		parsed, err := merger.ParseStrategy(req.Strategy)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, "invalid_request", err.Error())
+			return
+		}
+		strategy = parsed
+	}
+
+	count := len(req.Skills) + len(req.Refs)
+	if count < 2 || count > maxAPIMergeSkills {
+		writeAPIError(w, http.StatusBadRequest, "invalid_request", "between 2 and 10 skills are required for merging")
+		return
+	}
+
+	contents := req.Skills
+	if len(req.Refs) > 0 {
+		ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
+		defer cancel()
+
+		fetcher := NewMergeHandler(h.app)
+		for _, ref := range req.Refs {
+			if !validSources[ref.Source] || ref.ID == "" || containsPathTraversal(ref.ID) {
+				writeAPIError(w, http.StatusBadRequest, "invalid_request", "invalid skill reference: "+ref.Source+"/"+ref.ID)
+				return
+			}
+			content, err := fetcher.fetchSkillContent(ctx, ref)
+			if err != nil {
+				h.app.Logger.Error("api merge fetch failed", "source", ref.Source, "id", ref.ID, "error", err)
+				writeAPIError(w, http.StatusBadGateway, "fetch_failed", "failed to fetch skill "+ref.Source+"/"+ref.ID)
+				return
+			}
+			if content == "" {
+				writeAPIError(w, http.StatusNotFound, "not_found", "skill not found: "+ref.Source+"/"+ref.ID)
+				return
+			}
+			contents = append(contents, content)
+		}
+	}
+
+	skills := make([]*skill.Skill, 0, len(contents))
+	for i, content := range contents {
+		s, err := skill.Parse(content)
+		if err != nil {
+			writeAPIError(w, http.StatusUnprocessableEntity, "invalid_skill", "skill "+strconv.Itoa(i+1)+" could not be parsed")
+			return
+		}
+		skills = append(skills, s)
+	}
+
+	result, conflicts, err := h.app.Merger.MergeWithReport(skills, &merger.Options{
+		Name:        req.Name,
+		Description: req.Description,
+		Deduplicate: req.Deduplicate,
+		Resolver:    merger.NewConflictResolver(strategy),
+	})
+	if err != nil {
+		h.app.Logger.Error("api merge failed", "error", err)
+		writeAPIError(w, http.StatusInternalServerError, "merge_failed", "merge failed")
+		return
+	}
+
+	if conflicts == nil {
+		conflicts = []merger.Conflict{}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"skill":     result,
+		"markdown":  skill.Render(result),
+		"conflicts": conflicts,
+	})
+}
+
+// ListSkills lists registry skills, optionally filtered by tag.
+func (h *APIHandler) ListSkills(w http.ResponseWriter, r *http.Request) {
+	page, perPage, ok := pagination(w, r)
+	if !ok {
+		return
+	}
+
+	var (
+		skills []*skill.StoredSkill
+		total  int
+		err    error
+	)
+	if tag := r.URL.Query().Get("tag"); tag != "" {
+		if len(tag) > MaxSlugLength {
+			writeAPIError(w, http.StatusBadRequest, "invalid_request", "tag too long")
+			return
+		}
+		skills, total, err = h.app.RegistryService.ListSkillsByTag(tag, page, perPage)
+	} else {
+		skills, total, err = h.app.RegistryService.ListSkills(page, perPage)
+	}
+	if err != nil {
+		h.app.Logger.Error("api list skills failed", "error", err)
+		writeAPIError(w, http.StatusInternalServerError, "internal_error", "failed to list skills")
+		return
+	}
+
+	writeSkillPage(w, skills, total, page, perPage)
+}
+
+// SearchSkills searches the local registry.
+func (h *APIHandler) SearchSkills(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("q")
+	if len(query) > MaxQueryLength {
+		writeAPIError(w, http.StatusBadRequest, "invalid_request", "query too long")
+		return
+	}
+	page, perPage, ok := pagination(w, r)
+	if !ok {
+		return
+	}
+
+	skills, total, err := h.app.RegistryService.SearchSkills(query, page, perPage)
+	if err != nil {
+		h.app.Logger.Error("api search failed", "error", err)
+		writeAPIError(w, http.StatusInternalServerError, "internal_error", "search failed")
+		return
+	}
+
+	writeSkillPage(w, skills, total, page, perPage)
+}
+
+// FederatedSearch searches every enabled source, or those named in the
+// comma-separated "sources" parameter.
+func (h *APIHandler) FederatedSearch(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("q")
+	if len(query) > MaxQueryLength {
+		writeAPIError(w, http.StatusBadRequest, "invalid_request", "query too long")
+		return
+	}
+	page, perPage, ok := pagination(w, r)
+	if !ok {
+		return
+	}
+
+	var selected []sources.SourceType
+	if list := r.URL.Query().Get("sources"); list != "" {
+		for _, name := range strings.Split(list, ",") {
+			name = strings.TrimSpace(name)
+			if !validSources[name] {
+				writeAPIError(w, http.StatusBadRequest, "invalid_source", "invalid source: "+name)
+				return
+			}
+			selected = append(selected, sources.SourceType(name))
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), apiSourceTimeout)
+	defer cancel()
+
+	opts := sources.SearchOptions{Query: query, Page: page, PerPage: perPage}
+	var (
+		result *sources.FederatedResult
+		err    error
+	)
+	if selected == nil {
+		result, err = h.app.FederatedSource.Search(ctx, opts)
+	} else {
+		result, err = h.app.FederatedSource.SearchSources(ctx, opts, selected)
+	}
+	if err != nil {
+		h.app.Logger.Error("api federated search failed", "error", err)
+		writeAPIError(w, http.StatusBadGateway, "search_failed", "federated search failed")
+		return
+	}
+	if result.Skills == nil {
+		result.Skills = []*sources.ExternalSkill{}
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// GetSkill returns a registry skill by ID or slug.
+func (h *APIHandler) GetSkill(w http.ResponseWriter, r *http.Request) {
+	stored := h.lookupSkill(w, r)
+	if stored == nil {
+		return
+	}
+	writeJSON(w, http.StatusOK, stored)
+}
+
+// CreateSkill publishes a SKILL.md to the registry.
+func (h *APIHandler) CreateSkill(w http.ResponseWriter, r *http.Request) {
+	sk, files := h.decodeSkill(w, r)
+	if sk == nil {
+		return
+	}
+
+	var stored *skill.StoredSkill
+	var err error
+	if files != nil {
+		stored, err = h.app.RegistryService.CreateBundleAs(files, tokenOwner(r))
+	} else {
+		stored, err = h.app.RegistryService.CreateSkillAs(sk, tokenOwner(r))
+	}
+	if err != nil {
+		h.app.Logger.Error("api create skill failed", "error", err)
+		writeAPIError(w, http.StatusInternalServerError, "internal_error", "failed to create skill")
+		return
+	}
+
+	w.Header().Set("Location", "/api/v1/skills/"+stored.Slug)
+	writeJSON(w, http.StatusCreated, stored)
+}
+
+// ImportSkill copies an external skill into the registry.
+func (h *APIHandler) ImportSkill(w http.ResponseWriter, r *http.Request) {
+	var ref SkillRef
+	if !h.decode(w, r, &ref) {
+		return
+	}
+	if !validSources[ref.Source] || ref.Source == string(sources.SourceTypeLocal) {
+		writeAPIError(w, http.StatusBadRequest, "invalid_source", "invalid source: "+ref.Source)
+		return
+	}
+	if ref.ID == "" || len(ref.ID) > 500 || containsPathTraversal(ref.ID) {
+		writeAPIError(w, http.StatusBadRequest, "invalid_request", "invalid skill ID")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), apiSourceTimeout)
+	defer cancel()
+
+	content, err := NewMergeHandler(h.app).fetchSkillContent(ctx, ref)
+	if err != nil {
+		h.app.Logger.Error("api import fetch failed", "source", ref.Source, "id", ref.ID, "error", err)
+		writeAPIError(w, http.StatusBadGateway, "fetch_failed", "failed to fetch skill "+ref.Source+"/"+ref.ID)
+		return
+	}
+	if content == "" {
+		writeAPIError(w, http.StatusNotFound, "not_found", "skill not found: "+ref.Source+"/"+ref.ID)
+		return
+	}
+
+	stored, err := h.app.RegistryService.ImportSkillAs(content, tokenOwner(r))
+	if err != nil {
+		writeAPIError(w, http.StatusUnprocessableEntity, "invalid_skill", "failed to import skill: "+err.Error())
+		return
+	}
+
+	w.Header().Set("Location", "/api/v1/skills/"+stored.Slug)
+	writeJSON(w, http.StatusCreated, stored)
+}
+
+// UpdateSkill replaces a registry skill's content, recording a new revision.
+func (h *APIHandler) UpdateSkill(w http.ResponseWriter, r *http.Request) {
+	stored := h.lookupSkill(w, r)
+	if stored == nil {
+		return
+	}
+	if !canModifySkill(h.app, r, stored) {
+		writeAPIError(w, http.StatusForbidden, "forbidden", "only the skill owner or an admin can update this skill")
+		return
+	}
+
+	sk, files := h.decodeSkill(w, r)
+	if sk == nil {
+		return
+	}
+
+	var updated *skill.StoredSkill
+	var err error
+	if files != nil {
+		updated, err = h.app.RegistryService.UpdateBundle(stored.ID, files)
+	} else {
+		updated, err = h.app.RegistryService.UpdateSkill(stored.ID, sk)
+	}
+	if err != nil {
+		h.app.Logger.Error("api update skill failed", "error", err)
+		writeAPIError(w, http.StatusInternalServerError, "internal_error", "failed to update skill")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, updated)
+}
+
+// DeleteSkill removes a registry skill.
+func (h *APIHandler) DeleteSkill(w http.ResponseWriter, r *http.Request) {
+	stored := h.lookupSkill(w, r)
+	if stored == nil {
+		return
+	}
+	if !canModifySkill(h.app, r, stored) {
+		writeAPIError(w, http.StatusForbidden, "forbidden", "only the skill owner or an admin can delete this skill")
+		return
+	}
+
+	if err := h.app.RegistryService.DeleteSkill(stored.ID); err != nil {
+		h.app.Logger.Error("api delete skill failed", "error", err)
+		writeAPIError(w, http.StatusInternalServerError, "internal_error", "failed to delete skill")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// APIAuthError writes auth middleware failures with the API error envelope.
+// It satisfies middleware.AuthErrorWriter.
+func APIAuthError(w http.ResponseWriter, r *http.Request, status int, code, message string) {
+	writeAPIError(w, status, code, message)
+}
+
+// NotFound answers unknown /api/v1 routes.
+func (h *APIHandler) NotFound(w http.ResponseWriter, r *http.Request) {
+	writeAPIError(w, http.StatusNotFound, "not_found", "no such endpoint")
+}
+
+// MethodNotAllowed answers known /api/v1 routes called with the wrong method.
+func (h *APIHandler) MethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	writeAPIError(w, http.StatusMethodNotAllowed, "method_not_allowed", r.Method+" is not allowed here")
+}
+
+// decode reads a JSON request body into v. Requiring an application/json
+// content type keeps these cookie-less endpoints out of reach of plain
+// cross-site form posts.
+func (h *APIHandler) decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "application/json" {
+		writeAPIError(w, http.StatusUnsupportedMediaType, "unsupported_media_type", "request body must be application/json")
+		return false
+	}
+
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxAPIBodySize))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeAPIError(w, http.StatusRequestEntityTooLarge, "too_large", "request body too large (max 10MB)")
+			return false
+		}
+		if errors.Is(err, io.EOF) {
+			writeAPIError(w, http.StatusBadRequest, "invalid_json", "request body is empty")
+			return false
+		}
+		writeAPIError(w, http.StatusBadRequest, "invalid_json", "invalid JSON: "+err.Error())
+		return false
+	}
+	return true
+}
+
+// decodeSkill reads a {"content": "..."} body and parses it as a SKILL.md,
+// or a {"files": [...]} body holding an Agent Skills folder. The files are
+// returned for folders only.
+func (h *APIHandler) decodeSkill(w http.ResponseWriter, r *http.Request) (*skill.Skill, []skill.File) {
+	var req struct {
+		Content string       `json:"content"`
+		Files   []skill.File `json:"files"`
+	}
+	if !h.decode(w, r, &req) {
+		return nil, nil
+	}
+
+	var sk *skill.Skill
+	var err error
+	switch {
+	case len(req.Files) > 0 && req.Content != "":
+		writeAPIError(w, http.StatusBadRequest, "invalid_request", "send either content or files, not both")
+		return nil, nil
+	case len(req.Files) > maxAPIBundleFiles:
+		writeAPIError(w, http.StatusBadRequest, "invalid_request", "too many files in skill folder")
+		return nil, nil
+	case len(req.Files) > 0:
+		sk, err = bundle.Assemble(req.Files)
+	case strings.TrimSpace(req.Content) == "":
+		writeAPIError(w, http.StatusBadRequest, "invalid_request", "content is required")
+		return nil, nil
+	default:
+		sk, err = skill.Parse(req.Content)
+	}
+	if err != nil {
+		writeAPIError(w, http.StatusUnprocessableEntity, "invalid_skill", "failed to parse skill: "+err.Error())
+		return nil, nil
+	}
+	if sk.Frontmatter.Name == "" {
+		writeAPIError(w, http.StatusUnprocessableEntity, "invalid_skill", "skill frontmatter must include a name")
+		return nil, nil
+	}
+	return sk, req.Files
+}
+
+// lookupSkill resolves the {slug} URL parameter, writing an error response
+// and returning nil if the skill cannot be served.
+func (h *APIHandler) lookupSkill(w http.ResponseWriter, r *http.Request) *skill.StoredSkill {
+	slug := chi.URLParam(r, "slug")
+	if len(slug) > MaxSlugLength || len(slug) == 0 || containsPathTraversal(slug) {
+		writeAPIError(w, http.StatusBadRequest, "invalid_request", "invalid slug")
+		return nil
+	}
+
+	stored, err := h.app.RegistryService.GetSkill(slug)
+	if err != nil {
+		h.app.Logger.Error("api get skill failed", "error", err)
+		writeAPIError(w, http.StatusInternalServerError, "internal_error", "failed to get skill")
+		return nil
+	}
+	if stored == nil {
+		writeAPIError(w, http.StatusNotFound, "not_found", "skill not found: "+slug)
+		return nil
+	}
+	return stored
+}
+
+func (h *APIHandler) supportsFormat(format string) bool {
+	for _, f := range h.app.ConverterManager.SupportedFormats() {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// pagination parses the page and per_page query parameters.
+func pagination(w http.ResponseWriter, r *http.Request) (page, perPage int, ok bool) {
+	page, perPage = 1, defaultAPIPerPage
+	if v := r.URL.Query().Get("page"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			writeAPIError(w, http.StatusBadRequest, "invalid_request", "page must be a positive integer")
+			return 0, 0, false
+		}
+		page = n
+	}
+	if v := r.URL.Query().Get("per_page"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxAPIPerPage {
+			writeAPIError(w, http.StatusBadRequest, "invalid_request", "per_page must be between 1 and 100")
+			return 0, 0, false
+		}
+		perPage = n
+	}
+	return page, perPage, true
+}
+
+func writeSkillPage(w http.ResponseWriter, skills []*skill.StoredSkill, total, page, perPage int) {
+	if skills == nil {
+		skills = []*skill.StoredSkill{}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"skills":   skills,
+		"total":    total,
+		"page":     page,
+		"per_page": perPage,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeAPIError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, map[string]APIError{
+		"error": {Code: code, Message: message},
+	})
+}
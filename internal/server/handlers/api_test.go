@@ -0,0 +1,553 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sanixdarker/skill-md/internal/app"
+	"github.com/sanixdarker/skill-md/internal/auth"
+	"github.com/sanixdarker/skill-md/internal/converter"
+	"github.com/sanixdarker/skill-md/internal/server/middleware"
+	"github.com/sanixdarker/skill-md/pkg/skill"
+)
+
+const apiSpec = `openapi: "3.0.0"
+info:
+  title: Pet Store
+  version: "1.0.0"
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      summary: List pets
+      responses:
+        "200":
+          description: OK
+`
+
+func apiRouter(application *app.App) http.Handler {
+	h := NewAPIHandler(application)
+	requireScope := func(scope string) func(http.Handler) http.Handler {
+		if !application.Config.RequireAuth {
+			return func(next http.Handler) http.Handler { return next }
+		}
+		return middleware.RequireScope(scope, APIAuthError)
+	}
+
+	r := chi.NewRouter()
+	r.Use(middleware.TokenAuth(application.TokenService, APIAuthError))
+	r.Route("/api/v1", func(r chi.Router) {
+		r.NotFound(h.NotFound)
+		r.MethodNotAllowed(h.MethodNotAllowed)
+		r.Get("/openapi.json", h.OpenAPI)
+		r.Post("/convert", h.Convert)
+		r.Post("/detect", h.Detect)
+		r.Post("/merge", h.Merge)
+		r.Get("/skills", h.ListSkills)
+		r.With(requireScope(auth.ScopePublish)).Post("/skills", h.CreateSkill)
+		r.With(requireScope(auth.ScopePublish)).Post("/skills/import", h.ImportSkill)
+		r.Get("/skills/search", h.SearchSkills)
+		r.Get("/skills/{slug}", h.GetSkill)
+		r.With(requireScope(auth.ScopePublish)).Put("/skills/{slug}", h.UpdateSkill)
+		r.With(requireScope(auth.ScopeDelete)).Delete("/skills/{slug}", h.DeleteSkill)
+		r.Get("/search", h.FederatedSearch)
+	})
+	return r
+}
+
+func apiRequest(t *testing.T, router http.Handler, method, path, secret string, body interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var req *http.Request
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("failed to marshal body: %v", err)
+		}
+		req = httptest.NewRequest(method, path, strings.NewReader(string(data)))
+		req.Header.Set("Content-Type", "application/json")
+	} else {
+		req = httptest.NewRequest(method, path, nil)
+	}
+	if secret != "" {
+		req.Header.Set("Authorization", "Bearer "+secret)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func decodeAPIError(t *testing.T, w *httptest.ResponseRecorder) APIError {
+	t.Helper()
+	var envelope struct {
+		Error APIError `json:"error"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &envelope); err != nil {
+		t.Fatalf("expected JSON error envelope, got %q", w.Body.String())
+	}
+	if envelope.Error.Code == "" || envelope.Error.Message == "" {
+		t.Fatalf("incomplete error envelope: %q", w.Body.String())
+	}
+	return envelope.Error
+}
+
+func TestAPIHandler_SkillLifecycle(t *testing.T) {
+	application := setupTestApp(t)
+	router := apiRouter(application)
+
+	w := apiRequest(t, router, http.MethodPost, "/api/v1/skills", "", map[string]string{"content": ownedSkillContent})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var created skill.StoredSkill
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if created.Slug == "" || created.Name != "Owned Skill" {
+		t.Fatalf("unexpected skill: %+v", created)
+	}
+	if loc := w.Header().Get("Location"); loc != "/api/v1/skills/"+created.Slug {
+		t.Errorf("unexpected Location %q", loc)
+	}
+
+	w = apiRequest(t, router, http.MethodGet, "/api/v1/skills/"+created.Slug, "", nil)
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"slug":"`+created.Slug+`"`) {
+		t.Fatalf("get failed: %d %s", w.Code, w.Body.String())
+	}
+
+	w = apiRequest(t, router, http.MethodGet, "/api/v1/skills/search?q=Owned", "", nil)
+	var page struct {
+		Skills []skill.StoredSkill `json:"skills"`
+		Total  int                 `json:"total"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil || page.Total != 1 {
+		t.Fatalf("expected one search hit, got %s", w.Body.String())
+	}
+
+	updated := strings.Replace(ownedSkillContent, `version: "1.0.0"`, `version: "1.1.0"`, 1)
+	w = apiRequest(t, router, http.MethodPut, "/api/v1/skills/"+created.Slug, "", map[string]string{"content": updated})
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"version":"1.1.0"`) {
+		t.Fatalf("update failed: %d %s", w.Code, w.Body.String())
+	}
+	versions, _ := application.RegistryService.ListVersions(created.ID)
+	if len(versions) != 2 {
+		t.Errorf("expected update to record a revision, got %d", len(versions))
+	}
+
+	w = apiRequest(t, router, http.MethodDelete, "/api/v1/skills/"+created.Slug, "", nil)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", w.Code)
+	}
+
+	w = apiRequest(t, router, http.MethodGet, "/api/v1/skills/"+created.Slug, "", nil)
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 after delete, got %d", w.Code)
+	}
+	if e := decodeAPIError(t, w); e.Code != "not_found" {
+		t.Errorf("expected not_found, got %q", e.Code)
+	}
+}
+
+func TestAPIHandler_ListSkills_EmptyPage(t *testing.T) {
+	application := setupTestApp(t)
+	router := apiRouter(application)
+
+	w := apiRequest(t, router, http.MethodGet, "/api/v1/skills?per_page=5", "", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), `"skills":[]`) || !strings.Contains(w.Body.String(), `"per_page":5`) {
+		t.Errorf("unexpected page: %s", w.Body.String())
+	}
+
+	w = apiRequest(t, router, http.MethodGet, "/api/v1/skills?per_page=1000", "", nil)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for oversized page, got %d", w.Code)
+	}
+	decodeAPIError(t, w)
+}
+
+func TestAPIHandler_Convert(t *testing.T) {
+	application := setupTestApp(t)
+	router := apiRouter(application)
+
+	w := apiRequest(t, router, http.MethodPost, "/api/v1/convert", "", map[string]string{
+		"content":  apiSpec,
+		"filename": "petstore.yaml",
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var result struct {
+		Format   string       `json:"format"`
+		Skill    *skill.Skill `json:"skill"`
+		Markdown string       `json:"markdown"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if result.Format != "openapi" {
+		t.Errorf("expected openapi format, got %q", result.Format)
+	}
+	if result.Skill == nil || result.Skill.Frontmatter.Name != "Pet Store" {
+		t.Errorf("unexpected skill: %+v", result.Skill)
+	}
+	if !strings.HasPrefix(result.Markdown, "---\n") {
+		t.Errorf("expected rendered SKILL.md, got %q", result.Markdown)
+	}
+}
+
+func TestAPIHandler_Convert_Errors(t *testing.T) {
+	application := setupTestApp(t)
+	router := apiRouter(application)
+
+	tests := []struct {
+		name   string
+		body   interface{}
+		status int
+		code   string
+	}{
+		{"missing content", map[string]string{}, http.StatusBadRequest, "invalid_request"},
+		{"unknown format", map[string]string{"content": "x", "format": "cobol"}, http.StatusBadRequest, "unsupported_format"},
+		{"private url", map[string]string{"url": "http://127.0.0.1/spec.yaml"}, http.StatusBadRequest, "invalid_url"},
+		{"unknown field", map[string]string{"contents": "x"}, http.StatusBadRequest, "invalid_json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := apiRequest(t, router, http.MethodPost, "/api/v1/convert", "", tt.body)
+			if w.Code != tt.status {
+				t.Fatalf("expected %d, got %d: %s", tt.status, w.Code, w.Body.String())
+			}
+			if e := decodeAPIError(t, w); e.Code != tt.code {
+				t.Errorf("expected code %q, got %q", tt.code, e.Code)
+			}
+		})
+	}
+}
+
+func TestAPIHandler_RequiresJSONBody(t *testing.T) {
+	application := setupTestApp(t)
+	router := apiRouter(application)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/skills", strings.NewReader("content=x"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnsupportedMediaType {
+		t.Fatalf("expected 415, got %d", w.Code)
+	}
+	decodeAPIError(t, w)
+}
+
+func TestAPIHandler_Detect(t *testing.T) {
+	application := setupTestApp(t)
+	router := apiRouter(application)
+
+	w := apiRequest(t, router, http.MethodPost, "/api/v1/detect", "", map[string]string{"content": apiSpec, "filename": "petstore.yaml"})
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"format":"openapi"`) {
+		t.Fatalf("unexpected detect response: %d %s", w.Code, w.Body.String())
+	}
+}
+
+func TestAPIHandler_Merge(t *testing.T) {
+	application := setupTestApp(t)
+	router := apiRouter(application)
+
+	stored, err := application.RegistryService.ImportSkill(ownedSkillContent)
+	if err != nil {
+		t.Fatalf("failed to import skill: %v", err)
+	}
+
+	w := apiRequest(t, router, http.MethodPost, "/api/v1/merge", "", map[string]interface{}{
+		"skills": []string{"---\nname: Inline\n---\n\n## Usage\n\nInline skill.\n"},
+		"refs":   []SkillRef{{ID: stored.Slug, Source: "local"}},
+		"name":   "Combined",
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), "Combined") {
+		t.Errorf("expected merged name in response: %s", w.Body.String())
+	}
+
+	w = apiRequest(t, router, http.MethodPost, "/api/v1/merge", "", map[string]interface{}{
+		"skills": []string{ownedSkillContent},
+	})
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a single skill, got %d", w.Code)
+	}
+	decodeAPIError(t, w)
+}
+
+func TestAPIHandler_Merge_Strategy(t *testing.T) {
+	application := setupTestApp(t)
+	router := apiRouter(application)
+
+	skills := []string{
+		"---\nname: First\n---\n\n## Usage\n\nShort.\n",
+		"---\nname: Second\n---\n\n## Usage\n\nA much longer usage section.\n",
+	}
+	w := apiRequest(t, router, http.MethodPost, "/api/v1/merge", "", map[string]interface{}{
+		"skills":   skills,
+		"strategy": "longer",
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Markdown  string `json:"markdown"`
+		Conflicts []struct {
+			Field    string   `json:"field"`
+			Sources  []string `json:"sources"`
+			Resolved string   `json:"resolved"`
+		} `json:"conflicts"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	if strings.Contains(resp.Markdown, "Short.") {
+		t.Errorf("expected the shorter body to be dropped: %s", resp.Markdown)
+	}
+
+	var section bool
+	for _, c := range resp.Conflicts {
+		if c.Field == "section:Usage" {
+			section = true
+			if c.Resolved != "longer: kept Second" || len(c.Sources) != 2 {
+				t.Errorf("unexpected section conflict: %+v", c)
+			}
+		}
+	}
+	if !section {
+		t.Errorf("expected a section conflict, got %+v", resp.Conflicts)
+	}
+
+	w = apiRequest(t, router, http.MethodPost, "/api/v1/merge", "", map[string]interface{}{
+		"skills":   skills,
+		"strategy": "newest",
+	})
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for an unknown strategy, got %d", w.Code)
+	}
+	decodeAPIError(t, w)
+}
+
+func TestAPIHandler_Auth(t *testing.T) {
+	application := setupTestApp(t)
+	application.Config.RequireAuth = true
+	router := apiRouter(application)
+
+	_, aliceSecret, _ := application.TokenService.CreateToken("alice", "", []string{auth.ScopePublish})
+	_, bobSecret, _ := application.TokenService.CreateToken("bob", "", []string{auth.ScopePublish, auth.ScopeDelete})
+
+	w := apiRequest(t, router, http.MethodPost, "/api/v1/skills", "", map[string]string{"content": ownedSkillContent})
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 without token, got %d", w.Code)
+	}
+	if e := decodeAPIError(t, w); e.Code != "unauthorized" {
+		t.Errorf("expected unauthorized, got %q", e.Code)
+	}
+
+	w = apiRequest(t, router, http.MethodPost, "/api/v1/skills", "smd_bogus", map[string]string{"content": ownedSkillContent})
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for an invalid token, got %d", w.Code)
+	}
+	if e := decodeAPIError(t, w); e.Code != "invalid_token" {
+		t.Errorf("expected invalid_token, got %q", e.Code)
+	}
+
+	w = apiRequest(t, router, http.MethodPost, "/api/v1/skills", aliceSecret, map[string]string{"content": ownedSkillContent})
+	if w.Code != http.StatusCreated || !strings.Contains(w.Body.String(), `"owner":"alice"`) {
+		t.Fatalf("expected alice to publish, got %d %s", w.Code, w.Body.String())
+	}
+	var created skill.StoredSkill
+	json.Unmarshal(w.Body.Bytes(), &created)
+
+	w = apiRequest(t, router, http.MethodPut, "/api/v1/skills/"+created.Slug, bobSecret, map[string]string{"content": ownedSkillContent})
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for another owner, got %d", w.Code)
+	}
+	decodeAPIError(t, w)
+
+	w = apiRequest(t, router, http.MethodDelete, "/api/v1/skills/"+created.Slug, aliceSecret, nil)
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 without delete scope, got %d", w.Code)
+	}
+	if e := decodeAPIError(t, w); e.Code != "insufficient_scope" {
+		t.Errorf("expected insufficient_scope, got %q", e.Code)
+	}
+}
+
+func TestAPIHandler_UnknownRoute(t *testing.T) {
+	application := setupTestApp(t)
+	router := apiRouter(application)
+
+	w := apiRequest(t, router, http.MethodGet, "/api/v1/nope", "", nil)
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", w.Code)
+	}
+	decodeAPIError(t, w)
+
+	w = apiRequest(t, router, http.MethodPatch, "/api/v1/skills", "", nil)
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected 405, got %d", w.Code)
+	}
+	decodeAPIError(t, w)
+}
+
+func TestAPIHandler_OpenAPI(t *testing.T) {
+	application := setupTestApp(t)
+	router := apiRouter(application)
+
+	w := apiRequest(t, router, http.MethodGet, "/api/v1/openapi.json", "", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+
+	var doc struct {
+		Paths map[string]map[string]interface{} `json:"paths"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	for path, method := range map[string]string{
+		"/convert":       "post",
+		"/detect":        "post",
+		"/merge":         "post",
+		"/skills":        "post",
+		"/skills/search": "get",
+		"/skills/{slug}": "delete",
+		"/search":        "get",
+	} {
+		if _, ok := doc.Paths[path][method]; !ok {
+			t.Errorf("openapi document is missing %s %s", method, path)
+		}
+	}
+
+	// The document should be usable by the registry's own converter
+	sk, err := (&converter.OpenAPIConverter{}).Convert(w.Body.Bytes(), &converter.Options{})
+	if err != nil {
+		t.Fatalf("openapi document failed to convert: %v", err)
+	}
+	if sk.Frontmatter.Name != "Skill MD Registry API" {
+		t.Errorf("unexpected skill name %q", sk.Frontmatter.Name)
+	}
+}
+
+func TestAPIHandler_SkillSchema(t *testing.T) {
+	h := NewAPIHandler(setupTestApp(t))
+	w := httptest.NewRecorder()
+	h.SkillSchema(w, httptest.NewRequest(http.MethodGet, "/schema/skill.json", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/schema+json" {
+		t.Errorf("unexpected content type %q", ct)
+	}
+	var schema struct {
+		ID         string                 `json:"$id"`
+		Properties map[string]interface{} `json:"properties"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &schema); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if schema.ID != "https://skill-md.dev/schema/skill.json" || schema.Properties["difficulty"] == nil {
+		t.Errorf("unexpected schema: %s", w.Body.String())
+	}
+}
+
+func TestAPIHandler_ImportSkill_Validation(t *testing.T) {
+	application := setupTestApp(t)
+	router := apiRouter(application)
+
+	tests := []struct {
+		name string
+		ref  SkillRef
+		code string
+	}{
+		{"unknown source", SkillRef{Source: "nope", ID: "a/b"}, "invalid_source"},
+		{"local source", SkillRef{Source: "local", ID: "a"}, "invalid_source"},
+		{"path traversal", SkillRef{Source: "github", ID: "../etc/passwd"}, "invalid_request"},
+		{"missing id", SkillRef{Source: "github"}, "invalid_request"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := apiRequest(t, router, http.MethodPost, "/api/v1/skills/import", "", tt.ref)
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("expected 400, got %d: %s", w.Code, w.Body.String())
+			}
+			if e := decodeAPIError(t, w); e.Code != tt.code {
+				t.Errorf("expected code %q, got %q", tt.code, e.Code)
+			}
+		})
+	}
+}
+
+func TestAPIHandler_SkillFolder(t *testing.T) {
+	application := setupTestApp(t)
+	router := apiRouter(application)
+
+	files := []skill.File{
+		{Path: "SKILL.md", Content: "---\nname: \"Folder Skill\"\nversion: \"1.0.0\"\n---\n\n## Guides\n\n- [Setup](reference/setup.md)\n"},
+		{Path: "reference/setup.md", Content: "# Setup\n\nRun it."},
+	}
+	w := apiRequest(t, router, http.MethodPost, "/api/v1/skills", "", map[string]interface{}{"files": files})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var created skill.StoredSkill
+	if err := json.Unmarshal(w.Body.Bytes(), &created); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(created.Files) != 2 || !strings.Contains(created.Content, "Run it.") {
+		t.Fatalf("unexpected skill: %+v", created)
+	}
+
+	// The zip download serves the stored folder
+	r := chi.NewRouter()
+	r.Get("/api/skill/{slug}/download", NewSkillsHandler(application).Download)
+	req := httptest.NewRequest(http.MethodGet, "/api/skill/"+created.Slug+"/download?layout=folder", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "application/zip" {
+		t.Fatalf("expected a zip, got %d %s", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	zr, err := zip.NewReader(bytes.NewReader(rec.Body.Bytes()), int64(rec.Body.Len()))
+	if err != nil {
+		t.Fatalf("invalid zip: %v", err)
+	}
+	if len(zr.File) != 2 || zr.File[1].Name != created.Slug+"/reference/setup.md" {
+		t.Errorf("unexpected archive entries: %d", len(zr.File))
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/skill/"+created.Slug+"/download?layout=tarball", nil)
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an unknown layout, got %d", rec.Code)
+	}
+
+	for name, body := range map[string]interface{}{
+		"no SKILL.md":   map[string]interface{}{"files": []skill.File{{Path: "notes.md", Content: "# Notes"}}},
+		"unsafe path":   map[string]interface{}{"files": append(files, skill.File{Path: "../x.md"})},
+		"content+files": map[string]interface{}{"files": files, "content": ownedSkillContent},
+	} {
+		w := apiRequest(t, router, http.MethodPost, "/api/v1/skills", "", body)
+		if w.Code != http.StatusUnprocessableEntity && w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected a client error, got %d", name, w.Code)
+		}
+	}
+}
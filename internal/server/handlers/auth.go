@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/sanixdarker/skill-md/internal/app"
+	"github.com/sanixdarker/skill-md/internal/server/middleware"
+	"github.com/sanixdarker/skill-md/pkg/skill"
+)
+
+// tokenOwner returns the owner of the request's API token, or "" if the
+// request is anonymous.
+func tokenOwner(r *http.Request) string {
+	if token := middleware.GetAPIToken(r); token != nil {
+		return token.Owner
+	}
+	return ""
+}
+
+// canModifySkill reports whether the request may update or delete stored.
+// When auth is enforced only the owner's tokens and admin tokens qualify.
+func canModifySkill(application *app.App, r *http.Request, stored *skill.StoredSkill) bool {
+	if !application.Config.RequireAuth {
+		return true
+	}
+	token := middleware.GetAPIToken(r)
+	if token == nil {
+		return false
+	}
+	return token.IsAdmin() || (stored.Owner != "" && stored.Owner == token.Owner)
+}
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sanixdarker/skill-md/internal/app"
+	"github.com/sanixdarker/skill-md/internal/auth"
+	"github.com/sanixdarker/skill-md/internal/server/middleware"
+)
+
+const ownedSkillContent = `---
+name: "Owned Skill"
+version: "1.0.0"
+---
+
+## Overview
+
+Owned by a token holder.
+`
+
+func authRouter(application *app.App) http.Handler {
+	handler := NewSkillsHandler(application)
+	r := chi.NewRouter()
+	r.Use(middleware.TokenAuth(application.TokenService, nil))
+	r.With(middleware.RequireScope(auth.ScopeDelete, nil)).Delete("/api/skill/{id}", handler.Delete)
+	return r
+}
+
+func deleteSkill(t *testing.T, router http.Handler, id, secret string) int {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodDelete, "/api/skill/"+id, nil)
+	if secret != "" {
+		req.Header.Set("Authorization", "Bearer "+secret)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w.Code
+}
+
+func TestAuth_DeleteRequiresOwner(t *testing.T) {
+	application := setupTestApp(t)
+	application.Config.RequireAuth = true
+	router := authRouter(application)
+
+	stored, err := application.RegistryService.ImportSkillAs(ownedSkillContent, "alice")
+	if err != nil {
+		t.Fatalf("failed to import skill: %v", err)
+	}
+
+	_, bobSecret, _ := application.TokenService.CreateToken("bob", "", []string{auth.ScopeDelete})
+	_, aliceRead, _ := application.TokenService.CreateToken("alice", "", []string{auth.ScopeRead})
+	_, aliceSecret, _ := application.TokenService.CreateToken("alice", "", []string{auth.ScopeDelete})
+
+	if code := deleteSkill(t, router, stored.ID, ""); code != http.StatusUnauthorized {
+		t.Errorf("expected 401 without token, got %d", code)
+	}
+	if code := deleteSkill(t, router, stored.ID, "smd_bogus"); code != http.StatusUnauthorized {
+		t.Errorf("expected 401 for invalid token, got %d", code)
+	}
+	if code := deleteSkill(t, router, stored.ID, aliceRead); code != http.StatusForbidden {
+		t.Errorf("expected 403 without delete scope, got %d", code)
+	}
+	if code := deleteSkill(t, router, stored.ID, bobSecret); code != http.StatusForbidden {
+		t.Errorf("expected 403 for another owner, got %d", code)
+	}
+	if code := deleteSkill(t, router, stored.ID, aliceSecret); code != http.StatusSeeOther {
+		t.Errorf("expected owner to delete the skill, got %d", code)
+	}
+}
+
+func TestAuth_AdminDeletesUnownedSkill(t *testing.T) {
+	application := setupTestApp(t)
+	application.Config.RequireAuth = true
+	router := authRouter(application)
+
+	stored, err := application.RegistryService.ImportSkill(ownedSkillContent)
+	if err != nil {
+		t.Fatalf("failed to import skill: %v", err)
+	}
+	_, adminSecret, _ := application.TokenService.CreateToken("ops", "", []string{auth.ScopeAdmin})
+
+	if code := deleteSkill(t, router, stored.ID, adminSecret); code != http.StatusSeeOther {
+		t.Errorf("expected admin to delete the skill, got %d", code)
+	}
+}
+
+// importExternalRouter wires the browser import form the way the server does:
+// CSRF check, then the API token from the form, then the scope check.
+func importExternalRouter(application *app.App) http.Handler {
+	handler := NewSkillsHandler(application)
+	csrf := middleware.NewCSRFProtection(middleware.DefaultCSRFConfig())
+	r := chi.NewRouter()
+	r.Use(middleware.TokenAuth(application.TokenService, nil))
+	r.With(csrf.Protect).Get("/", func(w http.ResponseWriter, r *http.Request) {})
+	r.With(csrf.Protect, middleware.FormTokenAuth(application.TokenService, nil), middleware.RequireScope(auth.ScopePublish, nil)).
+		Post("/api/skills/import-external", handler.ImportExternal)
+	return r
+}
+
+func TestAuth_ImportExternalForm(t *testing.T) {
+	application := setupTestApp(t)
+	application.Config.RequireAuth = true
+	router := importExternalRouter(application)
+
+	source, err := application.RegistryService.ImportSkill(ownedSkillContent)
+	if err != nil {
+		t.Fatalf("failed to import skill: %v", err)
+	}
+	_, aliceSecret, _ := application.TokenService.CreateToken("alice", "", []string{auth.ScopePublish})
+
+	// Load a page like a browser to get the CSRF cookie
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	cookies := w.Result().Cookies()
+	csrfToken := w.Header().Get("X-CSRF-Token")
+
+	post := func(form url.Values, withCSRF bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/skills/import-external", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		if withCSRF {
+			for _, c := range cookies {
+				req.AddCookie(c)
+			}
+			req.Header.Set("X-CSRF-Token", csrfToken)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	form := url.Values{"source": {"local"}, "id": {source.Slug}}
+
+	if w := post(form, true); w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 for the form without a token, got %d", w.Code)
+	}
+
+	form.Set(middleware.FormTokenField, aliceSecret)
+	if w := post(form, false); w.Code != http.StatusForbidden {
+		t.Errorf("expected 403 for a form token without a CSRF token, got %d", w.Code)
+	}
+
+	w = post(form, true)
+	if w.Code != http.StatusSeeOther {
+		t.Fatalf("expected the form with a token to import the skill, got %d: %s", w.Code, w.Body.String())
+	}
+	slug := strings.TrimPrefix(w.Header().Get("Location"), "/skill/")
+	imported, err := application.RegistryService.GetSkill(slug)
+	if err != nil || imported == nil {
+		t.Fatalf("expected imported skill %q: %v", slug, err)
+	}
+	if imported.Owner != "alice" {
+		t.Errorf("expected the form token's owner to own the import, got %q", imported.Owner)
+	}
+}
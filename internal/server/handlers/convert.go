@@ -37,7 +37,7 @@ func (h *ConvertHandler) Index(w http.ResponseWriter, r *http.Request) {
 		"Formats": h.app.ConverterManager.SupportedFormats(),
 	}
 
-	if err := web.RenderPage(w, "convert.html", data); err != nil {
+	if err := renderPage(w, r, "convert.html", data); err != nil {
 		h.app.Logger.Error("failed to render convert page", "error", err)
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 	}
@@ -5,7 +5,6 @@ import (
 	"net/http"
 
 	"github.com/sanixdarker/skill-md/internal/app"
-	"github.com/sanixdarker/skill-md/web"
 )
 
 // HomeHandler handles home page requests.
@@ -31,7 +30,7 @@ func (h *HomeHandler) Index(w http.ResponseWriter, r *http.Request) {
 		"FormatCount": formatCount,
 	}
 
-	if err := web.RenderPage(w, "home.html", data); err != nil {
+	if err := renderPage(w, r, "home.html", data); err != nil {
 		h.app.Logger.Error("failed to render home page", "error", err)
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 	}
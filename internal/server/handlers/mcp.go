@@ -0,0 +1,398 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sanixdarker/skill-md/internal/app"
+	"github.com/sanixdarker/skill-md/internal/converter"
+	"github.com/sanixdarker/skill-md/internal/mcp"
+	"github.com/sanixdarker/skill-md/internal/merger"
+	"github.com/sanixdarker/skill-md/internal/sources"
+	"github.com/sanixdarker/skill-md/pkg/skill"
+)
+
+// MCP limits for the registry endpoint
+const (
+	mcpResourcePageSize = 100
+	mcpSearchPageSize   = 10
+	mcpMaxMergeSkills   = 10
+	mcpSourceTimeout    = 30 * time.Second
+)
+
+// skillURIPrefix prefixes every registry resource URI: skill://<source>/<id>.
+const skillURIPrefix = "skill://"
+
+// MCPHandler serves the registry as a Model Context Protocol endpoint.
+type MCPHandler struct {
+	app    *app.App
+	server *mcp.Server
+}
+
+// NewMCPHandler creates a new MCPHandler.
+func NewMCPHandler(application *app.App) *MCPHandler {
+	provider := &registryMCPProvider{app: application}
+	return &MCPHandler{
+		app:    application,
+		server: mcp.NewServer("skillmd-registry", "1.0.0", provider),
+	}
+}
+
+// ServeHTTP handles MCP messages over the streamable HTTP transport.
+func (h *MCPHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.server.ServeHTTP(w, r)
+}
+
+// registryMCPProvider exposes registry skills as resources and the
+// search, convert and merge features as tools.
+type registryMCPProvider struct {
+	app *app.App
+}
+
+// ListTools returns the registry tools.
+func (p *registryMCPProvider) ListTools(ctx context.Context) ([]mcp.Tool, error) {
+	return []mcp.Tool{
+		{
+			Name:        "search_skills",
+			Description: "Search the local registry and external skill sources. Returns skill URIs that can be read as resources.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"query":  map[string]interface{}{"type": "string", "description": "Search query"},
+					"source": map[string]interface{}{"type": "string", "description": "Limit the search to one source", "enum": sortedSources()},
+					"page":   map[string]interface{}{"type": "integer", "description": "Result page, starting at 1"},
+				},
+				"required": []string{"query"},
+			},
+		},
+		{
+			Name:        "get_skill",
+			Description: "Fetch the SKILL.md content of a skill by URI (skill://<source>/<id>).",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"uri": map[string]interface{}{"type": "string", "description": "Skill URI returned by search_skills"},
+				},
+				"required": []string{"uri"},
+			},
+		},
+		{
+			Name:        "convert_spec",
+			Description: "Convert an API specification or document into SKILL.md.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"content":  map[string]interface{}{"type": "string", "description": "Specification content"},
+					"format":   map[string]interface{}{"type": "string", "description": "Source format; detected from content when omitted"},
+					"filename": map[string]interface{}{"type": "string", "description": "Original filename, used as a detection hint"},
+					"name":     map[string]interface{}{"type": "string", "description": "Skill name override"},
+				},
+				"required": []string{"content"},
+			},
+		},
+		{
+			Name:        "merge_skills",
+			Description: "Merge several skills into a single SKILL.md.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"uris":        map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}, "description": "Skill URIs to merge"},
+					"contents":    map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}, "description": "Raw SKILL.md documents to merge"},
+					"name":        map[string]interface{}{"type": "string", "description": "Name of the merged skill"},
+					"description": map[string]interface{}{"type": "string", "description": "Description of the merged skill"},
+					"deduplicate": map[string]interface{}{"type": "boolean", "description": "Remove near-duplicate sections (default false)"},
+				},
+			},
+		},
+	}, nil
+}
+
+// CallTool runs a registry tool.
+func (p *registryMCPProvider) CallTool(ctx context.Context, name string, args map[string]interface{}) (*mcp.ToolResult, error) {
+	switch name {
+	case "search_skills":
+		return p.searchSkills(ctx, args), nil
+	case "get_skill":
+		uri, _ := args["uri"].(string)
+		content, err := p.fetchSkill(ctx, uri)
+		if err != nil {
+			return mcp.TextResult(err.Error(), true), nil
+		}
+		return mcp.TextResult(content, false), nil
+	case "convert_spec":
+		return p.convertSpec(args), nil
+	case "merge_skills":
+		return p.mergeSkills(ctx, args), nil
+	default:
+		return nil, &mcp.Error{Code: mcp.CodeInvalidParams, Message: fmt.Sprintf("unknown tool: %s", name)}
+	}
+}
+
+func (p *registryMCPProvider) searchSkills(ctx context.Context, args map[string]interface{}) *mcp.ToolResult {
+	query, _ := args["query"].(string)
+	source, _ := args["source"].(string)
+	page := 1
+	if v, ok := args["page"].(float64); ok && v >= 1 {
+		page = int(v)
+	}
+
+	if len(query) > MaxQueryLength {
+		return mcp.TextResult("query too long", true)
+	}
+	if source != "" && !validSources[source] {
+		return mcp.TextResult(fmt.Sprintf("invalid source: %s", source), true)
+	}
+
+	type hit struct {
+		URI         string `json:"uri"`
+		Name        string `json:"name"`
+		Description string `json:"description,omitempty"`
+		Source      string `json:"source"`
+		SourceURL   string `json:"source_url,omitempty"`
+	}
+	var hits []hit
+	total := 0
+
+	if source == string(sources.SourceTypeLocal) {
+		stored, count, err := p.app.RegistryService.SearchSkills(query, page, mcpSearchPageSize)
+		if err != nil {
+			p.app.Logger.Error("mcp registry search failed", "error", err)
+			return mcp.TextResult("search failed", true)
+		}
+		for _, sk := range stored {
+			hits = append(hits, hit{URI: localSkillURI(sk.Slug), Name: sk.Name, Description: sk.Description, Source: source})
+		}
+		total = count
+	} else {
+		ctx, cancel := context.WithTimeout(ctx, mcpSourceTimeout)
+		defer cancel()
+
+		opts := sources.SearchOptions{Query: query, Page: page, PerPage: mcpSearchPageSize}
+		var result *sources.FederatedResult
+		var err error
+		if source == "" {
+			result, err = p.app.FederatedSource.Search(ctx, opts)
+		} else {
+			result, err = p.app.FederatedSource.SearchSources(ctx, opts, []sources.SourceType{sources.SourceType(source)})
+		}
+		if err != nil {
+			p.app.Logger.Error("mcp federated search failed", "error", err)
+			return mcp.TextResult("search failed", true)
+		}
+		for _, sk := range result.Skills {
+			uri := externalSkillURI(sk.Source, sk.ID)
+			if sk.Source == sources.SourceTypeLocal {
+				uri = localSkillURI(sk.Slug)
+			}
+			hits = append(hits, hit{URI: uri, Name: sk.Name, Description: sk.Description, Source: string(sk.Source), SourceURL: sk.SourceURL})
+		}
+		total = result.Total
+	}
+
+	if hits == nil {
+		hits = []hit{}
+	}
+	data, err := json.MarshalIndent(map[string]interface{}{
+		"skills": hits,
+		"total":  total,
+		"page":   page,
+	}, "", "  ")
+	if err != nil {
+		return mcp.TextResult("failed to encode results", true)
+	}
+	return mcp.TextResult(string(data), false)
+}
+
+func (p *registryMCPProvider) convertSpec(args map[string]interface{}) *mcp.ToolResult {
+	content, _ := args["content"].(string)
+	format, _ := args["format"].(string)
+	filename, _ := args["filename"].(string)
+	name, _ := args["name"].(string)
+
+	if content == "" {
+		return mcp.TextResult("content is required", true)
+	}
+	if len(content) > maxConvertFileSize {
+		return mcp.TextResult("content too large (max 5MB)", true)
+	}
+	if filename == "" {
+		filename = "input.txt"
+	}
+	if format == "" || format == "auto" {
+		format = p.app.ConverterManager.DetectFormat(filename, []byte(content))
+	}
+	// Fetching arbitrary URLs on behalf of remote agents is not allowed here
+	if strings.EqualFold(format, "url") {
+		return mcp.TextResult("the url format is not available over MCP; pass the document content instead", true)
+	}
+
+	result, err := p.app.ConverterManager.Convert(format, []byte(content), &converter.Options{
+		Name:       name,
+		SourcePath: filename,
+	})
+	if err != nil {
+		return mcp.TextResult(fmt.Sprintf("conversion failed: %v", err), true)
+	}
+	return mcp.TextResult(skill.Render(result), false)
+}
+
+func (p *registryMCPProvider) mergeSkills(ctx context.Context, args map[string]interface{}) *mcp.ToolResult {
+	uris := stringList(args["uris"])
+	contents := stringList(args["contents"])
+	if len(uris)+len(contents) < 2 {
+		return mcp.TextResult("at least 2 skills are required to merge", true)
+	}
+	if len(uris)+len(contents) > mcpMaxMergeSkills {
+		return mcp.TextResult(fmt.Sprintf("at most %d skills can be merged at once", mcpMaxMergeSkills), true)
+	}
+
+	for _, uri := range uris {
+		content, err := p.fetchSkill(ctx, uri)
+		if err != nil {
+			return mcp.TextResult(err.Error(), true)
+		}
+		contents = append(contents, content)
+	}
+
+	var skills []*skill.Skill
+	for i, content := range contents {
+		s, err := skill.Parse(content)
+		if err != nil {
+			return mcp.TextResult(fmt.Sprintf("failed to parse skill %d: %v", i+1, err), true)
+		}
+		skills = append(skills, s)
+	}
+
+	// Deduplication is off unless asked for, as in skillmd merge and
+	// /api/v1/merge
+	opts := &merger.Options{}
+	opts.Name, _ = args["name"].(string)
+	opts.Description, _ = args["description"].(string)
+	opts.Deduplicate, _ = args["deduplicate"].(bool)
+
+	merged, err := p.app.Merger.Merge(skills, opts)
+	if err != nil {
+		return mcp.TextResult(fmt.Sprintf("merge failed: %v", err), true)
+	}
+	return mcp.TextResult(skill.Render(merged), false)
+}
+
+// ListResources returns the skills stored in the local registry. External
+// skills are not enumerated but can be read by URI after a search.
+func (p *registryMCPProvider) ListResources(ctx context.Context) ([]mcp.Resource, error) {
+	stored, _, err := p.app.RegistryService.ListSkills(1, mcpResourcePageSize)
+	if err != nil {
+		p.app.Logger.Error("mcp failed to list skills", "error", err)
+		return nil, &mcp.Error{Code: mcp.CodeInternalError, Message: "failed to list skills"}
+	}
+
+	resources := make([]mcp.Resource, 0, len(stored))
+	for _, sk := range stored {
+		resources = append(resources, mcp.Resource{
+			URI:         localSkillURI(sk.Slug),
+			Name:        sk.Name,
+			Description: sk.Description,
+			MimeType:    "text/markdown",
+		})
+	}
+	return resources, nil
+}
+
+// ReadResource returns the SKILL.md content for a skill URI.
+func (p *registryMCPProvider) ReadResource(ctx context.Context, uri string) (*mcp.ResourceContents, error) {
+	content, err := p.fetchSkill(ctx, uri)
+	if err != nil {
+		return nil, &mcp.Error{Code: mcp.CodeInvalidParams, Message: err.Error()}
+	}
+	return &mcp.ResourceContents{URI: uri, MimeType: "text/markdown", Text: content}, nil
+}
+
+// fetchSkill resolves a skill://<source>/<id> URI to SKILL.md content.
+func (p *registryMCPProvider) fetchSkill(ctx context.Context, uri string) (string, error) {
+	source, id, ok := parseSkillURI(uri)
+	if !ok {
+		return "", fmt.Errorf("invalid skill URI: %s", uri)
+	}
+
+	if source == sources.SourceTypeLocal {
+		stored, err := p.app.RegistryService.GetSkill(id)
+		if err != nil {
+			p.app.Logger.Error("mcp failed to get skill", "error", err)
+			return "", fmt.Errorf("failed to load skill: %s", uri)
+		}
+		if stored == nil {
+			return "", fmt.Errorf("skill not found: %s", uri)
+		}
+		return stored.Content, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, mcpSourceTimeout)
+	defer cancel()
+
+	external, err := p.app.FederatedSource.GetSkill(ctx, source, id)
+	if err != nil {
+		p.app.Logger.Error("mcp failed to get external skill", "source", source, "id", id, "error", err)
+		return "", fmt.Errorf("failed to load skill: %s", uri)
+	}
+	if external == nil {
+		return "", fmt.Errorf("skill not found: %s", uri)
+	}
+	if external.Content != "" {
+		return external.Content, nil
+	}
+
+	content, err := p.app.FederatedSource.GetContent(ctx, external)
+	if err != nil {
+		p.app.Logger.Error("mcp failed to get external content", "source", source, "id", id, "error", err)
+		return "", fmt.Errorf("failed to load skill content: %s", uri)
+	}
+	return content, nil
+}
+
+// parseSkillURI splits skill://<source>/<id>, validating both parts.
+func parseSkillURI(uri string) (sources.SourceType, string, bool) {
+	rest, ok := strings.CutPrefix(uri, skillURIPrefix)
+	if !ok {
+		return "", "", false
+	}
+	source, id, ok := strings.Cut(rest, "/")
+	if !ok || !validSources[source] || id == "" || len(id) > 500 || containsPathTraversal(id) {
+		return "", "", false
+	}
+	return sources.SourceType(source), id, true
+}
+
+func localSkillURI(slug string) string {
+	return externalSkillURI(sources.SourceTypeLocal, slug)
+}
+
+func externalSkillURI(source sources.SourceType, id string) string {
+	return skillURIPrefix + string(source) + "/" + id
+}
+
+// sortedSources returns the whitelisted source names in a stable order.
+func sortedSources() []string {
+	names := make([]string, 0, len(validSources))
+	for _, st := range sources.DefaultEnabledSources() {
+		if validSources[string(st)] {
+			names = append(names, string(st))
+		}
+	}
+	return names
+}
+
+// stringList extracts the strings from a decoded JSON array.
+func stringList(v interface{}) []string {
+	items, _ := v.([]interface{})
+	var out []string
+	for _, item := range items {
+		if s, ok := item.(string); ok && s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
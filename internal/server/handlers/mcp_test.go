@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sanixdarker/skill-md/pkg/skill"
+)
+
+func mcpCall(t *testing.T, handler *MCPHandler, method string, params interface{}) map[string]interface{} {
+	t.Helper()
+	data, _ := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "id": 1, "method": method, "params": params})
+
+	req := httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(string(data)))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	return resp
+}
+
+func mcpToolText(t *testing.T, resp map[string]interface{}) (string, bool) {
+	t.Helper()
+	result, ok := resp["result"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected result, got %v", resp)
+	}
+	text := result["content"].([]interface{})[0].(map[string]interface{})["text"].(string)
+	return text, result["isError"] == true
+}
+
+func TestMCPHandler_Resources(t *testing.T) {
+	application := setupTestApp(t)
+	stored, err := application.RegistryService.CreateSkill(skill.NewSkill("Weather API", "Forecasts"))
+	if err != nil {
+		t.Fatalf("failed to create skill: %v", err)
+	}
+	handler := NewMCPHandler(application)
+
+	resp := mcpCall(t, handler, "resources/list", map[string]interface{}{})
+	resources := resp["result"].(map[string]interface{})["resources"].([]interface{})
+	if len(resources) != 1 {
+		t.Fatalf("expected 1 resource, got %d", len(resources))
+	}
+	uri := resources[0].(map[string]interface{})["uri"].(string)
+	if uri != "skill://local/"+stored.Slug {
+		t.Errorf("unexpected resource URI %q", uri)
+	}
+
+	resp = mcpCall(t, handler, "resources/read", map[string]interface{}{"uri": uri})
+	contents := resp["result"].(map[string]interface{})["contents"].([]interface{})
+	if !strings.Contains(contents[0].(map[string]interface{})["text"].(string), "Weather API") {
+		t.Errorf("expected skill content, got %v", contents)
+	}
+
+	resp = mcpCall(t, handler, "resources/read", map[string]interface{}{"uri": "skill://local/../etc/passwd"})
+	if _, ok := resp["error"]; !ok {
+		t.Errorf("expected error for path traversal URI, got %v", resp)
+	}
+}
+
+func TestMCPHandler_SearchLocal(t *testing.T) {
+	application := setupTestApp(t)
+	if _, err := application.RegistryService.CreateSkill(skill.NewSkill("Weather API", "Forecasts")); err != nil {
+		t.Fatalf("failed to create skill: %v", err)
+	}
+	handler := NewMCPHandler(application)
+
+	resp := mcpCall(t, handler, "tools/call", map[string]interface{}{
+		"name":      "search_skills",
+		"arguments": map[string]interface{}{"query": "Weather", "source": "local"},
+	})
+	text, isError := mcpToolText(t, resp)
+	if isError {
+		t.Fatalf("unexpected tool error: %s", text)
+	}
+	if !strings.Contains(text, "skill://local/") {
+		t.Errorf("expected local skill URI in results, got %s", text)
+	}
+
+	resp = mcpCall(t, handler, "tools/call", map[string]interface{}{
+		"name":      "search_skills",
+		"arguments": map[string]interface{}{"query": "x", "source": "nowhere"},
+	})
+	if _, isError := mcpToolText(t, resp); !isError {
+		t.Error("expected tool error for invalid source")
+	}
+}
+
+func TestMCPHandler_ConvertAndMerge(t *testing.T) {
+	application := setupTestApp(t)
+	handler := NewMCPHandler(application)
+
+	resp := mcpCall(t, handler, "tools/call", map[string]interface{}{
+		"name":      "convert_spec",
+		"arguments": map[string]interface{}{"content": "type Query { hello: String }", "format": "graphql", "name": "Hello"},
+	})
+	text, isError := mcpToolText(t, resp)
+	if isError || !strings.Contains(text, `name: "Hello"`) {
+		t.Errorf("expected converted skill, got %s", text)
+	}
+
+	resp = mcpCall(t, handler, "tools/call", map[string]interface{}{
+		"name":      "convert_spec",
+		"arguments": map[string]interface{}{"content": "https://example.com", "format": "url"},
+	})
+	if _, isError := mcpToolText(t, resp); !isError {
+		t.Error("expected url format to be refused")
+	}
+
+	first := skill.Render(skill.NewSkill("One", "First"))
+	second := skill.Render(skill.NewSkill("Two", "Second"))
+	resp = mcpCall(t, handler, "tools/call", map[string]interface{}{
+		"name":      "merge_skills",
+		"arguments": map[string]interface{}{"contents": []string{first, second}, "name": "Both"},
+	})
+	text, isError = mcpToolText(t, resp)
+	if isError || !strings.Contains(text, `name: "Both"`) {
+		t.Errorf("expected merged skill, got %s", text)
+	}
+
+	// Like skillmd merge and /api/v1/merge, near-duplicates are kept unless
+	// deduplication is asked for
+	similar := []string{
+		"---\nname: One\n---\n\n## Setup\n\nInstall the client and export the API token before the first call.\n",
+		"---\nname: Two\n---\n\n## Install\n\nInstall the client and export the API token before the first call!\n",
+	}
+	for _, dedupe := range []bool{false, true} {
+		args := map[string]interface{}{"contents": similar}
+		if dedupe {
+			args["deduplicate"] = true
+		}
+		resp = mcpCall(t, handler, "tools/call", map[string]interface{}{"name": "merge_skills", "arguments": args})
+		text, _ = mcpToolText(t, resp)
+		if kept := strings.Contains(text, "## Setup") && strings.Contains(text, "## Install"); kept == dedupe {
+			t.Errorf("deduplicate=%v: unexpected sections in %s", dedupe, text)
+		}
+	}
+}
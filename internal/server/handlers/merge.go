@@ -45,7 +45,7 @@ func (h *MergeHandler) Index(w http.ResponseWriter, r *http.Request) {
 		"Title": "Merge - Skill MD",
 	}
 
-	if err := web.RenderPage(w, "merge.html", data); err != nil {
+	if err := renderPage(w, r, "merge.html", data); err != nil {
 		h.app.Logger.Error("failed to render merge page", "error", err)
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 	}
@@ -142,11 +142,21 @@ func (h *MergeHandler) Merge(w http.ResponseWriter, r *http.Request) {
 	// Get options
 	name := r.FormValue("name")
 	dedupe := r.FormValue("dedupe") == "true" || r.FormValue("dedupe") == "on"
+	strategy := merger.Combine
+	if value := r.FormValue("strategy"); value != "" {
+		parsed, err := merger.ParseStrategy(value)
+		if err != nil {
+			h.renderError(w, r, "Unknown conflict strategy. Use first, last, longer or combine.")
+			return
+		}
+		strategy = parsed
+	}
 
 	// Merge
 	result, err := h.app.Merger.Merge(skills, &merger.Options{
 		Name:        name,
 		Deduplicate: dedupe,
+		Resolver:    merger.NewConflictResolver(strategy),
 	})
 	if err != nil {
 		h.app.Logger.Error("merge failed", "error", err)
@@ -0,0 +1,18 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/sanixdarker/skill-md/internal/server/middleware"
+	"github.com/sanixdarker/skill-md/web"
+)
+
+// renderPage renders a full page, passing the CSRF token to the layout so
+// HTMX requests can send it back.
+func renderPage(w http.ResponseWriter, r *http.Request, name string, data map[string]interface{}) error {
+	if data == nil {
+		data = make(map[string]interface{})
+	}
+	data["CSRFToken"] = middleware.CSRFToken(r)
+	return web.RenderPage(w, name, data)
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"bytes"
 	"context"
 	"html"
 	"io"
@@ -12,8 +13,10 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/sanixdarker/skill-md/internal/app"
+	"github.com/sanixdarker/skill-md/internal/bundle"
 	"github.com/sanixdarker/skill-md/internal/server/middleware"
 	"github.com/sanixdarker/skill-md/internal/sources"
+	"github.com/sanixdarker/skill-md/pkg/skill"
 	"github.com/sanixdarker/skill-md/web"
 )
 
@@ -57,12 +60,13 @@ func containsPathTraversal(s string) bool {
 
 // SkillsHandler handles skill registry requests.
 type SkillsHandler struct {
-	app *app.App
+	app       *app.App
+	exporters *skill.ExportManager
 }
 
 // NewSkillsHandler creates a new SkillsHandler.
 func NewSkillsHandler(application *app.App) *SkillsHandler {
-	return &SkillsHandler{app: application}
+	return &SkillsHandler{app: application, exporters: skill.NewExportManager()}
 }
 
 // Browse renders the browse page.
@@ -157,7 +161,7 @@ func (h *SkillsHandler) Browse(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		}
 	} else {
-		if err := web.RenderPage(w, "browse.html", data); err != nil {
+		if err := renderPage(w, r, "browse.html", data); err != nil {
 			h.app.Logger.Error("failed to render browse page", "error", err)
 			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		}
@@ -181,11 +185,12 @@ func (h *SkillsHandler) View(w http.ResponseWriter, r *http.Request) {
 	}
 
 	data := map[string]interface{}{
-		"Title": skill.Name + " - Skill MD",
-		"Skill": skill,
+		"Title":         skill.Name + " - Skill MD",
+		"Skill":         skill,
+		"ExportFormats": h.exporters.SupportedFormats(),
 	}
 
-	if err := web.RenderPage(w, "skill.html", data); err != nil {
+	if err := renderPage(w, r, "skill.html", data); err != nil {
 		h.app.Logger.Error("failed to render skill page", "error", err)
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 	}
@@ -232,7 +237,7 @@ func (h *SkillsHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	stored, err := h.app.RegistryService.ImportSkill(content)
+	stored, err := h.app.RegistryService.ImportSkillAs(content, tokenOwner(r))
 	if err != nil {
 		h.app.Logger.Error("failed to create skill", "error", err)
 		http.Error(w, "Failed to create skill. Please check the skill format.", http.StatusBadRequest)
@@ -393,7 +398,23 @@ func (h *SkillsHandler) Get(w http.ResponseWriter, r *http.Request) {
 func (h *SkillsHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 
-	if err := h.app.RegistryService.DeleteSkill(id); err != nil {
+	stored, err := h.app.RegistryService.GetSkill(id)
+	if err != nil {
+		h.app.Logger.Error("failed to get skill", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	if stored == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	if !canModifySkill(h.app, r, stored) {
+		http.Error(w, "Only the skill owner or an admin can delete this skill", http.StatusForbidden)
+		return
+	}
+
+	if err := h.app.RegistryService.DeleteSkill(stored.ID); err != nil {
 		h.app.Logger.Error("failed to delete skill", "error", err)
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
@@ -407,7 +428,9 @@ func (h *SkillsHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// Download returns the skill content as a downloadable file.
+// Download returns the skill content as a downloadable file. With
+// ?layout=folder the skill is sent as a zip of its Agent Skills folder, and
+// ?format= exports it to another format, e.g. agents or llms.
 func (h *SkillsHandler) Download(w http.ResponseWriter, r *http.Request) {
 	slug := chi.URLParam(r, "slug")
 
@@ -423,14 +446,35 @@ func (h *SkillsHandler) Download(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	skill, err := h.app.RegistryService.GetSkill(slug)
+	format := r.URL.Query().Get("format")
+	var exporter skill.Exporter
+	if format != "" {
+		var ok bool
+		if exporter, ok = h.exporters.Lookup(format); !ok {
+			http.Error(w, "Invalid format", http.StatusBadRequest)
+			return
+		}
+	}
+
+	// Globs scope a Cursor rule, given repeated or comma-separated like the
+	// --globs flag of skillmd export
+	var globs []string
+	for _, value := range r.URL.Query()["globs"] {
+		for _, g := range strings.Split(value, ",") {
+			if g = strings.TrimSpace(g); g != "" {
+				globs = append(globs, g)
+			}
+		}
+	}
+
+	stored, err := h.app.RegistryService.GetSkill(slug)
 	if err != nil {
 		h.app.Logger.Error("failed to get skill", "error", err)
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
 	}
 
-	if skill == nil {
+	if stored == nil {
 		http.NotFound(w, r)
 		return
 	}
@@ -438,9 +482,56 @@ func (h *SkillsHandler) Download(w http.ResponseWriter, r *http.Request) {
 	// Sanitize filename to prevent header injection
 	safeFilename := middleware.SanitizeFilename(slug)
 
+	switch r.URL.Query().Get("layout") {
+	case "", "file":
+	case "folder":
+		if exporter != nil && exporter.Name() != "skill" {
+			http.Error(w, "A folder can only be downloaded as SKILL.md", http.StatusBadRequest)
+			return
+		}
+		files, err := h.app.RegistryService.Files(stored)
+		if err != nil {
+			h.app.Logger.Error("failed to build skill folder", "error", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		var buf bytes.Buffer
+		if err := bundle.WriteZip(&buf, safeFilename, files); err != nil {
+			h.app.Logger.Error("failed to zip skill folder", "error", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/zip")
+		w.Header().Set("Content-Disposition", "attachment; filename=\""+safeFilename+".zip\"")
+		w.Write(buf.Bytes())
+		return
+	default:
+		http.Error(w, "Invalid layout", http.StatusBadRequest)
+		return
+	}
+
+	if exporter != nil && exporter.Name() != "skill" {
+		parsed, err := skill.Parse(stored.Content)
+		if err != nil {
+			h.app.Logger.Error("failed to parse skill", "error", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		out, err := exporter.Export(parsed, &skill.ExportOptions{Globs: globs})
+		if err != nil {
+			h.app.Logger.Error("failed to export skill", "format", format, "error", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", exporter.ContentType())
+		w.Header().Set("Content-Disposition", "attachment; filename=\""+safeFilename+exporter.Extension()+"\"")
+		w.Write(out)
+		return
+	}
+
 	w.Header().Set("Content-Type", "text/markdown")
 	w.Header().Set("Content-Disposition", "attachment; filename=\""+safeFilename+".md\"")
-	w.Write([]byte(skill.Content))
+	w.Write([]byte(stored.Content))
 }
 
 // ViewExternal renders an external skill page.
@@ -490,11 +581,12 @@ func (h *SkillsHandler) ViewExternal(w http.ResponseWriter, r *http.Request) {
 	}
 
 	data := map[string]interface{}{
-		"Title": skill.Name + " - Skill MD",
-		"Skill": skill,
+		"Title":       skill.Name + " - Skill MD",
+		"Skill":       skill,
+		"RequireAuth": h.app.Config.RequireAuth,
 	}
 
-	if err := web.RenderPage(w, "skill-external.html", data); err != nil {
+	if err := renderPage(w, r, "skill-external.html", data); err != nil {
 		h.app.Logger.Error("failed to render external skill page", "error", err)
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 	}
@@ -560,7 +652,7 @@ func (h *SkillsHandler) ImportExternal(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Import to local registry
-	stored, err := h.app.RegistryService.ImportSkill(skill.Content)
+	stored, err := h.app.RegistryService.ImportSkillAs(skill.Content, tokenOwner(r))
 	if err != nil {
 		h.app.Logger.Error("failed to import skill", "error", err)
 		http.Error(w, "Failed to import skill. Please try again.", http.StatusBadRequest)
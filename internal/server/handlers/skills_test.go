@@ -8,6 +8,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/sanixdarker/skill-md/internal/app"
 )
 
@@ -165,6 +166,71 @@ This is a test API.
 	})
 }
 
+func TestSkillsHandler_Download_Format(t *testing.T) {
+	application := setupTestApp(t)
+	stored, err := application.RegistryService.ImportSkill(`---
+name: "Export Test API"
+version: "1.0.0"
+description: "A test API for exports"
+tools:
+  - name: "get_items"
+    description: "List items"
+---
+
+## Overview
+
+Items.
+`)
+	if err != nil {
+		t.Fatalf("failed to import test skill: %v", err)
+	}
+
+	r := chi.NewRouter()
+	r.Get("/api/skill/{slug}/download", NewSkillsHandler(application).Download)
+	download := func(query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/skill/"+stored.Slug+"/download"+query, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	tests := []struct {
+		query       string
+		status      int
+		contentType string
+		contains    string
+	}{
+		{"", http.StatusOK, "text/markdown", `name: "Export Test API"`},
+		{"?format=skill", http.StatusOK, "text/markdown", `name: "Export Test API"`},
+		{"?format=agents", http.StatusOK, "text/markdown", "# Export Test API\n\nA test API for exports"},
+		{"?format=llms", http.StatusOK, "text/plain", "> A test API for exports"},
+		{"?format=openai", http.StatusOK, "application/json", `"name": "get_items"`},
+		{"?format=cursor&globs=src/**/*.ts,lib/*.ts&globs=cmd/*.go", http.StatusOK, "text/markdown", "globs: src/**/*.ts,lib/*.ts,cmd/*.go\n"},
+		{"?format=docx", http.StatusBadRequest, "", ""},
+		{"?format=agents&layout=folder", http.StatusBadRequest, "", ""},
+	}
+	for _, tt := range tests {
+		w := download(tt.query)
+		if w.Code != tt.status {
+			t.Errorf("%s: expected %d, got %d", tt.query, tt.status, w.Code)
+			continue
+		}
+		if tt.status != http.StatusOK {
+			continue
+		}
+		if got := w.Header().Get("Content-Type"); got != tt.contentType {
+			t.Errorf("%s: expected Content-Type %s, got %s", tt.query, tt.contentType, got)
+		}
+		if !strings.Contains(w.Body.String(), tt.contains) {
+			t.Errorf("%s: expected body to contain %q, got:\n%s", tt.query, tt.contains, w.Body.String())
+		}
+	}
+
+	if got := download("?format=openai").Header().Get("Content-Disposition"); !strings.Contains(got, stored.Slug+".json") {
+		t.Errorf("unexpected Content-Disposition %q", got)
+	}
+}
+
 func TestSkillsHandler_List(t *testing.T) {
 	tmpDir := t.TempDir()
 	dbPath := filepath.Join(tmpDir, "test.db")
@@ -0,0 +1,245 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sanixdarker/skill-md/internal/app"
+	"github.com/sanixdarker/skill-md/internal/diff"
+	"github.com/sanixdarker/skill-md/internal/registry"
+	"github.com/sanixdarker/skill-md/internal/server/middleware"
+	"github.com/sanixdarker/skill-md/pkg/skill"
+)
+
+// VersionsHandler handles skill revision history requests.
+type VersionsHandler struct {
+	app *app.App
+}
+
+// NewVersionsHandler creates a new VersionsHandler.
+func NewVersionsHandler(application *app.App) *VersionsHandler {
+	return &VersionsHandler{app: application}
+}
+
+// List returns the revision history of a skill as JSON.
+func (h *VersionsHandler) List(w http.ResponseWriter, r *http.Request) {
+	stored := h.lookupSkill(w, r)
+	if stored == nil {
+		return
+	}
+
+	versions, err := h.app.RegistryService.ListVersions(stored.ID)
+	if err != nil {
+		h.app.Logger.Error("failed to list versions", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	if versions == nil {
+		versions = []*skill.SkillVersion{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"skill":    stored.Slug,
+		"versions": versions,
+	})
+}
+
+// Get returns the content of a single revision.
+func (h *VersionsHandler) Get(w http.ResponseWriter, r *http.Request) {
+	stored := h.lookupSkill(w, r)
+	if stored == nil {
+		return
+	}
+
+	revision, err := strconv.Atoi(chi.URLParam(r, "revision"))
+	if err != nil || revision < 1 {
+		http.Error(w, "Invalid revision", http.StatusBadRequest)
+		return
+	}
+
+	v, err := h.app.RegistryService.GetVersion(stored.ID, revision)
+	if err != nil {
+		h.app.Logger.Error("failed to get version", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	if v == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/markdown")
+	w.Write([]byte(v.Content))
+}
+
+// Diff returns the differences between two revisions. "to" defaults to the
+// latest revision and "from" to the one before it. By default the response
+// is a unified diff; format=text lists the semantic changes one per line and
+// format=json returns them as JSON.
+func (h *VersionsHandler) Diff(w http.ResponseWriter, r *http.Request) {
+	stored := h.lookupSkill(w, r)
+	if stored == nil {
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "unified" && format != "text" && format != "json" {
+		http.Error(w, "Invalid format", http.StatusBadRequest)
+		return
+	}
+
+	to, err := optionalRevision(r, "to")
+	if err != nil {
+		http.Error(w, "Invalid revision", http.StatusBadRequest)
+		return
+	}
+	from, err := optionalRevision(r, "from")
+	if err != nil {
+		http.Error(w, "Invalid revision", http.StatusBadRequest)
+		return
+	}
+
+	if to == 0 {
+		versions, err := h.app.RegistryService.ListVersions(stored.ID)
+		if err != nil {
+			h.app.Logger.Error("failed to list versions", "error", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		if len(versions) == 0 {
+			http.NotFound(w, r)
+			return
+		}
+		to = versions[0].Revision
+	}
+	if from == 0 {
+		from = to - 1
+	}
+
+	if format == "text" || format == "json" {
+		h.compare(w, r, stored, from, to, format)
+		return
+	}
+
+	out, err := h.app.RegistryService.DiffVersions(stored.ID, from, to)
+	if errors.Is(err, registry.ErrVersionNotFound) {
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		h.app.Logger.Error("failed to diff versions", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Write([]byte(out))
+}
+
+// compare writes the semantic changes between two revisions as text or
+// JSON.
+func (h *VersionsHandler) compare(w http.ResponseWriter, r *http.Request, stored *skill.StoredSkill, from, to int, format string) {
+	changes, err := h.app.RegistryService.CompareVersions(stored.ID, from, to)
+	if errors.Is(err, registry.ErrVersionNotFound) {
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		h.app.Logger.Error("failed to compare versions", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	if format == "text" {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Write([]byte(diff.Text(changes)))
+		return
+	}
+
+	if changes == nil {
+		changes = []diff.Change{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"skill":   stored.Slug,
+		"from":    from,
+		"to":      to,
+		"changes": changes,
+	})
+}
+
+// Rollback restores a skill to the revision given in the form.
+func (h *VersionsHandler) Rollback(w http.ResponseWriter, r *http.Request) {
+	stored := h.lookupSkill(w, r)
+	if stored == nil {
+		return
+	}
+
+	if !canModifySkill(h.app, r, stored) {
+		http.Error(w, "Only the skill owner or an admin can roll back this skill", http.StatusForbidden)
+		return
+	}
+
+	revision, err := strconv.Atoi(r.FormValue("revision"))
+	if err != nil || revision < 1 {
+		http.Error(w, "Invalid revision", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := h.app.RegistryService.RollbackSkill(stored.ID, revision); err != nil {
+		if errors.Is(err, registry.ErrVersionNotFound) {
+			http.NotFound(w, r)
+			return
+		}
+		h.app.Logger.Error("failed to roll back skill", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	target := "/skill/" + stored.Slug
+	if middleware.IsHTMXRequest(r) {
+		w.Header().Set("HX-Redirect", target)
+		w.WriteHeader(http.StatusOK)
+	} else {
+		http.Redirect(w, r, target, http.StatusSeeOther)
+	}
+}
+
+// lookupSkill resolves the {slug} URL parameter, writing an error response
+// and returning nil if the skill cannot be served.
+func (h *VersionsHandler) lookupSkill(w http.ResponseWriter, r *http.Request) *skill.StoredSkill {
+	slug := chi.URLParam(r, "slug")
+	if len(slug) > MaxSlugLength || len(slug) == 0 || containsPathTraversal(slug) {
+		http.Error(w, "Invalid slug", http.StatusBadRequest)
+		return nil
+	}
+
+	stored, err := h.app.RegistryService.GetSkill(slug)
+	if err != nil {
+		h.app.Logger.Error("failed to get skill", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return nil
+	}
+	if stored == nil {
+		http.NotFound(w, r)
+		return nil
+	}
+	return stored
+}
+
+// optionalRevision parses a revision query parameter, returning 0 if absent.
+func optionalRevision(r *http.Request, key string) (int, error) {
+	value := r.URL.Query().Get(key)
+	if value == "" {
+		return 0, nil
+	}
+	revision, err := strconv.Atoi(value)
+	if err != nil || revision < 1 {
+		return 0, errors.New("invalid revision")
+	}
+	return revision, nil
+}
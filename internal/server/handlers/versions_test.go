@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sanixdarker/skill-md/internal/app"
+	"github.com/sanixdarker/skill-md/internal/diff"
+	"github.com/sanixdarker/skill-md/pkg/skill"
+)
+
+func versionsRequest(method, target, slug string, params map[string]string, body string) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("slug", slug)
+	for k, v := range params {
+		rctx.URLParams.Add(k, v)
+	}
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func createVersionedSkill(t *testing.T, application *app.App) *skill.StoredSkill {
+	t.Helper()
+	sk := skill.NewSkill("Versioned", "First description")
+	stored, err := application.RegistryService.CreateSkill(sk)
+	if err != nil {
+		t.Fatalf("failed to create skill: %v", err)
+	}
+
+	sk.Frontmatter.Version = "1.1.0"
+	sk.Frontmatter.Description = "Second description"
+	if _, err := application.RegistryService.UpdateSkill(stored.ID, sk); err != nil {
+		t.Fatalf("failed to update skill: %v", err)
+	}
+	// Saving identical content must not add a revision
+	if _, err := application.RegistryService.UpdateSkill(stored.ID, sk); err != nil {
+		t.Fatalf("failed to update skill: %v", err)
+	}
+	return stored
+}
+
+func TestVersionsHandler_List(t *testing.T) {
+	application := setupTestApp(t)
+	stored := createVersionedSkill(t, application)
+	handler := NewVersionsHandler(application)
+
+	w := httptest.NewRecorder()
+	handler.List(w, versionsRequest(http.MethodGet, "/api/skill/"+stored.Slug+"/versions", stored.Slug, nil, ""))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+
+	var resp struct {
+		Versions []skill.SkillVersion `json:"versions"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(resp.Versions) != 2 {
+		t.Fatalf("expected 2 revisions, got %d", len(resp.Versions))
+	}
+	if resp.Versions[0].Revision != 2 || resp.Versions[0].Version != "1.1.0" {
+		t.Errorf("expected newest revision first, got %+v", resp.Versions[0])
+	}
+}
+
+func TestVersionsHandler_GetAndDiff(t *testing.T) {
+	application := setupTestApp(t)
+	stored := createVersionedSkill(t, application)
+	handler := NewVersionsHandler(application)
+
+	w := httptest.NewRecorder()
+	handler.Get(w, versionsRequest(http.MethodGet, "/", stored.Slug, map[string]string{"revision": "1"}, ""))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "First description") {
+		t.Errorf("expected first revision content, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	handler.Get(w, versionsRequest(http.MethodGet, "/", stored.Slug, map[string]string{"revision": "9"}, ""))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for missing revision, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	handler.Diff(w, versionsRequest(http.MethodGet, "/api/skill/x/diff", stored.Slug, nil, ""))
+	out := w.Body.String()
+	if !strings.Contains(out, `-description: "First description"`) || !strings.Contains(out, `+description: "Second description"`) {
+		t.Errorf("expected unified diff of the description, got:\n%s", out)
+	}
+}
+
+func TestVersionsHandler_SemanticDiff(t *testing.T) {
+	application := setupTestApp(t)
+	stored := createVersionedSkill(t, application)
+	handler := NewVersionsHandler(application)
+
+	w := httptest.NewRecorder()
+	handler.Diff(w, versionsRequest(http.MethodGet, "/api/skill/x/diff?from=1&to=2&format=text", stored.Slug, nil, ""))
+	if want := "~ version: \"1.0.0\" -> \"1.1.0\"\n~ description: \"First description\" -> \"Second description\"\n"; w.Body.String() != want {
+		t.Errorf("unexpected text diff:\n%s\nwant:\n%s", w.Body.String(), want)
+	}
+
+	w = httptest.NewRecorder()
+	handler.Diff(w, versionsRequest(http.MethodGet, "/api/skill/x/diff?format=json", stored.Slug, nil, ""))
+	var resp struct {
+		From    int           `json:"from"`
+		To      int           `json:"to"`
+		Changes []diff.Change `json:"changes"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if resp.From != 1 || resp.To != 2 || len(resp.Changes) != 2 || resp.Changes[0].Name != "version" {
+		t.Errorf("unexpected JSON diff: %+v", resp)
+	}
+
+	w = httptest.NewRecorder()
+	handler.Diff(w, versionsRequest(http.MethodGet, "/api/skill/x/diff?format=yaml", stored.Slug, nil, ""))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for an unknown format, got %d", w.Code)
+	}
+}
+
+func TestVersionsHandler_Rollback(t *testing.T) {
+	application := setupTestApp(t)
+	stored := createVersionedSkill(t, application)
+	handler := NewVersionsHandler(application)
+
+	form := url.Values{"revision": {"1"}}.Encode()
+	w := httptest.NewRecorder()
+	handler.Rollback(w, versionsRequest(http.MethodPost, "/", stored.Slug, nil, form))
+	if w.Code != http.StatusSeeOther {
+		t.Fatalf("expected status 303, got %d", w.Code)
+	}
+
+	current, _ := application.RegistryService.GetSkill(stored.ID)
+	if current.Version != "1.0.0" || !strings.Contains(current.Content, "First description") {
+		t.Errorf("expected skill restored to first revision, got %+v", current)
+	}
+
+	versions, _ := application.RegistryService.ListVersions(stored.ID)
+	if len(versions) != 3 {
+		t.Errorf("expected rollback to add a revision, got %d revisions", len(versions))
+	}
+}
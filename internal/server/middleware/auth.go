@@ -0,0 +1,129 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/sanixdarker/skill-md/internal/auth"
+)
+
+const tokenKey contextKey = "token"
+
+// TokenAuthenticator resolves a bearer secret to an API token.
+type TokenAuthenticator interface {
+	Authenticate(secret string) (*auth.Token, error)
+}
+
+// AuthErrorWriter writes an authentication or authorization failure. code is
+// a short machine-readable reason such as "invalid_token".
+type AuthErrorWriter func(w http.ResponseWriter, r *http.Request, status int, code, message string)
+
+// PlainAuthError writes auth failures as plain text.
+func PlainAuthError(w http.ResponseWriter, r *http.Request, status int, code, message string) {
+	http.Error(w, message, status)
+}
+
+// TokenAuth attaches the API token from an "Authorization: Bearer" header to
+// the request context. Requests without the header pass through unchanged;
+// an invalid or revoked token is rejected through writeError, which defaults
+// to PlainAuthError when nil.
+func TokenAuth(authenticator TokenAuthenticator, writeError AuthErrorWriter) func(http.Handler) http.Handler {
+	if writeError == nil {
+		writeError = PlainAuthError
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			secret, ok := BearerToken(r)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			authenticate(authenticator, writeError, secret, next, w, r)
+		})
+	}
+}
+
+// FormTokenField is the form field HTML forms send an API token in.
+const FormTokenField = "api_token"
+
+// FormTokenAuth attaches the API token posted in FormTokenField to the
+// request context, for browser forms that cannot set an Authorization
+// header. Requests already authenticated by TokenAuth, or without the field,
+// pass through unchanged. A browser submits the field with the rest of the
+// form, so routes using FormTokenAuth must check CSRF tokens before it.
+func FormTokenAuth(authenticator TokenAuthenticator, writeError AuthErrorWriter) func(http.Handler) http.Handler {
+	if writeError == nil {
+		writeError = PlainAuthError
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			secret := strings.TrimSpace(r.FormValue(FormTokenField))
+			if GetAPIToken(r) != nil || secret == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			authenticate(authenticator, writeError, secret, next, w, r)
+		})
+	}
+}
+
+// authenticate resolves secret and serves next with the token in the
+// request context, or rejects the request through writeError.
+func authenticate(authenticator TokenAuthenticator, writeError AuthErrorWriter, secret string, next http.Handler, w http.ResponseWriter, r *http.Request) {
+	token, err := authenticator.Authenticate(secret)
+	if err != nil {
+		if !errors.Is(err, auth.ErrInvalidToken) {
+			writeError(w, r, http.StatusInternalServerError, "internal_error", "Internal Server Error")
+			return
+		}
+		w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		writeError(w, r, http.StatusUnauthorized, "invalid_token", "Invalid API token")
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), tokenKey, token)
+	next.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// RequireScope rejects requests whose API token lacks scope, writing the
+// failure through writeError (PlainAuthError when nil).
+func RequireScope(scope string, writeError AuthErrorWriter) func(http.Handler) http.Handler {
+	if writeError == nil {
+		writeError = PlainAuthError
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := GetAPIToken(r)
+			if token == nil {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				writeError(w, r, http.StatusUnauthorized, "unauthorized", "API token required")
+				return
+			}
+			if !token.HasScope(scope) {
+				w.Header().Set("WWW-Authenticate", `Bearer error="insufficient_scope", scope="`+scope+`"`)
+				writeError(w, r, http.StatusForbidden, "insufficient_scope", "API token lacks the "+scope+" scope")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// GetAPIToken retrieves the authenticated API token from context, or nil.
+func GetAPIToken(r *http.Request) *auth.Token {
+	token, _ := r.Context().Value(tokenKey).(*auth.Token)
+	return token
+}
+
+// BearerToken extracts the secret from an "Authorization: Bearer" header.
+func BearerToken(r *http.Request) (string, bool) {
+	scheme, secret, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	secret = strings.TrimSpace(secret)
+	return secret, secret != ""
+}
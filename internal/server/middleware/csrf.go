@@ -1,11 +1,13 @@
 package middleware
 
 import (
+	"context"
 	"crypto/hmac"
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/base64"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 )
@@ -20,7 +22,7 @@ type CSRFConfig struct {
 	HeaderName    string
 	FormFieldName string
 	CookiePath    string
-	Secure        bool
+	Secure        bool // Applied only to requests served over HTTPS
 	SameSite      http.SameSite
 	MaxAge        int
 }
@@ -97,32 +99,38 @@ func (c *CSRFProtection) validateToken(token string) bool {
 	return hmac.Equal(providedSig, expectedSig)
 }
 
-// Protect returns the CSRF protection middleware
+// Protect returns the CSRF protection middleware. Safe requests get a token
+// cookie (reused while it stays valid, so a page's token survives its HTMX
+// requests) and the token in the request context. Unsafe requests must echo
+// the cookie in the header or form field. Requests authenticated with a
+// bearer token are exempt, since browsers never attach those on their own;
+// tokens posted in a form field are not.
 func (c *CSRFProtection) Protect(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Skip CSRF check for safe methods
 		if r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == http.MethodOptions {
-			// Generate token for the response
-			token, err := c.generateToken()
-			if err != nil {
-				http.Error(w, "Failed to generate CSRF token", http.StatusInternalServerError)
-				return
+			token := ""
+			if cookie, err := r.Cookie(c.config.CookieName); err == nil && c.validateToken(cookie.Value) {
+				token = cookie.Value
+			} else {
+				var err error
+				token, err = c.generateToken()
+				if err != nil {
+					http.Error(w, "Failed to generate CSRF token", http.StatusInternalServerError)
+					return
+				}
+				c.setCookie(w, r, token)
 			}
 
-			// Set cookie
-			http.SetCookie(w, &http.Cookie{
-				Name:     c.config.CookieName,
-				Value:    token,
-				Path:     c.config.CookiePath,
-				MaxAge:   c.config.MaxAge,
-				Secure:   c.config.Secure,
-				HttpOnly: true,
-				SameSite: c.config.SameSite,
-			})
-
 			// Make token available to templates
 			w.Header().Set("X-CSRF-Token", token)
+			ctx := context.WithValue(r.Context(), CSRFTokenKey{}, token)
 
+			next.ServeHTTP(w, r.WithContext(ctx))
+			return
+		}
+
+		if _, ok := BearerToken(r); ok && GetAPIToken(r) != nil {
 			next.ServeHTTP(w, r)
 			return
 		}
@@ -146,27 +154,27 @@ func (c *CSRFProtection) Protect(next http.Handler) http.Handler {
 			return
 		}
 
-		// Token is valid, refresh it
-		newToken, err := c.generateToken()
-		if err == nil {
-			http.SetCookie(w, &http.Cookie{
-				Name:     c.config.CookieName,
-				Value:    newToken,
-				Path:     c.config.CookiePath,
-				MaxAge:   c.config.MaxAge,
-				Secure:   c.config.Secure,
-				HttpOnly: true,
-				SameSite: c.config.SameSite,
-			})
-			w.Header().Set("X-CSRF-Token", newToken)
-		}
-
 		next.ServeHTTP(w, r)
 	})
 }
 
+func (c *CSRFProtection) setCookie(w http.ResponseWriter, r *http.Request, token string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     c.config.CookieName,
+		Value:    token,
+		Path:     c.config.CookiePath,
+		MaxAge:   c.config.MaxAge,
+		Secure:   c.config.Secure && isHTTPS(r),
+		HttpOnly: true,
+		SameSite: c.config.SameSite,
+	})
+}
+
 // GetToken returns the CSRF token from the request context or generates a new one
 func (c *CSRFProtection) GetToken(r *http.Request) string {
+	if token := CSRFToken(r); token != "" {
+		return token
+	}
 	if cookie, err := r.Cookie(c.config.CookieName); err == nil {
 		return cookie.Value
 	}
@@ -174,6 +182,18 @@ func (c *CSRFProtection) GetToken(r *http.Request) string {
 	return token
 }
 
+// CSRFToken returns the token Protect stored in the request context, or "".
+func CSRFToken(r *http.Request) string {
+	token, _ := r.Context().Value(CSRFTokenKey{}).(string)
+	return token
+}
+
+// isHTTPS reports whether the request reached us, or a proxy in front of us,
+// over TLS. Secure cookies set over plain HTTP would be dropped by browsers.
+func isHTTPS(r *http.Request) bool {
+	return r.TLS != nil || strings.EqualFold(r.Header.Get("X-Forwarded-Proto"), "https")
+}
+
 // Token cleanup goroutine (if using in-memory storage)
 func (c *CSRFProtection) startCleanup(interval time.Duration) {
 	ticker := time.NewTicker(interval)
@@ -5,11 +5,13 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/sanixdarker/skill-md/internal/app"
+	"github.com/sanixdarker/skill-md/internal/auth"
 	"github.com/sanixdarker/skill-md/internal/server/handlers"
 	servermw "github.com/sanixdarker/skill-md/internal/server/middleware"
 	"github.com/sanixdarker/skill-md/web"
@@ -21,6 +23,7 @@ type Server struct {
 	server      *http.Server
 	router      *chi.Mux
 	rateLimiter *servermw.RateLimiter
+	csrf        *servermw.CSRFProtection
 }
 
 // New creates a new Server.
@@ -29,6 +32,7 @@ func New(application *app.App) *Server {
 		app:         application,
 		router:      chi.NewRouter(),
 		rateLimiter: servermw.NewRateLimiter(5, 20), // 5 req/sec, burst of 20
+		csrf:        servermw.NewCSRFProtection(servermw.DefaultCSRFConfig()),
 	}
 
 	s.setupMiddleware()
@@ -49,6 +53,7 @@ func (s *Server) setupMiddleware() {
 	s.router.Use(middleware.RequestID)
 	s.router.Use(middleware.RealIP)
 	s.router.Use(s.rateLimiter.Limit) // Rate limiting
+	s.router.Use(servermw.TokenAuth(s.app.TokenService, s.authError))
 	s.router.Use(servermw.SecurityHeaders)
 	s.router.Use(servermw.Logger(s.app.Logger))
 	s.router.Use(middleware.Recoverer)
@@ -65,29 +70,99 @@ func (s *Server) setupRoutes() {
 	convertHandler := handlers.NewConvertHandler(s.app)
 	mergeHandler := handlers.NewMergeHandler(s.app)
 	skillsHandler := handlers.NewSkillsHandler(s.app)
+	versionsHandler := handlers.NewVersionsHandler(s.app)
+	mcpHandler := handlers.NewMCPHandler(s.app)
+	apiHandler := handlers.NewAPIHandler(s.app)
 
-	// Pages
-	s.router.Get("/", homeHandler.Index)
-	s.router.Get("/convert", convertHandler.Index)
-	s.router.Get("/merge", mergeHandler.Index)
-	s.router.Get("/skill/{slug}", skillsHandler.View)
+	// Pages (issue the CSRF token rendered into the layout)
+	s.router.Group(func(r chi.Router) {
+		r.Use(s.csrf.Protect)
+		r.Get("/", homeHandler.Index)
+		r.Get("/convert", convertHandler.Index)
+		r.Get("/merge", mergeHandler.Index)
+		r.Get("/skill/{slug}", skillsHandler.View)
 
-	// External skill routes
-	s.router.Get("/external/{source}/*", skillsHandler.ViewExternal)
+		// External skill routes
+		r.Get("/external/{source}/*", skillsHandler.ViewExternal)
+	})
 
 	// API endpoints (HTMX)
-	s.router.Post("/api/convert", convertHandler.Convert)
+	s.router.With(s.csrf.Protect).Post("/api/convert", convertHandler.Convert)
 	s.router.Post("/api/convert/url", convertHandler.ConvertURL)
 	s.router.Post("/api/convert/detect", convertHandler.DetectFormat)
-	s.router.Post("/api/merge", mergeHandler.Merge)
-	s.router.Post("/api/skills", skillsHandler.Create)
+	s.router.With(s.csrf.Protect).Post("/api/merge", mergeHandler.Merge)
+	s.router.With(s.requireWrite(auth.ScopePublish)).Post("/api/skills", skillsHandler.Create)
 	s.router.Get("/api/skills", skillsHandler.List)
 	s.router.Get("/api/skills/search", skillsHandler.Search)
 	s.router.Get("/api/skill/{slug}", skillsHandler.Get)
-	s.router.Delete("/api/skill/{id}", skillsHandler.Delete)
+	s.router.With(s.requireWrite(auth.ScopeDelete)).Delete("/api/skill/{id}", skillsHandler.Delete)
 	s.router.Get("/api/skill/{slug}/download", skillsHandler.Download)
-	s.router.Post("/api/skills/import-external", skillsHandler.ImportExternal)
+	s.router.Get("/api/skill/{slug}/versions", versionsHandler.List)
+	s.router.Get("/api/skill/{slug}/versions/{revision}", versionsHandler.Get)
+	s.router.Get("/api/skill/{slug}/diff", versionsHandler.Diff)
+	s.router.With(s.requireWrite(auth.ScopePublish)).Post("/api/skill/{slug}/rollback", versionsHandler.Rollback)
+	// The external skill page posts this form from the browser, with the API
+	// token in a form field when auth is required
+	s.router.With(s.csrf.Protect, servermw.FormTokenAuth(s.app.TokenService, s.authError), s.requireScope(auth.ScopePublish)).
+		Post("/api/skills/import-external", skillsHandler.ImportExternal)
 	s.router.Get("/api/external/{source}/content/*", skillsHandler.GetExternalContent)
+
+	// JSON Schema of SKILL.md frontmatter, for editors and CI
+	s.router.Get("/schema/skill.json", apiHandler.SkillSchema)
+
+	// Versioned JSON API. Bodies must be application/json, so these routes
+	// are not exposed to cross-site form posts and skip CSRF tokens.
+	s.router.Route("/api/v1", func(r chi.Router) {
+		r.NotFound(apiHandler.NotFound)
+		r.MethodNotAllowed(apiHandler.MethodNotAllowed)
+
+		r.Get("/openapi.json", apiHandler.OpenAPI)
+		r.Post("/convert", apiHandler.Convert)
+		r.Post("/detect", apiHandler.Detect)
+		r.Post("/merge", apiHandler.Merge)
+		r.Get("/skills", apiHandler.ListSkills)
+		r.With(s.requireScope(auth.ScopePublish)).Post("/skills", apiHandler.CreateSkill)
+		r.With(s.requireScope(auth.ScopePublish)).Post("/skills/import", apiHandler.ImportSkill)
+		r.Get("/skills/search", apiHandler.SearchSkills)
+		r.Get("/skills/{slug}", apiHandler.GetSkill)
+		r.With(s.requireScope(auth.ScopePublish)).Put("/skills/{slug}", apiHandler.UpdateSkill)
+		r.With(s.requireScope(auth.ScopeDelete)).Delete("/skills/{slug}", apiHandler.DeleteSkill)
+		r.Get("/search", apiHandler.FederatedSearch)
+	})
+
+	// Model Context Protocol (streamable HTTP)
+	s.router.With(s.requireScope(auth.ScopeRead)).Handle("/mcp", mcpHandler)
+}
+
+// requireScope enforces an API token scope when the server requires auth,
+// and is a no-op otherwise.
+func (s *Server) requireScope(scope string) func(http.Handler) http.Handler {
+	if !s.app.Config.RequireAuth {
+		return func(next http.Handler) http.Handler { return next }
+	}
+	return servermw.RequireScope(scope, s.authError)
+}
+
+// requireWrite guards HTMX write endpoints that no page posts to. When the
+// server requires auth they take API tokens only, which browsers never send
+// on their own, so there is nothing for a CSRF token to protect. Without auth
+// anyone may call them, and the CSRF check keeps other sites from doing so
+// through a visitor's browser.
+func (s *Server) requireWrite(scope string) func(http.Handler) http.Handler {
+	if !s.app.Config.RequireAuth {
+		return s.csrf.Protect
+	}
+	return servermw.RequireScope(scope, s.authError)
+}
+
+// authError answers auth failures with the JSON error envelope under /api/v1
+// and as plain text everywhere else.
+func (s *Server) authError(w http.ResponseWriter, r *http.Request, status int, code, message string) {
+	if r.URL.Path == "/api/v1" || strings.HasPrefix(r.URL.Path, "/api/v1/") {
+		handlers.APIAuthError(w, r, status, code, message)
+		return
+	}
+	servermw.PlainAuthError(w, r, status, code, message)
 }
 
 // Start starts the HTTP server.
@@ -19,12 +19,12 @@ type FederatedSource struct {
 
 // FederatedResult contains combined results from multiple sources.
 type FederatedResult struct {
-	Skills       []*ExternalSkill
-	Total        int
-	BySource     map[SourceType]int
-	SearchTime   time.Duration
-	SourceTimes  map[SourceType]time.Duration
-	SourceErrors map[SourceType]string // Error messages per source (e.g., auth required)
+	Skills       []*ExternalSkill             `json:"skills"`
+	Total        int                          `json:"total"`
+	BySource     map[SourceType]int           `json:"by_source"`
+	SearchTime   time.Duration                `json:"search_time_ns"`
+	SourceTimes  map[SourceType]time.Duration `json:"source_times_ns"`
+	SourceErrors map[SourceType]string        `json:"source_errors,omitempty"` // Error messages per source (e.g., auth required)
 }
 
 // NewFederatedSource creates a new federated source manager.
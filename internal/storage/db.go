@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"embed"
 	"fmt"
+	"net/url"
 	"strings"
 
 	_ "modernc.org/sqlite"
@@ -15,23 +16,28 @@ var migrationsFS embed.FS
 
 // NewDB creates a new SQLite database connection.
 func NewDB(path string) (*sql.DB, error) {
-	db, err := sql.Open("sqlite", path)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open database: %w", err)
+	// Pragmas are passed in the DSN so every pooled connection gets them, and
+	// transactions start with BEGIN IMMEDIATE so concurrent writers wait on
+	// busy_timeout instead of failing when they upgrade a read lock.
+	params := url.Values{}
+	params.Add("_pragma", "journal_mode(WAL)")
+	params.Add("_pragma", "foreign_keys(1)")
+	params.Add("_pragma", "busy_timeout(5000)")
+	params.Set("_txlock", "immediate")
+
+	sep := "?"
+	if strings.Contains(path, "?") {
+		sep = "&"
 	}
 
-	// Enable WAL mode and foreign keys
-	pragmas := []string{
-		"PRAGMA journal_mode=WAL",
-		"PRAGMA foreign_keys=ON",
-		"PRAGMA busy_timeout=5000",
+	db, err := sql.Open("sqlite", path+sep+params.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
-	for _, pragma := range pragmas {
-		if _, err := db.Exec(pragma); err != nil {
-			db.Close() // Close on failure to prevent connection leak
-			return nil, fmt.Errorf("failed to set pragma: %w", err)
-		}
+	if err := db.Ping(); err != nil {
+		db.Close() // Close on failure to prevent connection leak
+		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
 	return db, nil
@@ -0,0 +1,22 @@
+-- Revision history for skills
+
+CREATE TABLE IF NOT EXISTS skill_versions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    skill_id TEXT NOT NULL,
+    revision INTEGER NOT NULL,
+    version TEXT DEFAULT '1.0.0',
+    name TEXT NOT NULL,
+    description TEXT,
+    content TEXT NOT NULL,
+    content_hash TEXT NOT NULL,
+    source_format TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (skill_id, revision),
+    FOREIGN KEY (skill_id) REFERENCES skills(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_skill_versions_skill ON skill_versions(skill_id, revision DESC);
+
+-- Seed the history with the current content of existing skills
+INSERT INTO skill_versions (skill_id, revision, version, name, description, content, content_hash, source_format, created_at)
+SELECT id, 1, version, name, description, content, content_hash, source_format, updated_at FROM skills;
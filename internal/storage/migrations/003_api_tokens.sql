@@ -0,0 +1,19 @@
+-- API tokens and skill ownership
+
+CREATE TABLE IF NOT EXISTS api_tokens (
+    id TEXT PRIMARY KEY,
+    owner TEXT NOT NULL,
+    name TEXT NOT NULL,
+    prefix TEXT NOT NULL,
+    token_hash TEXT UNIQUE NOT NULL,
+    scopes TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    last_used_at DATETIME,
+    revoked_at DATETIME
+);
+
+CREATE INDEX IF NOT EXISTS idx_api_tokens_owner ON api_tokens(owner);
+
+ALTER TABLE skills ADD COLUMN owner TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_skills_owner ON skills(owner);
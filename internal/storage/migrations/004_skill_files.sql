@@ -0,0 +1,9 @@
+-- Files of skills published as Agent Skills folders
+
+CREATE TABLE IF NOT EXISTS skill_files (
+    skill_id TEXT NOT NULL,
+    path TEXT NOT NULL,
+    content TEXT NOT NULL,
+    PRIMARY KEY (skill_id, path),
+    FOREIGN KEY (skill_id) REFERENCES skills(id) ON DELETE CASCADE
+);
@@ -12,6 +12,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/sanixdarker/skill-md/internal/converter"
+	"github.com/sanixdarker/skill-md/internal/merger"
 	"github.com/sanixdarker/skill-md/internal/registry"
 	"github.com/sanixdarker/skill-md/internal/sources"
 	"github.com/sanixdarker/skill-md/pkg/skill"
@@ -690,14 +691,19 @@ type MergeModel struct {
 	err      error
 	result   string
 	merging  bool
+	strategy merger.ConflictStrategy
 }
 
+// mergeStrategies is the order Tab cycles conflict strategies in.
+var mergeStrategies = []merger.ConflictStrategy{merger.Combine, merger.KeepFirst, merger.KeepLast, merger.KeepLonger}
+
 // NewMergeModel creates a new merge model.
 func NewMergeModel(keys KeyMap, styles Styles, registryService *registry.Service) MergeModel {
 	return MergeModel{
 		keys:     keys,
 		styles:   styles,
 		registry: registryService,
+		strategy: merger.Combine,
 	}
 }
 
@@ -753,6 +759,13 @@ func (m MergeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.cursor < len(m.selected) {
 				m.selected[m.cursor] = !m.selected[m.cursor]
 			}
+		case "tab": // Cycle conflict strategy
+			for i, strategy := range mergeStrategies {
+				if strategy == m.strategy {
+					m.strategy = mergeStrategies[(i+1)%len(mergeStrategies)]
+					break
+				}
+			}
 		case "enter":
 			// Trigger merge
 			return m, m.doMerge()
@@ -789,34 +802,26 @@ func (m MergeModel) doMerge() tea.Cmd {
 			return mergeResultMsg{err: fmt.Errorf("select at least 2 skills to merge")}
 		}
 
-		// Merge skills
-		var merged strings.Builder
-		merged.WriteString("---\n")
-		merged.WriteString("name: Merged Skills\n")
-		merged.WriteString("description: Combined from multiple skills\n")
-		merged.WriteString("version: 1.0.0\n")
-		merged.WriteString("---\n\n")
-		merged.WriteString("# Merged Skills\n\n")
-
-		for i, sk := range selectedSkills {
-			merged.WriteString(fmt.Sprintf("## %d. %s\n\n", i+1, sk.Name))
-			if sk.Description != "" {
-				merged.WriteString(sk.Description + "\n\n")
-			}
-			// Extract body content (skip frontmatter)
-			content := sk.Content
-			if idx := strings.Index(content, "---"); idx != -1 {
-				if endIdx := strings.Index(content[idx+3:], "---"); endIdx != -1 {
-					content = strings.TrimSpace(content[idx+3+endIdx+3:])
-				}
-			}
-			if content != "" {
-				merged.WriteString(content)
-				merged.WriteString("\n\n")
+		skills := make([]*skill.Skill, 0, len(selectedSkills))
+		sources := make([]string, 0, len(selectedSkills))
+		for _, stored := range selectedSkills {
+			sk, err := skill.Parse(stored.Content)
+			if err != nil {
+				return mergeResultMsg{err: fmt.Errorf("failed to parse %s: %w", stored.Name, err)}
 			}
+			skills = append(skills, sk)
+			sources = append(sources, stored.Name)
+		}
+
+		result, err := merger.New().Merge(skills, &merger.Options{
+			Resolver: merger.NewConflictResolver(m.strategy),
+			Sources:  sources,
+		})
+		if err != nil {
+			return mergeResultMsg{err: fmt.Errorf("merge failed: %w", err)}
 		}
 
-		return mergeResultMsg{result: merged.String()}
+		return mergeResultMsg{result: skill.Render(result)}
 	}
 }
 
@@ -861,6 +866,8 @@ func (m MergeModel) View() string {
 		}
 	}
 	b.WriteString(m.styles.Normal.Render(fmt.Sprintf("Select skills to merge (%d selected):", count)))
+	b.WriteString("\n")
+	b.WriteString(m.styles.Muted.Render("Conflict strategy: " + m.strategy.String()))
 	b.WriteString("\n\n")
 
 	if len(m.skills) == 0 {
@@ -892,7 +899,7 @@ func (m MergeModel) View() string {
 	}
 
 	b.WriteString("\n")
-	b.WriteString(m.styles.Help.Render("Space: toggle | a: all | n: none | Enter: merge | Esc: back"))
+	b.WriteString(m.styles.Help.Render("Space: toggle | a: all | n: none | Tab: strategy | Enter: merge | Esc: back"))
 
 	return lipgloss.NewStyle().Padding(2).Render(b.String())
 }
@@ -0,0 +1,79 @@
+//go:build linux
+
+package watch
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+
+	"golang.org/x/sys/unix"
+)
+
+const inotifyMask = unix.IN_CREATE | unix.IN_MODIFY | unix.IN_CLOSE_WRITE |
+	unix.IN_MOVED_FROM | unix.IN_MOVED_TO | unix.IN_DELETE | unix.IN_ATTRIB
+
+// inotifyNotifier signals on any inotify event. Events are not decoded; the
+// watcher rescans to find out what changed.
+type inotifyNotifier struct {
+	fd     int
+	file   *os.File
+	events chan struct{}
+
+	mu      sync.Mutex
+	watched map[string]bool
+}
+
+func newNotifier() (notifier, error) {
+	fd, err := unix.InotifyInit1(unix.IN_CLOEXEC | unix.IN_NONBLOCK)
+	if err != nil {
+		return nil, err
+	}
+
+	n := &inotifyNotifier{
+		fd:      fd,
+		file:    os.NewFile(uintptr(fd), "inotify"),
+		events:  make(chan struct{}, 1),
+		watched: make(map[string]bool),
+	}
+	go n.read()
+	return n, nil
+}
+
+func (n *inotifyNotifier) read() {
+	buf := make([]byte, 64*(unix.SizeofInotifyEvent+unix.NAME_MAX+1))
+	for {
+		if _, err := n.file.Read(buf); err != nil {
+			return
+		}
+		select {
+		case n.events <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (n *inotifyNotifier) Events() <-chan struct{} {
+	return n.events
+}
+
+func (n *inotifyNotifier) Add(dir string) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.watched[abs] {
+		return
+	}
+	// Failures leave the directory to the polling fallback
+	if _, err := unix.InotifyAddWatch(n.fd, abs, inotifyMask); err == nil {
+		n.watched[abs] = true
+	}
+}
+
+func (n *inotifyNotifier) Close() error {
+	return n.file.Close()
+}
@@ -0,0 +1,10 @@
+//go:build !linux
+
+package watch
+
+import "errors"
+
+// newNotifier is unsupported here, so Watch relies on polling alone.
+func newNotifier() (notifier, error) {
+	return nil, errors.New("filesystem notifications are not supported on this platform")
+}
@@ -0,0 +1,187 @@
+// Package watch reports changes to files and directory trees.
+package watch
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Options configures a Watch.
+type Options struct {
+	// Interval between polls. Polling runs alongside filesystem
+	// notifications to catch anything they miss, and replaces them on
+	// platforms without support. Defaults to one second.
+	Interval time.Duration
+	// Debounce is how long the tree must be quiet before a change is
+	// reported, so an editor's save burst is reported once. Defaults to
+	// 200ms.
+	Debounce time.Duration
+	// Ignore lists paths whose contents are not watched, such as an output
+	// directory inside the watched tree.
+	Ignore []string
+	// PollOnly disables filesystem notifications.
+	PollOnly bool
+}
+
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// notifier delivers a signal whenever something changes in a watched
+// directory. Implementations are platform specific.
+type notifier interface {
+	Events() <-chan struct{}
+	Add(dir string)
+	Close() error
+}
+
+// Watch blocks until ctx is done, calling onChange with the sorted list of
+// added, modified and removed files after each burst of changes under paths.
+// Paths may be files or directories; hidden files and directories are
+// skipped.
+func Watch(ctx context.Context, paths []string, opts *Options, onChange func(changed []string)) error {
+	if opts == nil {
+		opts = &Options{}
+	}
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	debounce := opts.Debounce
+	if debounce <= 0 {
+		debounce = 200 * time.Millisecond
+	}
+
+	ignore := make(map[string]bool, len(opts.Ignore))
+	for _, p := range opts.Ignore {
+		if abs, err := filepath.Abs(p); err == nil {
+			ignore[abs] = true
+		}
+	}
+
+	var events <-chan struct{}
+	var n notifier
+	if !opts.PollOnly {
+		// Without notifications polling alone still sees every change
+		if created, err := newNotifier(); err == nil {
+			n = created
+			defer n.Close()
+			events = n.Events()
+		}
+	}
+
+	// last is the state onChange was last called with; seen is the most
+	// recent poll, so a change only postpones the report once per poll.
+	last, dirs := scan(paths, ignore)
+	seen := last
+	addDirs(n, dirs)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	timer := time.NewTimer(debounce)
+	timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-events:
+			timer.Reset(debounce)
+		case <-ticker.C:
+			if current, _ := scan(paths, ignore); len(diff(seen, current)) > 0 {
+				seen = current
+				timer.Reset(debounce)
+			}
+		case <-timer.C:
+			current, dirs := scan(paths, ignore)
+			addDirs(n, dirs)
+			seen = current
+			if changed := diff(last, current); len(changed) > 0 {
+				last = current
+				onChange(changed)
+			}
+		}
+	}
+}
+
+func addDirs(n notifier, dirs []string) {
+	if n == nil {
+		return
+	}
+	for _, dir := range dirs {
+		n.Add(dir)
+	}
+}
+
+// scan records the state of every file under paths, along with the
+// directories that need to be watched for notifications. A watched file is
+// covered through its parent directory so atomic saves, which replace the
+// file, are still seen.
+func scan(paths []string, ignore map[string]bool) (map[string]fileState, []string) {
+	files := make(map[string]fileState)
+	var dirs []string
+
+	for _, root := range paths {
+		info, err := os.Stat(root)
+		if err != nil {
+			continue
+		}
+		if !info.IsDir() {
+			files[root] = fileState{modTime: info.ModTime(), size: info.Size()}
+			dirs = append(dirs, filepath.Dir(root))
+			continue
+		}
+
+		filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			if path != root && strings.HasPrefix(d.Name(), ".") {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if d.IsDir() {
+				if abs, err := filepath.Abs(path); err == nil && ignore[abs] {
+					return filepath.SkipDir
+				}
+				dirs = append(dirs, path)
+				return nil
+			}
+			if !d.Type().IsRegular() {
+				return nil
+			}
+			if info, err := d.Info(); err == nil {
+				files[path] = fileState{modTime: info.ModTime(), size: info.Size()}
+			}
+			return nil
+		})
+	}
+
+	return files, dirs
+}
+
+// diff returns the sorted paths that differ between two scans.
+func diff(before, after map[string]fileState) []string {
+	var changed []string
+	for path, state := range after {
+		if prev, ok := before[path]; !ok || prev != state {
+			changed = append(changed, path)
+		}
+	}
+	for path := range before {
+		if _, ok := after[path]; !ok {
+			changed = append(changed, path)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
@@ -0,0 +1,72 @@
+package watch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func watchChanges(t *testing.T, paths []string, opts *Options) <-chan []string {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	changes := make(chan []string, 10)
+	go Watch(ctx, paths, opts, func(changed []string) { changes <- changed })
+	// Give the watcher time to take its first snapshot
+	time.Sleep(50 * time.Millisecond)
+	return changes
+}
+
+func expectChange(t *testing.T, changes <-chan []string, want string) {
+	t.Helper()
+	select {
+	case changed := <-changes:
+		if len(changed) != 1 || changed[0] != want {
+			t.Errorf("expected change to %s, got %v", want, changed)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatalf("no change reported for %s", want)
+	}
+}
+
+func TestWatch_File(t *testing.T) {
+	for _, pollOnly := range []bool{false, true} {
+		dir := t.TempDir()
+		spec := filepath.Join(dir, "openapi.yaml")
+		os.WriteFile(spec, []byte("openapi: 3.0.0\n"), 0644)
+
+		changes := watchChanges(t, []string{spec}, &Options{
+			Interval: 20 * time.Millisecond,
+			Debounce: 20 * time.Millisecond,
+			PollOnly: pollOnly,
+		})
+
+		// Unrelated files next to the watched one are not reported
+		os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("x"), 0644)
+		os.WriteFile(spec, []byte("openapi: 3.1.0\ninfo: {}\n"), 0644)
+		expectChange(t, changes, spec)
+	}
+}
+
+func TestWatch_DirectorySkipsHiddenAndIgnored(t *testing.T) {
+	dir := t.TempDir()
+	out := filepath.Join(dir, "skills")
+	os.MkdirAll(filepath.Join(dir, ".git"), 0755)
+	os.MkdirAll(out, 0755)
+
+	changes := watchChanges(t, []string{dir}, &Options{
+		Interval: 20 * time.Millisecond,
+		Debounce: 20 * time.Millisecond,
+		Ignore:   []string{out},
+	})
+
+	os.WriteFile(filepath.Join(dir, ".git", "index"), []byte("x"), 0644)
+	os.WriteFile(filepath.Join(out, "api.md"), []byte("x"), 0644)
+	os.MkdirAll(filepath.Join(dir, "pets"), 0755)
+	spec := filepath.Join(dir, "pets", "openapi.yaml")
+	os.WriteFile(spec, []byte("openapi: 3.0.0\n"), 0644)
+	expectChange(t, changes, spec)
+}
@@ -0,0 +1,342 @@
+package skill
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Exporter defines the interface for skill exporters, which render a skill
+// in another agent-instruction format.
+type Exporter interface {
+	// Name returns the format name.
+	Name() string
+	// Extension returns the file extension of the output, e.g. ".md".
+	Extension() string
+	// ContentType returns the MIME type of the output.
+	ContentType() string
+	// Export renders the skill.
+	Export(s *Skill, opts *ExportOptions) ([]byte, error)
+}
+
+// ExportOptions holds exporter options.
+type ExportOptions struct {
+	// Globs are the file patterns a Cursor rule applies to.
+	Globs []string
+}
+
+// ExportManager manages available exporters.
+type ExportManager struct {
+	exporters []Exporter
+}
+
+// NewExportManager creates a new export manager with all built-in exporters.
+func NewExportManager() *ExportManager {
+	m := &ExportManager{}
+	m.Register(skillExporter{})
+	m.Register(agentsExporter{})
+	m.Register(cursorExporter{})
+	m.Register(llmsExporter{})
+	m.Register(llmsFullExporter{})
+	m.Register(openAIExporter{})
+	m.Register(jsonExporter{})
+	return m
+}
+
+// Register adds an exporter to the manager.
+func (m *ExportManager) Register(e Exporter) {
+	m.exporters = append(m.exporters, e)
+}
+
+// Lookup returns the exporter for a format.
+func (m *ExportManager) Lookup(format string) (Exporter, bool) {
+	for _, e := range m.exporters {
+		if strings.EqualFold(e.Name(), format) {
+			return e, true
+		}
+	}
+	return nil, false
+}
+
+// Export renders a skill in the specified format.
+func (m *ExportManager) Export(format string, s *Skill, opts *ExportOptions) ([]byte, error) {
+	e, ok := m.Lookup(format)
+	if !ok {
+		return nil, fmt.Errorf("unknown export format: %s", format)
+	}
+	if s == nil {
+		return nil, fmt.Errorf("no skill to export")
+	}
+	if opts == nil {
+		opts = &ExportOptions{}
+	}
+	return e.Export(s, opts)
+}
+
+// SupportedFormats returns a list of supported export formats.
+func (m *ExportManager) SupportedFormats() []string {
+	formats := make([]string, len(m.exporters))
+	for i, e := range m.exporters {
+		formats[i] = e.Name()
+	}
+	return formats
+}
+
+// skillExporter renders SKILL.md itself.
+type skillExporter struct{}
+
+func (skillExporter) Name() string        { return "skill" }
+func (skillExporter) Extension() string   { return ".md" }
+func (skillExporter) ContentType() string { return "text/markdown" }
+
+func (skillExporter) Export(s *Skill, _ *ExportOptions) ([]byte, error) {
+	return []byte(Render(s)), nil
+}
+
+// agentsExporter renders an AGENTS.md: plain markdown without frontmatter.
+type agentsExporter struct{}
+
+func (agentsExporter) Name() string        { return "agents" }
+func (agentsExporter) Extension() string   { return ".md" }
+func (agentsExporter) ContentType() string { return "text/markdown" }
+
+func (agentsExporter) Export(s *Skill, _ *ExportOptions) ([]byte, error) {
+	var b strings.Builder
+	b.WriteString("# " + s.Frontmatter.Name + "\n\n")
+	if s.Frontmatter.Description != "" {
+		b.WriteString(s.Frontmatter.Description + "\n\n")
+	}
+	if facts := connectionFacts(s); facts != "" {
+		b.WriteString(facts + "\n\n")
+	}
+	b.WriteString(renderSections(s.Sections))
+	return []byte(strings.TrimRight(b.String(), "\n") + "\n"), nil
+}
+
+// cursorExporter renders a Cursor rules file (.mdc) whose frontmatter
+// scopes the rule to file globs.
+type cursorExporter struct{}
+
+func (cursorExporter) Name() string        { return "cursor" }
+func (cursorExporter) Extension() string   { return ".mdc" }
+func (cursorExporter) ContentType() string { return "text/markdown" }
+
+func (cursorExporter) Export(s *Skill, opts *ExportOptions) ([]byte, error) {
+	description := s.Frontmatter.Description
+	if description == "" {
+		description = s.Frontmatter.Name
+	}
+
+	var b strings.Builder
+	b.WriteString("---\n")
+	// Cursor reads globs as a bare comma-separated list, so only the
+	// description is quoted
+	globs := make([]string, 0, len(opts.Globs))
+	for _, g := range opts.Globs {
+		globs = append(globs, oneLine(g))
+	}
+	b.WriteString(fmt.Sprintf("description: %q\n", oneLine(description)))
+	b.WriteString("globs: " + strings.Join(globs, ",") + "\n")
+	b.WriteString("alwaysApply: false\n")
+	b.WriteString("---\n\n")
+
+	body, _ := agentsExporter{}.Export(s, opts)
+	b.Write(body)
+	return []byte(b.String()), nil
+}
+
+// llmsExporter renders an llms.txt index: a title, a summary and a list of
+// what the skill covers.
+type llmsExporter struct{}
+
+func (llmsExporter) Name() string        { return "llms" }
+func (llmsExporter) Extension() string   { return ".txt" }
+func (llmsExporter) ContentType() string { return "text/plain" }
+
+func (llmsExporter) Export(s *Skill, _ *ExportOptions) ([]byte, error) {
+	var b strings.Builder
+	writeLLMSHeader(&b, s)
+
+	var contents []string
+	for _, sec := range s.Sections {
+		if sec.Level > 2 {
+			continue
+		}
+		line := "- " + sec.Title
+		if summary := summaryLine(sec.Content); summary != "" {
+			line += ": " + summary
+		}
+		contents = append(contents, line)
+	}
+	if len(contents) > 0 {
+		b.WriteString("## Contents\n\n" + strings.Join(contents, "\n") + "\n\n")
+	}
+
+	if len(s.Frontmatter.ToolDefinitions) > 0 {
+		b.WriteString("## Tools\n\n")
+		for _, tool := range s.Frontmatter.ToolDefinitions {
+			name := tool.Name
+			if base := toolBaseURL(s, tool); base != "" && tool.Path != "" {
+				name = fmt.Sprintf("[%s](%s%s)", tool.Name, base, tool.Path)
+			}
+			line := "- " + name
+			if tool.Description != "" {
+				line += ": " + oneLine(tool.Description)
+			}
+			b.WriteString(line + "\n")
+		}
+		b.WriteString("\n")
+	}
+
+	return []byte(strings.TrimRight(b.String(), "\n") + "\n"), nil
+}
+
+// llmsFullExporter renders llms-full.txt: the llms.txt header followed by
+// the whole skill.
+type llmsFullExporter struct{}
+
+func (llmsFullExporter) Name() string        { return "llms-full" }
+func (llmsFullExporter) Extension() string   { return ".txt" }
+func (llmsFullExporter) ContentType() string { return "text/plain" }
+
+func (llmsFullExporter) Export(s *Skill, _ *ExportOptions) ([]byte, error) {
+	var b strings.Builder
+	writeLLMSHeader(&b, s)
+	b.WriteString(renderSections(s.Sections))
+	return []byte(strings.TrimRight(b.String(), "\n") + "\n"), nil
+}
+
+// writeLLMSHeader writes the llms.txt title, summary blockquote and
+// connection details.
+func writeLLMSHeader(b *strings.Builder, s *Skill) {
+	b.WriteString("# " + s.Frontmatter.Name + "\n\n")
+	if s.Frontmatter.Description != "" {
+		b.WriteString("> " + oneLine(s.Frontmatter.Description) + "\n\n")
+	}
+	if facts := connectionFacts(s); facts != "" {
+		b.WriteString(facts + "\n\n")
+	}
+}
+
+// openAIExporter renders the tool definitions as OpenAI function-calling
+// tools.
+type openAIExporter struct{}
+
+func (openAIExporter) Name() string        { return "openai" }
+func (openAIExporter) Extension() string   { return ".json" }
+func (openAIExporter) ContentType() string { return "application/json" }
+
+// openAIFunction is one entry of an OpenAI "tools" array.
+type openAIFunction struct {
+	Type     string `json:"type"`
+	Function struct {
+		Name        string                 `json:"name"`
+		Description string                 `json:"description,omitempty"`
+		Parameters  map[string]interface{} `json:"parameters"`
+	} `json:"function"`
+}
+
+func (openAIExporter) Export(s *Skill, _ *ExportOptions) ([]byte, error) {
+	tools := make([]openAIFunction, 0, len(s.Frontmatter.ToolDefinitions))
+	for _, tool := range s.Frontmatter.ToolDefinitions {
+		var fn openAIFunction
+		fn.Type = "function"
+
+		// Function names are limited to 64 letters, digits, _ and -
+		name := toolNameInvalidRegex.ReplaceAllString(tool.Name, "_")
+		if len(name) > 64 {
+			name = name[:64]
+		}
+		fn.Function.Name = name
+		fn.Function.Description = tool.Description
+
+		params := make(map[string]interface{}, len(tool.Parameters)+2)
+		for k, v := range tool.Parameters {
+			params[k] = v
+		}
+		if _, ok := params["type"]; !ok {
+			params["type"] = "object"
+		}
+		if _, ok := params["properties"]; !ok {
+			params["properties"] = map[string]interface{}{}
+		}
+		if _, ok := params["required"]; !ok && len(tool.Required) > 0 {
+			params["required"] = tool.Required
+		}
+		fn.Function.Parameters = params
+
+		tools = append(tools, fn)
+	}
+
+	data, err := json.MarshalIndent(tools, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode tools: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// jsonExporter renders the skill structure as JSON.
+type jsonExporter struct{}
+
+func (jsonExporter) Name() string        { return "json" }
+func (jsonExporter) Extension() string   { return ".json" }
+func (jsonExporter) ContentType() string { return "application/json" }
+
+func (jsonExporter) Export(s *Skill, _ *ExportOptions) ([]byte, error) {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode skill: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// connectionFacts summarizes the version, base URL and authentication of a
+// skill in one line.
+func connectionFacts(s *Skill) string {
+	var facts []string
+	if s.Frontmatter.Version != "" {
+		facts = append(facts, "Version "+s.Frontmatter.Version+".")
+	}
+	if s.Frontmatter.BaseURL != "" {
+		facts = append(facts, "Base URL: `"+s.Frontmatter.BaseURL+"`.")
+	}
+	if len(s.Frontmatter.AuthMethods) > 0 {
+		facts = append(facts, "Authentication: "+strings.Join(s.Frontmatter.AuthMethods, ", ")+".")
+	}
+	return strings.Join(facts, " ")
+}
+
+// toolBaseURL returns the base URL a tool is called on.
+func toolBaseURL(s *Skill, tool ToolDefinition) string {
+	if tool.BaseURL != "" {
+		return strings.TrimSuffix(tool.BaseURL, "/")
+	}
+	return strings.TrimSuffix(s.Frontmatter.BaseURL, "/")
+}
+
+// summaryLine returns the first line of prose in content, shortened to a
+// list entry.
+func summaryLine(content string) string {
+	inCode := false
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "```") {
+			inCode = !inCode
+			continue
+		}
+		if inCode || line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "<") ||
+			strings.HasPrefix(line, "|") || strings.HasPrefix(line, "-") || strings.HasPrefix(line, "*") {
+			continue
+		}
+		if r := []rune(line); len(r) > 120 {
+			line = strings.TrimSpace(string(r[:117])) + "..."
+		}
+		return line
+	}
+	return ""
+}
+
+// oneLine collapses whitespace so text fits on a single line.
+func oneLine(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
@@ -0,0 +1,151 @@
+package skill
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func exportSkill() *Skill {
+	return &Skill{
+		Frontmatter: Frontmatter{
+			Name:        "Pets",
+			Version:     "1.0.0",
+			Description: "Pet store API",
+			BaseURL:     "https://api.example.com",
+			AuthMethods: []string{"bearer"},
+			ToolDefinitions: []ToolDefinition{
+				{
+					Name:        "get_pet",
+					Description: "Get a pet",
+					Method:      "GET",
+					Path:        "/pets/{id}",
+					Parameters: map[string]interface{}{
+						"type": "object",
+						"properties": map[string]interface{}{
+							"id": map[string]interface{}{"type": "string"},
+						},
+					},
+					Required: []string{"id"},
+				},
+				{Name: "pets.list", Description: "List pets"},
+			},
+		},
+		Sections: []Section{
+			{Title: "Overview", Level: 2, Content: "Pets, in a store.\n\nMore details."},
+			{Title: "GET /pets/{id}", Level: 3, Content: "Get a pet."},
+		},
+	}
+}
+
+func TestExportManager_Formats(t *testing.T) {
+	m := NewExportManager()
+	want := []string{"skill", "agents", "cursor", "llms", "llms-full", "openai", "json"}
+	if got := m.SupportedFormats(); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("SupportedFormats() = %v, want %v", got, want)
+	}
+
+	for _, format := range want {
+		out, err := m.Export(format, exportSkill(), nil)
+		if err != nil {
+			t.Errorf("%s: %v", format, err)
+			continue
+		}
+		if !strings.HasSuffix(string(out), "\n") || strings.HasSuffix(string(out), "\n\n") {
+			t.Errorf("%s: expected a single trailing newline:\n%s", format, out)
+		}
+	}
+
+	if _, err := m.Export("docx", exportSkill(), nil); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+	if _, err := m.Export("skill", nil, nil); err == nil {
+		t.Error("expected an error for a nil skill")
+	}
+}
+
+func TestExport_Markdown(t *testing.T) {
+	m := NewExportManager()
+
+	agents, _ := m.Export("agents", exportSkill(), nil)
+	for _, want := range []string{"# Pets\n\nPet store API\n\n", "Base URL: `https://api.example.com`", "## Overview", "### GET /pets/{id}"} {
+		if !strings.Contains(string(agents), want) {
+			t.Errorf("AGENTS.md is missing %q:\n%s", want, agents)
+		}
+	}
+	if strings.HasPrefix(string(agents), "---") {
+		t.Errorf("AGENTS.md should not have frontmatter:\n%s", agents)
+	}
+
+	cursor, _ := m.Export("cursor", exportSkill(), &ExportOptions{Globs: []string{"src/**/*.ts", "lib/*.ts"}})
+	if !strings.HasPrefix(string(cursor), "---\ndescription: \"Pet store API\"\nglobs: src/**/*.ts,lib/*.ts\nalwaysApply: false\n---\n\n# Pets") {
+		t.Errorf("unexpected .mdc frontmatter:\n%s", cursor)
+	}
+	tricky := exportSkill()
+	tricky.Frontmatter.Description = "*Pets*: the #1 \"store\""
+	cursor, _ = m.Export("cursor", tricky, nil)
+	if !strings.HasPrefix(string(cursor), "---\ndescription: \"*Pets*: the #1 \\\"store\\\"\"\nglobs: \n") {
+		t.Errorf("expected a quoted description:\n%s", cursor)
+	}
+
+	llms, _ := m.Export("llms", exportSkill(), nil)
+	for _, want := range []string{"# Pets\n\n> Pet store API\n", "- Overview: Pets, in a store.", "- [get_pet](https://api.example.com/pets/{id}): Get a pet", "- pets.list: List pets"} {
+		if !strings.Contains(string(llms), want) {
+			t.Errorf("llms.txt is missing %q:\n%s", want, llms)
+		}
+	}
+	if strings.Contains(string(llms), "More details.") {
+		t.Errorf("llms.txt should only summarize sections:\n%s", llms)
+	}
+
+	full, _ := m.Export("llms-full", exportSkill(), nil)
+	if !strings.Contains(string(full), "> Pet store API") || !strings.Contains(string(full), "More details.") {
+		t.Errorf("llms-full.txt should hold the whole skill:\n%s", full)
+	}
+}
+
+func TestExport_OpenAI(t *testing.T) {
+	s := exportSkill()
+	out, err := NewExportManager().Export("openai", s, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tools []struct {
+		Type     string `json:"type"`
+		Function struct {
+			Name        string                 `json:"name"`
+			Description string                 `json:"description"`
+			Parameters  map[string]interface{} `json:"parameters"`
+		} `json:"function"`
+	}
+	if err := json.Unmarshal(out, &tools); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if len(tools) != 2 || tools[0].Type != "function" || tools[0].Function.Name != "get_pet" {
+		t.Fatalf("unexpected tools: %+v", tools)
+	}
+	if req, _ := tools[0].Function.Parameters["required"].([]interface{}); len(req) != 1 || req[0] != "id" {
+		t.Errorf("expected required to be folded into the parameters, got %v", tools[0].Function.Parameters)
+	}
+	if tools[1].Function.Name != "pets_list" || tools[1].Function.Parameters["type"] != "object" {
+		t.Errorf("expected a sanitized name and an empty object schema, got %+v", tools[1].Function)
+	}
+	if _, ok := s.Frontmatter.ToolDefinitions[0].Parameters["required"]; ok {
+		t.Error("export should not modify the skill")
+	}
+}
+
+func TestExport_JSON(t *testing.T) {
+	out, err := NewExportManager().Export("json", exportSkill(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var s Skill
+	if err := json.Unmarshal(out, &s); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if s.Frontmatter.Name != "Pets" || len(s.Sections) != 2 || len(s.Frontmatter.ToolDefinitions) != 2 {
+		t.Errorf("unexpected skill: %+v", s)
+	}
+}
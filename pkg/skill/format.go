@@ -0,0 +1,168 @@
+package skill
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/adrg/frontmatter"
+)
+
+var (
+	// closingHashesRegex matches the optional closing sequence of an ATX
+	// heading, as in "## Title ##".
+	closingHashesRegex = regexp.MustCompile(`\s+#+\s*$`)
+	// listMarkerRegex matches bullet list items using * or +.
+	listMarkerRegex = regexp.MustCompile(`^(\s*)[*+](\s+\S)`)
+	// thematicBreakRegex matches horizontal rules such as "* * *".
+	thematicBreakRegex = regexp.MustCompile(`^\s*([*_-])(\s*([*_-]))+\s*$`)
+	// openFenceRegex splits an opening code fence into its indent, marker
+	// and info string.
+	openFenceRegex = regexp.MustCompile("^(\\s*)(`{3,}|~{3,})\\s*(.*?)\\s*$")
+)
+
+// Format rewrites a SKILL.md file in canonical form: frontmatter keys in
+// Render's order with quoted strings, headings surrounded by one blank line,
+// backtick code fences with the language right after them and "-" list
+// markers. Text before the first heading and frontmatter keys Frontmatter
+// does not know are kept. It fails on files without valid frontmatter and
+// when formatting would change the skill.
+func Format(content string) (string, error) {
+	content = strings.ReplaceAll(strings.TrimPrefix(content, "\ufeff"), "\r\n", "\n")
+	if !strings.HasPrefix(content, "---\n") {
+		return "", errors.New("no YAML frontmatter")
+	}
+	if _, err := frontmatter.Parse(strings.NewReader(content), &Frontmatter{}); err != nil {
+		return "", fmt.Errorf("invalid frontmatter: %w", err)
+	}
+
+	s, err := Parse(content)
+	if err != nil {
+		return "", err
+	}
+	preamble := formatMarkdown(s.Preamble())
+	for i := range s.Sections {
+		s.Sections[i].Title = formatTitle(s.Sections[i].Title)
+		s.Sections[i].Content = formatMarkdown(s.Sections[i].Content)
+	}
+
+	out := Render(s)
+	if preamble != "" {
+		// Render drops the preamble; put it back between the frontmatter
+		// and the first heading
+		head := Render(&Skill{Frontmatter: s.Frontmatter})
+		out = head + "\n" + preamble + "\n" + strings.TrimPrefix(out, head)
+	}
+	if !strings.HasSuffix(out, "\n") {
+		out += "\n"
+	}
+
+	if err := sameSkill(content, out); err != nil {
+		return "", fmt.Errorf("formatting would change the skill: %w", err)
+	}
+	return out, nil
+}
+
+// sameSkill checks that formatted has the frontmatter and headings of
+// original.
+func sameSkill(original, formatted string) error {
+	a, err := Parse(original)
+	if err != nil {
+		return err
+	}
+	b, err := Parse(formatted)
+	if err != nil {
+		return err
+	}
+
+	fa, _ := json.Marshal(a.Frontmatter)
+	fb, _ := json.Marshal(b.Frontmatter)
+	if string(fa) != string(fb) {
+		return errors.New("frontmatter differs")
+	}
+	if len(a.Sections) != len(b.Sections) {
+		return fmt.Errorf("%d sections became %d", len(a.Sections), len(b.Sections))
+	}
+	for i := range a.Sections {
+		if a.Sections[i].Level != b.Sections[i].Level || formatTitle(a.Sections[i].Title) != b.Sections[i].Title {
+			return fmt.Errorf("heading %q changed", a.Sections[i].Title)
+		}
+	}
+	return nil
+}
+
+// formatTitle trims a heading and drops its closing hashes.
+func formatTitle(title string) string {
+	return strings.TrimSpace(closingHashesRegex.ReplaceAllString(strings.TrimSpace(title), ""))
+}
+
+// formatMarkdown normalizes the body of a section: one blank line between
+// blocks, "-" list markers and backtick code fences. Code blocks are kept
+// as they are.
+func formatMarkdown(text string) string {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	out := make([]string, 0, len(lines))
+	blank := false
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		if m := fenceRegex.FindStringSubmatch(line); m != nil {
+			end := i + 1
+			for end < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[end]), m[1]) {
+				end++
+			}
+			if end == len(lines) {
+				// Unclosed fence: leave the rest alone
+				out = append(out, lines[i:]...)
+				break
+			}
+			out = append(out, formatCodeBlock(lines[i:end+1])...)
+			i = end
+			blank = false
+			continue
+		}
+
+		if strings.TrimSpace(line) == "" {
+			if !blank {
+				out = append(out, "")
+			}
+			blank = true
+			continue
+		}
+		blank = false
+		if !thematicBreakRegex.MatchString(line) {
+			line = listMarkerRegex.ReplaceAllString(line, "${1}-${2}")
+		}
+		out = append(out, line)
+	}
+	return strings.Join(out, "\n")
+}
+
+// formatCodeBlock writes a fenced code block with backticks, unless its code
+// contains a backtick fence itself, and no space before the language.
+func formatCodeBlock(block []string) []string {
+	m := openFenceRegex.FindStringSubmatch(block[0])
+	if m == nil {
+		return block
+	}
+	indent, marker, info := m[1], m[2], m[3]
+	if strings.HasPrefix(marker, "~") {
+		safe := !strings.Contains(info, "`")
+		for _, line := range block[1 : len(block)-1] {
+			if strings.Contains(line, "```") {
+				safe = false
+				break
+			}
+		}
+		if safe {
+			marker = strings.Repeat("`", len(marker))
+		}
+	}
+
+	out := make([]string, 0, len(block))
+	out = append(out, indent+marker+info)
+	out = append(out, block[1:len(block)-1]...)
+	return append(out, indent+marker)
+}
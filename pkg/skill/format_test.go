@@ -0,0 +1,134 @@
+package skill
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const messyDoc = "---\r\n" +
+	"version: 1.0.0\r\n" +
+	"name: 'pets'\r\n" +
+	"license: MIT\r\n" +
+	"allowed-tools: [Read, Grep]\r\n" +
+	"metadata:\r\n" +
+	"  owner: {team: pets, oncall: null}\r\n" +
+	"  ratio: 0.5\r\n" +
+	"---\r\n" +
+	"Manage pets.\r\n" +
+	"## Overview ##\r\n" +
+	"The pets API.\r\n" +
+	"\r\n" +
+	"\r\n" +
+	"\r\n" +
+	"* list pets\r\n" +
+	"  + nested\r\n" +
+	"* * *\r\n" +
+	"### Example\r\n" +
+	"~~~   bash\r\n" +
+	"curl /pets\r\n" +
+	"* not a list\r\n" +
+	"~~~\r\n"
+
+const tidyDoc = `---
+name: "pets"
+version: "1.0.0"
+allowed-tools:
+  - "Read"
+  - "Grep"
+license: "MIT"
+metadata:
+  owner:
+    oncall: null
+    team: "pets"
+  ratio: 0.5
+---
+
+Manage pets.
+
+## Overview
+
+The pets API.
+
+- list pets
+  - nested
+* * *
+
+### Example
+
+` + "```bash\ncurl /pets\n* not a list\n```\n"
+
+func TestFormat(t *testing.T) {
+	got, err := Format(messyDoc)
+	if err != nil {
+		t.Fatalf("format failed: %v", err)
+	}
+	if got != tidyDoc {
+		t.Errorf("unexpected output:\n%s\nwant:\n%s", got, tidyDoc)
+	}
+
+	again, err := Format(got)
+	if err != nil {
+		t.Fatalf("second format failed: %v", err)
+	}
+	if again != got {
+		t.Errorf("format is not idempotent:\n%s", again)
+	}
+}
+
+func TestFormat_Extensions(t *testing.T) {
+	s, err := Parse(tidyDoc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ext := s.Frontmatter.Extensions
+	if ext["license"] != "MIT" || len(ext) != 3 {
+		t.Fatalf("unexpected extensions: %#v", ext)
+	}
+	owner, ok := ext["metadata"].(map[string]interface{})["owner"].(map[string]interface{})
+	if !ok || owner["team"] != "pets" {
+		t.Errorf("expected nested maps with string keys, got %#v", ext["metadata"])
+	}
+	if !strings.Contains(Render(s), "license: \"MIT\"\n") {
+		t.Error("expected Render to keep extension keys")
+	}
+}
+
+func TestFormat_Errors(t *testing.T) {
+	if _, err := Format("# No frontmatter\n"); err == nil {
+		t.Error("expected an error without frontmatter")
+	}
+	if _, err := Format("---\nname: [pets\n---\n\n# Pets\n"); err == nil {
+		t.Error("expected an error for invalid frontmatter")
+	}
+}
+
+func TestFormatCodeBlock_KeepsTildesAroundBackticks(t *testing.T) {
+	block := []string{"~~~ markdown", "```go", "package main", "```", "~~~"}
+	got := strings.Join(formatCodeBlock(block), "\n")
+	if got != "~~~markdown\n```go\npackage main\n```\n~~~" {
+		t.Errorf("unexpected block:\n%s", got)
+	}
+}
+
+func TestFormat_Golden(t *testing.T) {
+	files, err := filepath.Glob("../../testdata/golden/*.md")
+	if err != nil || len(files) == 0 {
+		t.Fatalf("no golden files: %v", err)
+	}
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		formatted, err := Format(string(content))
+		if err != nil {
+			t.Errorf("%s: %v", filepath.Base(file), err)
+			continue
+		}
+		if again, _ := Format(formatted); again != formatted {
+			t.Errorf("%s: format is not idempotent", filepath.Base(file))
+		}
+	}
+}
@@ -0,0 +1,316 @@
+package skill
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Severity is how serious a lint finding is.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+	// SeverityOff disables a rule.
+	SeverityOff Severity = "off"
+)
+
+// Diagnostic is a problem found by a lint rule.
+type Diagnostic struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+	// Line is the 1-based line of the problem in the parsed file, 0 when
+	// unknown.
+	Line int `json:"line,omitempty"`
+	// Fixable reports whether the rule can fix the problem.
+	Fixable bool `json:"fixable,omitempty"`
+
+	// Where the problem is, used to work out Line: a section index plus one,
+	// a tool name or a frontmatter key.
+	section int
+	tool    string
+	field   string
+}
+
+// LintRule checks skills for one kind of problem.
+type LintRule interface {
+	// ID returns the rule ID used in reports and configuration.
+	ID() string
+	// Description says what the rule checks.
+	Description() string
+	// DefaultSeverity returns the severity used unless configured.
+	DefaultSeverity() Severity
+	// Check returns the problems found in s. Rule and Severity are filled
+	// in by the linter.
+	Check(s *Skill, opts RuleOptions) []Diagnostic
+}
+
+// LintFixer is implemented by rules that can fix the problems they find.
+type LintFixer interface {
+	// Fix repairs s in place and returns the number of problems fixed.
+	Fix(s *Skill, opts RuleOptions) int
+}
+
+// RuleOptions holds the settings of a rule other than its severity.
+type RuleOptions map[string]interface{}
+
+// Int returns an integer option, or def when it is unset or not a number.
+func (o RuleOptions) Int(key string, def int) int {
+	switch v := o[key].(type) {
+	case int:
+		return v
+	case float64:
+		return int(v)
+	}
+	return def
+}
+
+// Strings returns a list of strings option, or def when it is unset.
+func (o RuleOptions) Strings(key string, def []string) []string {
+	list, ok := o[key].([]interface{})
+	if !ok {
+		if strs, ok := o[key].([]string); ok {
+			return strs
+		}
+		return def
+	}
+	out := make([]string, 0, len(list))
+	for _, v := range list {
+		out = append(out, fmt.Sprint(v))
+	}
+	return out
+}
+
+// LintConfig configures the linter. It is the lint section of a
+// .skillmd.yaml file:
+//
+//	lint:
+//	  rules:
+//	    placeholder-text: off
+//	    oversized-section:
+//	      severity: error
+//	      max_tokens: 3000
+type LintConfig struct {
+	Rules map[string]RuleConfig `yaml:"rules" json:"rules"`
+}
+
+// RuleConfig overrides the severity of a rule and sets its options. In YAML
+// a rule can be given a severity alone.
+type RuleConfig struct {
+	Severity Severity    `json:"severity,omitempty"`
+	Options  RuleOptions `json:"options,omitempty"`
+}
+
+// UnmarshalYAML reads a rule configured as a severity or as a map of a
+// severity and options.
+func (c *RuleConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var severity string
+	if err := unmarshal(&severity); err == nil {
+		c.Severity = Severity(severity)
+		return nil
+	}
+
+	var raw map[string]interface{}
+	if err := unmarshal(&raw); err != nil {
+		return err
+	}
+	c.Options = RuleOptions{}
+	for key, value := range raw {
+		if key == "severity" {
+			c.Severity = Severity(fmt.Sprint(value))
+			continue
+		}
+		c.Options[key] = value
+	}
+	return nil
+}
+
+// Linter checks skills against a set of rules.
+type Linter struct {
+	rules  []LintRule
+	config LintConfig
+}
+
+// NewLinter creates a linter with all built-in rules, configured by cfg. A
+// nil cfg uses the default severities. It fails on unknown rule IDs and
+// severities.
+func NewLinter(cfg *LintConfig) (*Linter, error) {
+	l := &Linter{}
+	l.Register(requiredFieldsRule{})
+	l.Register(missingDescriptionRule{})
+	l.Register(toolNameRule{})
+	l.Register(parameterDescriptionRule{})
+	l.Register(headingLevelRule{})
+	l.Register(duplicateSectionRule{})
+	l.Register(orphanTextRule{})
+	l.Register(deadLinkRule{})
+	l.Register(codeBlockLanguageRule{})
+	l.Register(oversizedSectionRule{})
+	l.Register(placeholderTextRule{})
+
+	if cfg != nil {
+		for id, rc := range cfg.Rules {
+			if l.lookup(id) == nil {
+				return nil, fmt.Errorf("unknown lint rule: %s", id)
+			}
+			switch rc.Severity {
+			case "", SeverityError, SeverityWarning, SeverityInfo, SeverityOff:
+			default:
+				return nil, fmt.Errorf("invalid severity %q for lint rule %s", rc.Severity, id)
+			}
+		}
+		l.config = *cfg
+	}
+	return l, nil
+}
+
+// Register adds a rule to the linter.
+func (l *Linter) Register(r LintRule) {
+	l.rules = append(l.rules, r)
+}
+
+// Rules returns the registered rules.
+func (l *Linter) Rules() []LintRule {
+	return l.rules
+}
+
+// Severity returns the configured severity of a rule.
+func (l *Linter) Severity(r LintRule) Severity {
+	if rc, ok := l.config.Rules[r.ID()]; ok && rc.Severity != "" {
+		return rc.Severity
+	}
+	return r.DefaultSeverity()
+}
+
+func (l *Linter) lookup(id string) LintRule {
+	for _, r := range l.rules {
+		if r.ID() == id {
+			return r
+		}
+	}
+	return nil
+}
+
+// Lint checks s against every enabled rule and returns the problems found,
+// ordered by line.
+func (l *Linter) Lint(s *Skill) []Diagnostic {
+	loc := newLocator(s)
+	var diagnostics []Diagnostic
+	for _, r := range l.rules {
+		severity := l.Severity(r)
+		if severity == SeverityOff {
+			continue
+		}
+		_, fixable := r.(LintFixer)
+		for _, d := range r.Check(s, l.config.Rules[r.ID()].Options) {
+			d.Rule = r.ID()
+			d.Severity = severity
+			d.Fixable = fixable
+			if d.Line == 0 {
+				d.Line = loc.line(d)
+			}
+			diagnostics = append(diagnostics, d)
+		}
+	}
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		return diagnostics[i].Line < diagnostics[j].Line
+	})
+	return diagnostics
+}
+
+// Fix applies the fixes of every enabled rule that has them and returns the
+// number of problems fixed.
+func (l *Linter) Fix(s *Skill) int {
+	fixed := 0
+	for _, r := range l.rules {
+		fixer, ok := r.(LintFixer)
+		if !ok || l.Severity(r) == SeverityOff {
+			continue
+		}
+		fixed += fixer.Fix(s, l.config.Rules[r.ID()].Options)
+	}
+	return fixed
+}
+
+// Preamble returns the text between the frontmatter and the first heading,
+// which Render does not keep.
+func (s *Skill) Preamble() string {
+	var b strings.Builder
+	fence := ""
+	for _, line := range strings.Split(s.Content, "\n") {
+		if m := fenceRegex.FindStringSubmatch(line); m != nil {
+			switch fence {
+			case "":
+				fence = m[1]
+			case m[1]:
+				fence = ""
+			}
+		}
+		if fence == "" && headerRegex.MatchString(line) {
+			break
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// locator finds the lines of sections, tools and frontmatter keys in the
+// parsed file.
+type locator struct {
+	lines    []string
+	headings []int // line of each section's heading
+	fmEnd    int   // number of lines before the body
+}
+
+func newLocator(s *Skill) *locator {
+	loc := &locator{}
+	if s.Raw == "" || !strings.HasSuffix(s.Raw, s.Content) {
+		return loc
+	}
+	loc.lines = strings.Split(s.Raw, "\n")
+	loc.fmEnd = strings.Count(s.Raw[:len(s.Raw)-len(s.Content)], "\n")
+
+	fence := ""
+	for i, line := range strings.Split(s.Content, "\n") {
+		if m := fenceRegex.FindStringSubmatch(line); m != nil {
+			switch fence {
+			case "":
+				fence = m[1]
+			case m[1]:
+				fence = ""
+			}
+		}
+		if fence == "" && headerRegex.MatchString(line) {
+			loc.headings = append(loc.headings, loc.fmEnd+i+1)
+		}
+	}
+	return loc
+}
+
+func (loc *locator) line(d Diagnostic) int {
+	switch {
+	case d.section > 0 && d.section <= len(loc.headings):
+		return loc.headings[d.section-1]
+	case d.tool != "":
+		for i := 0; i < loc.fmEnd && i < len(loc.lines); i++ {
+			line := strings.TrimSpace(loc.lines[i])
+			if value, ok := strings.CutPrefix(line, "- name:"); ok && strings.Trim(strings.TrimSpace(value), `"'`) == d.tool {
+				return i + 1
+			}
+		}
+	case d.field != "":
+		for i := 0; i < loc.fmEnd && i < len(loc.lines); i++ {
+			if strings.HasPrefix(loc.lines[i], d.field+":") {
+				return i + 1
+			}
+		}
+		if loc.fmEnd > 0 {
+			return 1
+		}
+	}
+	return 0
+}
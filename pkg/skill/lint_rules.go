@@ -0,0 +1,490 @@
+package skill
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// requiredFieldsRule reports a missing name or version.
+type requiredFieldsRule struct{}
+
+func (requiredFieldsRule) ID() string                { return "required-fields" }
+func (requiredFieldsRule) Description() string       { return "The frontmatter has a name and a version" }
+func (requiredFieldsRule) DefaultSeverity() Severity { return SeverityError }
+
+func (requiredFieldsRule) Check(s *Skill, opts RuleOptions) []Diagnostic {
+	var ds []Diagnostic
+	if s.Frontmatter.Name == "" {
+		ds = append(ds, Diagnostic{Message: "missing required field: name", field: "name"})
+	}
+	if s.Frontmatter.Version == "" {
+		ds = append(ds, Diagnostic{Message: "missing required field: version", field: "version"})
+	}
+	return ds
+}
+
+// missingDescriptionRule reports skills without a description. The fix uses
+// the first paragraph of prose in the body.
+type missingDescriptionRule struct{}
+
+func (missingDescriptionRule) ID() string                { return "missing-description" }
+func (missingDescriptionRule) Description() string       { return "The frontmatter has a description" }
+func (missingDescriptionRule) DefaultSeverity() Severity { return SeverityWarning }
+
+func (missingDescriptionRule) Check(s *Skill, opts RuleOptions) []Diagnostic {
+	if strings.TrimSpace(s.Frontmatter.Description) != "" {
+		return nil
+	}
+	return []Diagnostic{{Message: "missing description: agents use it to decide when to load the skill", field: "description"}}
+}
+
+func (missingDescriptionRule) Fix(s *Skill, opts RuleOptions) int {
+	if strings.TrimSpace(s.Frontmatter.Description) != "" {
+		return 0
+	}
+	texts := []string{s.Preamble()}
+	for _, sec := range s.Sections {
+		texts = append(texts, sec.Content)
+	}
+	for _, text := range texts {
+		if para := leadParagraph(text); para != "" {
+			s.Frontmatter.Description = para
+			return 1
+		}
+	}
+	return 0
+}
+
+// leadParagraph returns the first paragraph of prose in text on one line.
+func leadParagraph(text string) string {
+	for _, para := range strings.Split(text, "\n\n") {
+		para = strings.TrimSpace(para)
+		if para == "" || strings.HasPrefix(para, "```") || strings.HasPrefix(para, "~~~") || strings.HasPrefix(para, "|") ||
+			strings.HasPrefix(para, "-") || strings.HasPrefix(para, "*") || strings.HasPrefix(para, ">") || strings.HasPrefix(para, "<") {
+			continue
+		}
+		return strings.Join(strings.Fields(para), " ")
+	}
+	return ""
+}
+
+// toolNameRule reports tool names MCP clients or the OpenAI API would reject.
+// The fix renames them with SanitizeToolName unless that would clash with
+// another tool.
+type toolNameRule struct{}
+
+func (toolNameRule) ID() string { return "tool-name" }
+func (toolNameRule) Description() string {
+	return "Tool names use only letters, digits, _ and -, up to 64 characters"
+}
+func (toolNameRule) DefaultSeverity() Severity { return SeverityWarning }
+
+func (toolNameRule) Check(s *Skill, opts RuleOptions) []Diagnostic {
+	var ds []Diagnostic
+	for i, tool := range s.Frontmatter.ToolDefinitions {
+		switch want := SanitizeToolName(tool.Name); {
+		case tool.Name == "":
+			ds = append(ds, Diagnostic{Message: fmt.Sprintf("tool %d has no name", i+1), field: "tools"})
+		case ValidToolName(tool.Name):
+		case want == "":
+			ds = append(ds, Diagnostic{Message: fmt.Sprintf("tool %q is not a valid tool name", tool.Name), tool: tool.Name})
+		default:
+			ds = append(ds, Diagnostic{Message: fmt.Sprintf("tool %q should be named %q", tool.Name, want), tool: tool.Name})
+		}
+	}
+	return ds
+}
+
+func (toolNameRule) Fix(s *Skill, opts RuleOptions) int {
+	taken := make(map[string]bool)
+	for _, tool := range s.Frontmatter.ToolDefinitions {
+		taken[tool.Name] = true
+	}
+	fixed := 0
+	for i := range s.Frontmatter.ToolDefinitions {
+		tool := &s.Frontmatter.ToolDefinitions[i]
+		want := SanitizeToolName(tool.Name)
+		if tool.Name == "" || ValidToolName(tool.Name) || want == "" || taken[want] {
+			continue
+		}
+		taken[want] = true
+		tool.Name = want
+		fixed++
+	}
+	return fixed
+}
+
+// parameterDescriptionRule reports tool parameters without a description.
+type parameterDescriptionRule struct{}
+
+func (parameterDescriptionRule) ID() string { return "tool-parameter-description" }
+func (parameterDescriptionRule) Description() string {
+	return "Every tool parameter has a description"
+}
+func (parameterDescriptionRule) DefaultSeverity() Severity { return SeverityWarning }
+
+func (parameterDescriptionRule) Check(s *Skill, opts RuleOptions) []Diagnostic {
+	var ds []Diagnostic
+	for _, tool := range s.Frontmatter.ToolDefinitions {
+		props, _ := tool.Parameters["properties"].(map[string]interface{})
+		names := make([]string, 0, len(props))
+		for name := range props {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			prop, _ := props[name].(map[string]interface{})
+			if desc, _ := prop["description"].(string); strings.TrimSpace(desc) == "" {
+				ds = append(ds, Diagnostic{
+					Message: fmt.Sprintf("parameter %q of tool %q has no description", name, tool.Name),
+					tool:    tool.Name,
+				})
+			}
+		}
+	}
+	return ds
+}
+
+// headingLevelRule reports headings that skip a level. The fix moves them
+// up to one level below the previous heading.
+type headingLevelRule struct{}
+
+func (headingLevelRule) ID() string                { return "heading-level" }
+func (headingLevelRule) Description() string       { return "Headings do not skip levels" }
+func (headingLevelRule) DefaultSeverity() Severity { return SeverityWarning }
+
+func (headingLevelRule) Check(s *Skill, opts RuleOptions) []Diagnostic {
+	var ds []Diagnostic
+	for i := 1; i < len(s.Sections); i++ {
+		prev, sec := s.Sections[i-1], s.Sections[i]
+		if sec.Level > prev.Level+1 {
+			ds = append(ds, Diagnostic{
+				Message: fmt.Sprintf("section %q skips heading level (h%d after h%d)", sec.Title, sec.Level, prev.Level),
+				section: i + 1,
+			})
+		}
+	}
+	return ds
+}
+
+func (headingLevelRule) Fix(s *Skill, opts RuleOptions) int {
+	fixed := 0
+	for i := 1; i < len(s.Sections); i++ {
+		if limit := s.Sections[i-1].Level + 1; s.Sections[i].Level > limit {
+			s.Sections[i].Level = limit
+			fixed++
+		}
+	}
+	return fixed
+}
+
+// duplicateSectionRule reports sections sharing a title with a sibling.
+type duplicateSectionRule struct{}
+
+func (duplicateSectionRule) ID() string { return "duplicate-section" }
+func (duplicateSectionRule) Description() string {
+	return "Sections under the same heading have distinct titles"
+}
+func (duplicateSectionRule) DefaultSeverity() Severity { return SeverityWarning }
+
+func (duplicateSectionRule) Check(s *Skill, opts RuleOptions) []Diagnostic {
+	var ds []Diagnostic
+	// Siblings are the sections at the same level since the last heading
+	// above that level
+	seen := make([]map[string]bool, 7)
+	for i, sec := range s.Sections {
+		for level := sec.Level + 1; level < len(seen); level++ {
+			seen[level] = nil
+		}
+		if seen[sec.Level] == nil {
+			seen[sec.Level] = make(map[string]bool)
+		}
+		key := strings.ToLower(strings.TrimSpace(sec.Title))
+		if seen[sec.Level][key] {
+			ds = append(ds, Diagnostic{Message: fmt.Sprintf("duplicate section %q", sec.Title), section: i + 1})
+		}
+		seen[sec.Level][key] = true
+	}
+	return ds
+}
+
+// orphanTextRule reports text before the first heading, which Render drops.
+// The fix moves it into an Overview section.
+type orphanTextRule struct{}
+
+func (orphanTextRule) ID() string { return "orphan-text" }
+func (orphanTextRule) Description() string {
+	return "All body text is under a heading"
+}
+func (orphanTextRule) DefaultSeverity() Severity { return SeverityWarning }
+
+func (orphanTextRule) Check(s *Skill, opts RuleOptions) []Diagnostic {
+	if s.Preamble() == "" {
+		return nil
+	}
+	loc := newLocator(s)
+	d := Diagnostic{Message: "text before the first heading is dropped when the skill is rendered"}
+	for i := loc.fmEnd; i < len(loc.lines); i++ {
+		if strings.TrimSpace(loc.lines[i]) != "" {
+			d.Line = i + 1
+			break
+		}
+	}
+	return []Diagnostic{d}
+}
+
+func (orphanTextRule) Fix(s *Skill, opts RuleOptions) int {
+	text := s.Preamble()
+	if text == "" {
+		return 0
+	}
+	if len(s.Sections) > 0 && strings.EqualFold(s.Sections[0].Title, "Overview") {
+		s.Sections[0].Content = strings.TrimSpace(text + "\n\n" + s.Sections[0].Content)
+	} else {
+		s.Sections = append([]Section{{Title: "Overview", Level: 2, Content: text}}, s.Sections...)
+	}
+	s.Content = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(s.Content), text))
+	return 1
+}
+
+// deadLinkRule reports links to anchors that no heading defines.
+type deadLinkRule struct{}
+
+func (deadLinkRule) ID() string                { return "dead-link" }
+func (deadLinkRule) Description() string       { return "Links to #anchors point at an existing heading" }
+func (deadLinkRule) DefaultSeverity() Severity { return SeverityError }
+
+var anchorLinkRegex = regexp.MustCompile(`\]\(#([^)\s]+)\)`)
+
+func (deadLinkRule) Check(s *Skill, opts RuleOptions) []Diagnostic {
+	anchors := make(map[string]bool)
+	counts := make(map[string]int)
+	for _, sec := range s.Sections {
+		slug := headingSlug(sec.Title)
+		if n := counts[slug]; n > 0 {
+			anchors[fmt.Sprintf("%s-%d", slug, n)] = true
+		} else {
+			anchors[slug] = true
+		}
+		counts[slug]++
+	}
+
+	var ds []Diagnostic
+	for i, sec := range s.Sections {
+		for _, line := range proseLines(sec.Content) {
+			for _, m := range anchorLinkRegex.FindAllStringSubmatch(line, -1) {
+				if !anchors[strings.ToLower(m[1])] {
+					ds = append(ds, Diagnostic{
+						Message: fmt.Sprintf("link to #%s in section %q matches no heading", m[1], sec.Title),
+						section: i + 1,
+					})
+				}
+			}
+		}
+	}
+	return ds
+}
+
+var slugStripRegex = regexp.MustCompile(`[^\p{L}\p{N}\s_-]`)
+
+// headingSlug returns the anchor GitHub generates for a heading.
+func headingSlug(title string) string {
+	slug := slugStripRegex.ReplaceAllString(strings.ToLower(strings.TrimSpace(title)), "")
+	return strings.ReplaceAll(slug, " ", "-")
+}
+
+// proseLines returns the lines of content outside fenced code blocks.
+func proseLines(content string) []string {
+	var lines []string
+	fence := ""
+	for _, line := range strings.Split(content, "\n") {
+		if m := fenceRegex.FindStringSubmatch(line); m != nil {
+			switch fence {
+			case "":
+				fence = m[1]
+			case m[1]:
+				fence = ""
+			}
+			continue
+		}
+		if fence == "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// codeBlockLanguageRule reports fenced code blocks without a language. The
+// fix guesses one from the first line of code, falling back to "text".
+type codeBlockLanguageRule struct{}
+
+func (codeBlockLanguageRule) ID() string { return "code-block-language" }
+func (codeBlockLanguageRule) Description() string {
+	return "Fenced code blocks declare a language"
+}
+func (codeBlockLanguageRule) DefaultSeverity() Severity { return SeverityWarning }
+
+// bareFenceRegex matches an opening fence without an info string.
+var bareFenceRegex = regexp.MustCompile("^(\\s*)(```|~~~)\\s*$")
+
+func (codeBlockLanguageRule) Check(s *Skill, opts RuleOptions) []Diagnostic {
+	var ds []Diagnostic
+	for i, sec := range s.Sections {
+		if n := len(bareFences(sec.Content)); n > 0 {
+			ds = append(ds, Diagnostic{
+				Message: fmt.Sprintf("section %q has code blocks without a language (%d)", sec.Title, n),
+				section: i + 1,
+			})
+		}
+	}
+	return ds
+}
+
+func (codeBlockLanguageRule) Fix(s *Skill, opts RuleOptions) int {
+	fixed := 0
+	for i := range s.Sections {
+		lines := strings.Split(s.Sections[i].Content, "\n")
+		for _, at := range bareFences(s.Sections[i].Content) {
+			first := ""
+			for _, line := range lines[at+1:] {
+				if line = strings.TrimSpace(line); line != "" {
+					first = line
+					break
+				}
+			}
+			lines[at] = strings.TrimRight(lines[at], " \t") + guessLanguage(first)
+			fixed++
+		}
+		s.Sections[i].Content = strings.Join(lines, "\n")
+	}
+	return fixed
+}
+
+// bareFences returns the line indexes of opening fences without a language.
+func bareFences(content string) []int {
+	var at []int
+	fence := ""
+	for i, line := range strings.Split(content, "\n") {
+		m := fenceRegex.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		switch fence {
+		case "":
+			fence = m[1]
+			if bareFenceRegex.MatchString(line) {
+				at = append(at, i)
+			}
+		case m[1]:
+			fence = ""
+		}
+	}
+	return at
+}
+
+// guessLanguage names the language of a code block from its first line.
+func guessLanguage(first string) string {
+	switch {
+	case strings.HasPrefix(first, "{") || strings.HasPrefix(first, "["):
+		return "json"
+	case strings.HasPrefix(first, "curl ") || strings.HasPrefix(first, "$ ") || strings.HasPrefix(first, "#!/bin/"):
+		return "bash"
+	case strings.HasPrefix(first, "<?xml") || strings.HasPrefix(first, "<soap"):
+		return "xml"
+	case strings.HasPrefix(first, "package ") && strings.Count(first, " ") == 1:
+		return "go"
+	case strings.HasPrefix(first, "import ") || strings.HasPrefix(first, "from ") || strings.HasPrefix(first, "def "):
+		return "python"
+	case strings.HasPrefix(first, "const ") || strings.HasPrefix(first, "await ") || strings.HasPrefix(first, "fetch("):
+		return "javascript"
+	case strings.HasPrefix(first, "query ") || strings.HasPrefix(first, "mutation ") || strings.HasPrefix(first, "subscription "):
+		return "graphql"
+	case strings.HasPrefix(first, "GET ") || strings.HasPrefix(first, "POST ") || strings.HasPrefix(first, "PUT ") ||
+		strings.HasPrefix(first, "PATCH ") || strings.HasPrefix(first, "DELETE "):
+		return "http"
+	}
+	return "text"
+}
+
+// oversizedSectionRule reports sections whose own content is over a token
+// budget, 2000 by default (option max_tokens).
+type oversizedSectionRule struct{}
+
+func (oversizedSectionRule) ID() string { return "oversized-section" }
+func (oversizedSectionRule) Description() string {
+	return "Sections stay under max_tokens estimated tokens (default 2000)"
+}
+func (oversizedSectionRule) DefaultSeverity() Severity { return SeverityWarning }
+
+func (oversizedSectionRule) Check(s *Skill, opts RuleOptions) []Diagnostic {
+	limit := opts.Int("max_tokens", 2000)
+	var ds []Diagnostic
+	for i, sec := range s.Sections {
+		if tokens := EstimateTokens(sec.Title + "\n" + sec.Content); tokens > limit {
+			ds = append(ds, Diagnostic{
+				Message: fmt.Sprintf("section %q is %d tokens, over the limit of %d", sec.Title, tokens, limit),
+				section: i + 1,
+			})
+		}
+	}
+	return ds
+}
+
+// placeholderTextRule reports placeholders left in code examples. The
+// patterns option replaces the default list.
+type placeholderTextRule struct{}
+
+func (placeholderTextRule) ID() string { return "placeholder-text" }
+func (placeholderTextRule) Description() string {
+	return "Code examples contain no placeholders such as YOUR_API_KEY"
+}
+func (placeholderTextRule) DefaultSeverity() Severity { return SeverityWarning }
+
+var defaultPlaceholders = []string{"YOUR_API_KEY", "YOUR_TOKEN", "YOUR_", "<your-", "TODO", "FIXME", "lorem ipsum"}
+
+func (placeholderTextRule) Check(s *Skill, opts RuleOptions) []Diagnostic {
+	patterns := opts.Strings("patterns", defaultPlaceholders)
+	var ds []Diagnostic
+	for i, sec := range s.Sections {
+		code := strings.ToLower(codeLines(sec.Content))
+		var found []string
+		for _, p := range patterns {
+			if p != "" && strings.Contains(code, strings.ToLower(p)) {
+				found = append(found, p)
+				// YOUR_API_KEY is also a YOUR_ match; report the specific one
+				code = strings.ReplaceAll(code, strings.ToLower(p), "")
+			}
+		}
+		if len(found) > 0 {
+			ds = append(ds, Diagnostic{
+				Message: fmt.Sprintf("code examples in section %q contain placeholders: %s", sec.Title, strings.Join(found, ", ")),
+				section: i + 1,
+			})
+		}
+	}
+	return ds
+}
+
+// codeLines returns the lines of content inside fenced code blocks.
+func codeLines(content string) string {
+	var b strings.Builder
+	fence := ""
+	for _, line := range strings.Split(content, "\n") {
+		if m := fenceRegex.FindStringSubmatch(line); m != nil {
+			switch fence {
+			case "":
+				fence = m[1]
+			case m[1]:
+				fence = ""
+			}
+			continue
+		}
+		if fence != "" {
+			b.WriteString(line)
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
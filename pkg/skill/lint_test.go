@@ -0,0 +1,218 @@
+package skill
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+const lintDoc = `---
+name: pets
+version: 1.0.0
+tools:
+  - name: Get Pet
+    description: Fetch a pet
+    parameters:
+      type: object
+      properties:
+        id:
+          type: string
+          description: Pet ID
+        verbose:
+          type: boolean
+---
+
+Manage pets.
+
+## Overview
+
+The pets API. See [auth](#authentication) and [errors](#errors).
+
+#### Example
+
+` + "```\ncurl -H \"Authorization: YOUR_API_KEY\" /pets/1\n```" + `
+
+## Authentication
+
+Use a key.
+
+## authentication
+
+Again.
+`
+
+func lintRules(ds []Diagnostic) string {
+	var out []string
+	for _, d := range ds {
+		out = append(out, d.Rule)
+	}
+	return strings.Join(out, ",")
+}
+
+func TestLinter_Lint(t *testing.T) {
+	s, err := Parse(lintDoc)
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	l, err := NewLinter(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ds := l.Lint(s)
+	want := "missing-description,tool-name,tool-parameter-description,orphan-text,dead-link,heading-level,code-block-language,placeholder-text,duplicate-section"
+	if got := lintRules(ds); got != want {
+		t.Fatalf("unexpected rules:\n%s\nwant:\n%s", got, want)
+	}
+
+	byRule := make(map[string]Diagnostic)
+	for _, d := range ds {
+		byRule[d.Rule] = d
+	}
+	lines := map[string]int{
+		"missing-description": 1, "tool-name": 5, "orphan-text": 17,
+		"dead-link": 19, "heading-level": 23, "duplicate-section": 33,
+	}
+	for rule, line := range lines {
+		if byRule[rule].Line != line {
+			t.Errorf("%s: expected line %d, got %d", rule, line, byRule[rule].Line)
+		}
+	}
+	if d := byRule["dead-link"]; d.Severity != SeverityError || !strings.Contains(d.Message, "#errors") {
+		t.Errorf("expected an error for the #errors link only, got %+v", d)
+	}
+	if d := byRule["placeholder-text"]; !strings.Contains(d.Message, "YOUR_API_KEY") || strings.Contains(d.Message, "YOUR_,") {
+		t.Errorf("expected YOUR_API_KEY to be reported once, got %q", d.Message)
+	}
+	if !byRule["tool-name"].Fixable || byRule["tool-parameter-description"].Fixable {
+		t.Error("expected only fixable rules to be marked fixable")
+	}
+}
+
+func TestLinter_Fix(t *testing.T) {
+	s, _ := Parse(lintDoc)
+	l, _ := NewLinter(nil)
+
+	if fixed := l.Fix(s); fixed != 5 {
+		t.Errorf("expected 5 fixes, got %d", fixed)
+	}
+	if s.Preamble() != "" {
+		t.Errorf("expected the preamble to be moved, got %q", s.Preamble())
+	}
+
+	fixed, err := Parse(Render(s))
+	if err != nil {
+		t.Fatalf("parse of fixed skill failed: %v", err)
+	}
+	if got := lintRules(l.Lint(fixed)); got != "tool-parameter-description,dead-link,placeholder-text,duplicate-section" {
+		t.Errorf("unexpected rules after fixing: %s", got)
+	}
+	if fixed.Frontmatter.Description != "Manage pets." || fixed.Frontmatter.ToolDefinitions[0].Name != "get_pet" {
+		t.Errorf("unexpected frontmatter: %+v", fixed.Frontmatter)
+	}
+	if fixed.Sections[0].Title != "Overview" || !strings.HasPrefix(fixed.Sections[0].Content, "Manage pets.\n\nThe pets API.") {
+		t.Errorf("expected the preamble at the start of the overview, got %q", fixed.Sections[0].Content)
+	}
+	if fixed.Sections[1].Level != 3 || !strings.Contains(fixed.Sections[1].Content, "```bash\n") {
+		t.Errorf("expected a level 3 example with a bash block, got %+v", fixed.Sections[1])
+	}
+}
+
+func TestLinter_Config(t *testing.T) {
+	var cfg struct {
+		Lint LintConfig `yaml:"lint"`
+	}
+	err := yaml.Unmarshal([]byte(`lint:
+  rules:
+    placeholder-text: off
+    duplicate-section: error
+    oversized-section:
+      severity: info
+      max_tokens: 10
+`), &cfg)
+	if err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+
+	l, err := NewLinter(&cfg.Lint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, _ := Parse(lintDoc)
+	var oversized int
+	for _, d := range l.Lint(s) {
+		switch d.Rule {
+		case "placeholder-text":
+			t.Error("expected placeholder-text to be off")
+		case "duplicate-section":
+			if d.Severity != SeverityError {
+				t.Errorf("expected duplicate-section to be an error, got %s", d.Severity)
+			}
+		case "oversized-section":
+			oversized++
+			if d.Severity != SeverityInfo {
+				t.Errorf("expected oversized-section to be info, got %s", d.Severity)
+			}
+		}
+	}
+	if oversized != 2 {
+		t.Errorf("expected two sections over 10 tokens, got %d", oversized)
+	}
+
+	if _, err := NewLinter(&LintConfig{Rules: map[string]RuleConfig{"no-such-rule": {}}}); err == nil {
+		t.Error("expected an error for an unknown rule")
+	}
+	if _, err := NewLinter(&LintConfig{Rules: map[string]RuleConfig{"dead-link": {Severity: "fatal"}}}); err == nil {
+		t.Error("expected an error for an unknown severity")
+	}
+}
+
+func TestGuessLanguage(t *testing.T) {
+	tests := map[string]string{
+		`{"id": 1}`:               "json",
+		"curl https://api.test":   "bash",
+		"<?xml version=\"1.0\"?>": "xml",
+		"package main":            "go",
+		"query { pets { id } }":   "graphql",
+		"GET /pets HTTP/1.1":      "http",
+		"hello":                   "text",
+	}
+	for first, want := range tests {
+		if got := guessLanguage(first); got != want {
+			t.Errorf("guessLanguage(%q) = %q, want %q", first, got, want)
+		}
+	}
+}
+
+func TestToolNameRule(t *testing.T) {
+	s := &Skill{Frontmatter: Frontmatter{ToolDefinitions: []ToolDefinition{
+		{Name: "mutation_createUser"},
+		{Name: "list-pets"},
+		{Name: "get users"},
+		{Name: "ns:op"},
+		{Name: strings.Repeat("a", 65)},
+	}}}
+	var flagged []string
+	for _, d := range (toolNameRule{}).Check(s, nil) {
+		flagged = append(flagged, d.tool)
+	}
+	if got := strings.Join(flagged, ","); got != "get users,ns:op,"+strings.Repeat("a", 65) {
+		t.Errorf("unexpected tools flagged: %s", got)
+	}
+
+	if n := (toolNameRule{}).Fix(s, nil); n != 3 {
+		t.Errorf("expected 3 renames, got %d", n)
+	}
+	for _, tool := range s.Frontmatter.ToolDefinitions {
+		if !ValidToolName(tool.Name) {
+			t.Errorf("tool %q is still invalid after fixing", tool.Name)
+		}
+	}
+	if got := s.Frontmatter.ToolDefinitions[0].Name; got != "mutation_createUser" {
+		t.Errorf("valid names should be kept, got %q", got)
+	}
+	if got := s.Frontmatter.ToolDefinitions[2].Name; got != "get_users" {
+		t.Errorf("expected get_users, got %q", got)
+	}
+}
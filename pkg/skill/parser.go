@@ -1,6 +1,7 @@
 package skill
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
 
@@ -10,7 +11,10 @@ import (
 	"github.com/yuin/goldmark/text"
 )
 
-var headerRegex = regexp.MustCompile(`^(#{1,6})\s+(.+)$`)
+var (
+	headerRegex = regexp.MustCompile(`^(#{1,6})\s+(.+)$`)
+	fenceRegex  = regexp.MustCompile("^\\s*(```|~~~)")
+)
 
 // CodeBlock represents a code block in the markdown.
 type CodeBlock struct {
@@ -18,7 +22,8 @@ type CodeBlock struct {
 	Content  string
 }
 
-// ParsedSection represents a section with richer parsed content.
+// ParsedSection represents a section with richer parsed content. Children
+// holds the sections nested under its heading.
 type ParsedSection struct {
 	Title      string
 	Level      int
@@ -27,6 +32,45 @@ type ParsedSection struct {
 	Children   []*ParsedSection
 }
 
+// BuildTree nests flat sections by heading level: each section becomes a
+// child of the closest preceding section with a lower level.
+func BuildTree(sections []Section) []*ParsedSection {
+	var roots []*ParsedSection
+	var stack []*ParsedSection
+
+	for _, sec := range sections {
+		node := &ParsedSection{Title: sec.Title, Level: sec.Level, Content: sec.Content}
+		for len(stack) > 0 && stack[len(stack)-1].Level >= sec.Level {
+			stack = stack[:len(stack)-1]
+		}
+		if len(stack) == 0 {
+			roots = append(roots, node)
+		} else {
+			parent := stack[len(stack)-1]
+			parent.Children = append(parent.Children, node)
+		}
+		stack = append(stack, node)
+	}
+
+	return roots
+}
+
+// FlattenTree lists a section tree in document order, the inverse of
+// BuildTree.
+func FlattenTree(tree []*ParsedSection) []Section {
+	var sections []Section
+	for _, node := range tree {
+		sections = append(sections, Section{Title: node.Title, Level: node.Level, Content: node.Content})
+		sections = append(sections, FlattenTree(node.Children)...)
+	}
+	return sections
+}
+
+// Tree returns the skill's sections nested by heading level.
+func (s *Skill) Tree() []*ParsedSection {
+	return BuildTree(s.Sections)
+}
+
 // Parse parses a SKILL.md file content into a Skill struct.
 func Parse(content string) (*Skill, error) {
 	skill := &Skill{Raw: content}
@@ -43,18 +87,66 @@ func Parse(content string) (*Skill, error) {
 	// Parse sections from content
 	skill.Sections = parseSections(skill.Content)
 
+	// Nested YAML maps decode with interface{} keys; make parameters and
+	// extensions JSON-friendly
+	for i := range skill.Frontmatter.ToolDefinitions {
+		tool := &skill.Frontmatter.ToolDefinitions[i]
+		for key, value := range tool.Parameters {
+			tool.Parameters[key] = normalizeYAML(value)
+		}
+	}
+	for key, value := range skill.Frontmatter.Extensions {
+		skill.Frontmatter.Extensions[key] = normalizeYAML(value)
+	}
+
 	return skill, nil
 }
 
-// parseSections extracts sections from markdown content.
+// normalizeYAML converts map[interface{}]interface{} values produced by the
+// YAML decoder into map[string]interface{}, recursively.
+func normalizeYAML(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(val))
+		for k, child := range val {
+			m[fmt.Sprint(k)] = normalizeYAML(child)
+		}
+		return m
+	case map[string]interface{}:
+		for k, child := range val {
+			val[k] = normalizeYAML(child)
+		}
+		return val
+	case []interface{}:
+		for i, child := range val {
+			val[i] = normalizeYAML(child)
+		}
+		return val
+	default:
+		return v
+	}
+}
+
+// parseSections extracts sections from markdown content. Lines inside fenced
+// code blocks are never headings.
 func parseSections(content string) []Section {
 	lines := strings.Split(content, "\n")
 	var sections []Section
 	var currentSection *Section
 	var contentBuilder strings.Builder
+	var fence string
 
 	for _, line := range lines {
-		if matches := headerRegex.FindStringSubmatch(line); matches != nil {
+		if m := fenceRegex.FindStringSubmatch(line); m != nil {
+			switch fence {
+			case "":
+				fence = m[1]
+			case m[1]:
+				fence = ""
+			}
+		}
+
+		if matches := headerRegex.FindStringSubmatch(line); matches != nil && fence == "" {
 			// Save previous section
 			if currentSection != nil {
 				currentSection.Content = strings.TrimSpace(contentBuilder.String())
@@ -127,7 +219,9 @@ func (p *ASTParser) ParseAST(content []byte) (*ParsedSkill, error) {
 	reader := text.NewReader(content)
 	doc := p.md.Parser().Parse(reader)
 
-	// Walk the AST
+	// Walk the AST, nesting each heading under the closest preceding heading
+	// with a lower level
+	var stack []*ParsedSection
 	ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
 		if !entering {
 			return ast.WalkContinue, nil
@@ -144,6 +238,17 @@ func (p *ASTParser) ParseAST(content []byte) (*ParsedSkill, error) {
 			}
 			result.Sections = append(result.Sections, section)
 
+			for len(stack) > 0 && stack[len(stack)-1].Level >= section.Level {
+				stack = stack[:len(stack)-1]
+			}
+			if len(stack) == 0 {
+				result.Tree = append(result.Tree, section)
+			} else {
+				parent := stack[len(stack)-1]
+				parent.Children = append(parent.Children, section)
+			}
+			stack = append(stack, section)
+
 		case *ast.FencedCodeBlock:
 			lang := string(node.Language(content))
 			code := extractCodeBlockContent(node, content)
@@ -169,8 +274,11 @@ func (p *ASTParser) ParseAST(content []byte) (*ParsedSkill, error) {
 // ParsedSkill represents a fully parsed SKILL.md with AST information.
 type ParsedSkill struct {
 	Frontmatter Frontmatter
-	Sections    []*ParsedSection
-	CodeBlocks  []CodeBlock
+	// Sections lists every heading in document order; Tree holds the
+	// top-level ones, with nested headings in their Children.
+	Sections   []*ParsedSection
+	Tree       []*ParsedSection
+	CodeBlocks []CodeBlock
 }
 
 // GetSectionsByLevel returns all sections at a specific level.
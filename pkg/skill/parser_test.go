@@ -0,0 +1,78 @@
+package skill
+
+import (
+	"strings"
+	"testing"
+)
+
+const treeDoc = `# API
+
+Intro.
+
+## GET /users
+
+### Example
+
+` + "```bash\n# list users\ncurl /users\n```" + `
+
+## GET /orders
+
+### Example
+
+Orders.
+`
+
+func TestParse_IgnoresHeadingsInCodeBlocks(t *testing.T) {
+	s, err := Parse(treeDoc)
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	if len(s.Sections) != 5 {
+		t.Fatalf("expected 5 sections, got %d", len(s.Sections))
+	}
+	if !strings.Contains(s.Sections[2].Content, "# list users") {
+		t.Errorf("expected code block comment to stay in the section body, got %q", s.Sections[2].Content)
+	}
+}
+
+func TestSkill_Tree(t *testing.T) {
+	s, _ := Parse(treeDoc)
+	tree := s.Tree()
+
+	if len(tree) != 1 || tree[0].Title != "API" {
+		t.Fatalf("expected a single root, got %d", len(tree))
+	}
+	endpoints := tree[0].Children
+	if len(endpoints) != 2 || endpoints[1].Title != "GET /orders" {
+		t.Fatalf("expected two endpoint children, got %d", len(endpoints))
+	}
+	if len(endpoints[1].Children) != 1 || endpoints[1].Children[0].Content != "Orders." {
+		t.Errorf("expected the orders example under its endpoint, got %+v", endpoints[1].Children)
+	}
+
+	flat := FlattenTree(tree)
+	if len(flat) != len(s.Sections) {
+		t.Fatalf("expected flatten to restore %d sections, got %d", len(s.Sections), len(flat))
+	}
+	for i := range flat {
+		if flat[i] != s.Sections[i] {
+			t.Errorf("section %d changed: %+v != %+v", i, flat[i], s.Sections[i])
+		}
+	}
+}
+
+func TestASTParser_FillsChildren(t *testing.T) {
+	parsed, err := NewASTParser().ParseAST([]byte(treeDoc))
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	if len(parsed.Sections) != 5 || len(parsed.Tree) != 1 {
+		t.Fatalf("expected 5 sections under 1 root, got %d and %d", len(parsed.Sections), len(parsed.Tree))
+	}
+	if got := len(parsed.Tree[0].Children); got != 2 {
+		t.Errorf("expected 2 children, got %d", got)
+	}
+	if code := parsed.Tree[0].Children[0].Children[0].CodeBlocks; len(code) != 1 {
+		t.Errorf("expected the code block on the nested example, got %d", len(code))
+	}
+}
@@ -2,36 +2,178 @@ package skill
 
 import (
 	"fmt"
+	"regexp"
+	"sort"
 	"strings"
 )
 
-// renderParameters recursively renders JSON Schema parameters as YAML
+// schemaKeywordOrder is the order JSON Schema keywords are rendered in.
+// Keywords not listed follow in alphabetical order.
+var schemaKeywordOrder = []string{
+	"$schema", "$id", "$ref", "title", "type", "format", "description",
+	"enum", "const", "default", "example", "examples", "items", "properties",
+	"required", "additionalProperties", "minimum", "maximum", "minLength",
+	"maxLength", "pattern", "minItems", "maxItems",
+}
+
+var schemaKeywordRank = func() map[string]int {
+	rank := make(map[string]int, len(schemaKeywordOrder))
+	for i, key := range schemaKeywordOrder {
+		rank[key] = i
+	}
+	return rank
+}()
+
+// schemaNameMaps are the keywords whose values map names to schemas; their
+// keys are property names rather than keywords.
+var schemaNameMaps = map[string]bool{
+	"properties": true, "patternProperties": true, "definitions": true, "$defs": true,
+}
+
+// yamlMode says how the keys of a rendered map are ordered and what its
+// values are.
+type yamlMode int
+
+const (
+	// plainMap keys are in alphabetical order.
+	plainMap yamlMode = iota
+	// schemaMap is a JSON Schema: keywords first, in schemaKeywordOrder.
+	schemaMap
+	// schemaNames maps names, such as property names, to schemas.
+	schemaNames
+)
+
+// child returns the mode of the map under key.
+func (m yamlMode) child(key string) yamlMode {
+	switch {
+	case m == plainMap:
+		return plainMap
+	case m == schemaMap && schemaNameMaps[key]:
+		return schemaNames
+	}
+	return schemaMap
+}
+
+// sortedKeys returns the keys of params in rendering order: keywords first
+// when params is a schema, alphabetical otherwise.
+func sortedKeys(params map[string]interface{}, schema bool) []string {
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if schema {
+			ri, iok := schemaKeywordRank[keys[i]]
+			rj, jok := schemaKeywordRank[keys[j]]
+			if iok != jok {
+				return iok
+			}
+			if iok && ri != rj {
+				return ri < rj
+			}
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+// renderParameters recursively renders JSON Schema parameters as YAML, in a
+// stable key order.
 func renderParameters(b *strings.Builder, params map[string]interface{}, indent string) {
-	for key, value := range params {
-		switch v := value.(type) {
-		case string:
-			b.WriteString(fmt.Sprintf("%s%s: %q\n", indent, key, v))
-		case int, int64, float64:
-			b.WriteString(fmt.Sprintf("%s%s: %v\n", indent, key, v))
-		case bool:
-			b.WriteString(fmt.Sprintf("%s%s: %t\n", indent, key, v))
-		case []interface{}:
-			b.WriteString(fmt.Sprintf("%s%s:\n", indent, key))
+	renderYAMLMap(b, params, indent, schemaMap)
+}
+
+func renderYAMLMap(b *strings.Builder, m map[string]interface{}, indent string, mode yamlMode) {
+	for _, key := range sortedKeys(m, mode == schemaMap) {
+		name := yamlKey(key)
+		switch v := m[key].(type) {
+		case []string:
+			if len(v) == 0 {
+				b.WriteString(fmt.Sprintf("%s%s: []\n", indent, name))
+				continue
+			}
+			b.WriteString(fmt.Sprintf("%s%s:\n", indent, name))
 			for _, item := range v {
-				if str, ok := item.(string); ok {
-					b.WriteString(fmt.Sprintf("%s  - %q\n", indent, str))
-				} else if m, ok := item.(map[string]interface{}); ok {
-					b.WriteString(fmt.Sprintf("%s  -\n", indent))
-					renderParameters(b, m, indent+"    ")
-				}
+				b.WriteString(fmt.Sprintf("%s  - %q\n", indent, item))
+			}
+		case []interface{}:
+			if len(v) == 0 {
+				b.WriteString(fmt.Sprintf("%s%s: []\n", indent, name))
+				continue
+			}
+			b.WriteString(fmt.Sprintf("%s%s:\n", indent, name))
+			renderYAMLList(b, v, indent+"  ", mode)
+		case map[string]interface{}:
+			// An empty map would read back as null
+			if len(v) == 0 {
+				b.WriteString(fmt.Sprintf("%s%s: {}\n", indent, name))
+				continue
 			}
+			b.WriteString(fmt.Sprintf("%s%s:\n", indent, name))
+			renderYAMLMap(b, v, indent+"  ", mode.child(key))
+		default:
+			if scalar, ok := yamlScalar(v); ok {
+				b.WriteString(fmt.Sprintf("%s%s: %s\n", indent, name, scalar))
+			}
+		}
+	}
+}
+
+func renderYAMLList(b *strings.Builder, items []interface{}, indent string, mode yamlMode) {
+	for _, item := range items {
+		switch v := item.(type) {
 		case map[string]interface{}:
-			b.WriteString(fmt.Sprintf("%s%s:\n", indent, key))
-			renderParameters(b, v, indent+"  ")
+			if len(v) == 0 {
+				b.WriteString(indent + "- {}\n")
+				continue
+			}
+			b.WriteString(indent + "-\n")
+			renderYAMLMap(b, v, indent+"  ", mode)
+		case []interface{}:
+			if len(v) == 0 {
+				b.WriteString(indent + "- []\n")
+				continue
+			}
+			b.WriteString(indent + "-\n")
+			renderYAMLList(b, v, indent+"  ", mode)
+		default:
+			if scalar, ok := yamlScalar(v); ok {
+				b.WriteString(fmt.Sprintf("%s- %s\n", indent, scalar))
+			}
 		}
 	}
 }
 
+// yamlScalar renders a scalar value, reporting false for other values.
+func yamlScalar(v interface{}) (string, bool) {
+	switch val := v.(type) {
+	case nil:
+		return "null", true
+	case string:
+		return fmt.Sprintf("%q", val), true
+	case bool:
+		return fmt.Sprintf("%t", val), true
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return fmt.Sprintf("%v", val), true
+	}
+	return "", false
+}
+
+// plainKeyRegex matches keys that need no quotes in YAML.
+var plainKeyRegex = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$./-]*$`)
+
+// yamlKey quotes a map key unless it reads back as the same string.
+func yamlKey(key string) string {
+	switch strings.ToLower(key) {
+	case "y", "n", "yes", "no", "on", "off", "true", "false", "null":
+		return fmt.Sprintf("%q", key)
+	}
+	if plainKeyRegex.MatchString(key) {
+		return key
+	}
+	return fmt.Sprintf("%q", key)
+}
+
 // Render generates the SKILL.md content from a Skill struct.
 func Render(s *Skill) string {
 	if s == nil {
@@ -96,6 +238,40 @@ func Render(s *Skill) string {
 		for _, tool := range s.Frontmatter.ToolDefinitions {
 			b.WriteString(fmt.Sprintf("  - name: %q\n", tool.Name))
 			b.WriteString(fmt.Sprintf("    description: %q\n", tool.Description))
+			if tool.Method != "" {
+				b.WriteString(fmt.Sprintf("    method: %q\n", tool.Method))
+			}
+			if tool.Path != "" {
+				b.WriteString(fmt.Sprintf("    path: %q\n", tool.Path))
+			}
+			if tool.GraphQL != "" {
+				b.WriteString(fmt.Sprintf("    graphql: %q\n", tool.GraphQL))
+			}
+			if tool.BaseURL != "" {
+				b.WriteString(fmt.Sprintf("    base_url: %q\n", tool.BaseURL))
+			}
+			if len(tool.Location) > 0 {
+				b.WriteString("    location:\n")
+				args := make([]string, 0, len(tool.Location))
+				for arg := range tool.Location {
+					args = append(args, arg)
+				}
+				sort.Strings(args)
+				for _, arg := range args {
+					b.WriteString(fmt.Sprintf("      %q: %q\n", arg, tool.Location[arg]))
+				}
+			}
+			if len(tool.ParamNames) > 0 {
+				b.WriteString("    param_names:\n")
+				args := make([]string, 0, len(tool.ParamNames))
+				for arg := range tool.ParamNames {
+					args = append(args, arg)
+				}
+				sort.Strings(args)
+				for _, arg := range args {
+					b.WriteString(fmt.Sprintf("      %q: %q\n", arg, tool.ParamNames[arg]))
+				}
+			}
 			if len(tool.Parameters) > 0 {
 				b.WriteString("    parameters:\n")
 				renderParameters(&b, tool.Parameters, "      ")
@@ -154,6 +330,8 @@ func Render(s *Skill) string {
 			b.WriteString(fmt.Sprintf("  - %q\n", server))
 		}
 	}
+	// Extension keys follow in alphabetical order
+	renderYAMLMap(&b, s.Frontmatter.Extensions, "", plainMap)
 	b.WriteString("---\n\n")
 
 	// Write sections
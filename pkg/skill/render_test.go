@@ -187,6 +187,8 @@ func TestRender_MCPCompatible(t *testing.T) {
 						},
 					},
 					Required: []string{"limit"},
+					Method:   "GET",
+					Path:     "/users",
 				},
 			},
 			MaxTokensPerCall: 4096,
@@ -204,6 +206,9 @@ func TestRender_MCPCompatible(t *testing.T) {
 	if !strings.Contains(result, `name: "get_users"`) {
 		t.Error("expected tool name in output")
 	}
+	if !strings.Contains(result, `method: "GET"`) || !strings.Contains(result, `path: "/users"`) {
+		t.Error("expected tool method and path in output")
+	}
 	if !strings.Contains(result, "max_tokens_per_call: 4096") {
 		t.Error("expected max_tokens_per_call in output")
 	}
@@ -459,3 +464,71 @@ func TestRenderParameters(t *testing.T) {
 		t.Error("expected properties in parameters")
 	}
 }
+
+func TestRenderParameters_StableOrder(t *testing.T) {
+	s := &Skill{
+		Frontmatter: Frontmatter{
+			Name: "Test",
+			ToolDefinitions: []ToolDefinition{{
+				Name: "search",
+				Parameters: map[string]interface{}{
+					"required": []string{"query"},
+					"properties": map[string]interface{}{
+						"type":  map[string]interface{}{"enum": []interface{}{"a", "b"}, "type": "string"},
+						"query": map[string]interface{}{"description": "Search terms", "type": "string", "minLength": 1},
+					},
+					"type": "object",
+				},
+			}},
+		},
+	}
+
+	want := `    parameters:
+      type: "object"
+      properties:
+        query:
+          type: "string"
+          description: "Search terms"
+          minLength: 1
+        type:
+          type: "string"
+          enum:
+            - "a"
+            - "b"
+      required:
+        - "query"
+`
+	for i := 0; i < 10; i++ {
+		if result := Render(s); !strings.Contains(result, want) {
+			t.Fatalf("expected keywords in schema order and properties sorted by name, got:\n%s", result)
+		}
+	}
+}
+
+func TestRenderParameters_EmptyValues(t *testing.T) {
+	s := &Skill{
+		Frontmatter: Frontmatter{
+			Name: "Test",
+			ToolDefinitions: []ToolDefinition{{
+				Name: "ping",
+				Parameters: map[string]interface{}{
+					"type":       "object",
+					"properties": map[string]interface{}{},
+					"required":   []interface{}{},
+				},
+			}},
+		},
+	}
+
+	parsed, err := Parse(Render(s))
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	params := parsed.Frontmatter.ToolDefinitions[0].Parameters
+	if props, ok := params["properties"].(map[string]interface{}); !ok || len(props) != 0 {
+		t.Errorf("expected empty properties to round-trip, got %#v", params["properties"])
+	}
+	if required, ok := params["required"].([]interface{}); !ok || len(required) != 0 {
+		t.Errorf("expected an empty required list to round-trip, got %#v", params["required"])
+	}
+}
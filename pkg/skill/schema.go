@@ -0,0 +1,329 @@
+package skill
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FrontmatterSchema is the JSON Schema of SKILL.md frontmatter. It describes
+// the fields of Frontmatter; TestFrontmatterSchema_InSync keeps the two
+// aligned.
+//
+//go:embed schema.json
+var FrontmatterSchema []byte
+
+// frontmatterSchema is FrontmatterSchema parsed for validation.
+var frontmatterSchema = mustParseSchema(FrontmatterSchema)
+
+// SchemaError is a frontmatter value that does not match the schema.
+type SchemaError struct {
+	// Path locates the value, such as tools[0].parameters.type.
+	Path    string `json:"path,omitempty"`
+	Message string `json:"message"`
+	// Line and Column are 1-based positions in the SKILL.md file.
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+func (e SchemaError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("%d:%d: %s", e.Line, e.Column, e.Message)
+	}
+	return fmt.Sprintf("%d:%d: %s: %s", e.Line, e.Column, e.Path, e.Message)
+}
+
+// schemaNode is the subset of JSON Schema used by FrontmatterSchema.
+type schemaNode struct {
+	Ref                  string                 `json:"$ref"`
+	Type                 string                 `json:"type"`
+	Enum                 []interface{}          `json:"enum"`
+	Properties           map[string]*schemaNode `json:"properties"`
+	AdditionalProperties *additionalProperties  `json:"additionalProperties"`
+	Required             []string               `json:"required"`
+	Items                *schemaNode            `json:"items"`
+	AnyOf                []*schemaNode          `json:"anyOf"`
+	Minimum              *float64               `json:"minimum"`
+	MinLength            *int                   `json:"minLength"`
+	Format               string                 `json:"format"`
+	Defs                 map[string]*schemaNode `json:"$defs"`
+}
+
+// additionalProperties is false, true or a schema for the values of keys
+// not listed in properties.
+type additionalProperties struct {
+	closed bool
+	schema *schemaNode
+}
+
+func (a *additionalProperties) UnmarshalJSON(data []byte) error {
+	switch string(data) {
+	case "false":
+		a.closed = true
+		return nil
+	case "true":
+		return nil
+	}
+	a.schema = &schemaNode{}
+	return json.Unmarshal(data, a.schema)
+}
+
+func mustParseSchema(data []byte) *schemaNode {
+	var s schemaNode
+	if err := json.Unmarshal(data, &s); err != nil {
+		panic(fmt.Sprintf("skill: invalid frontmatter schema: %v", err))
+	}
+	return &s
+}
+
+// yamlLineRegex matches the line numbers in YAML syntax errors.
+var yamlLineRegex = regexp.MustCompile(`line (\d+)`)
+
+// ValidateSchema checks the frontmatter of a SKILL.md file against
+// FrontmatterSchema, which is stricter than Parse: it enforces value types,
+// enums such as difficulty and tools whose parameters are JSON Schemas. It
+// returns an error when the file has no frontmatter or the frontmatter is
+// not valid YAML.
+func ValidateSchema(content string) ([]SchemaError, error) {
+	lines := strings.Split(strings.TrimPrefix(content, "\ufeff"), "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return nil, errors.New("no YAML frontmatter")
+	}
+	end := -1
+	for i := 1; i < len(lines); i++ {
+		if line := strings.TrimSpace(lines[i]); line == "---" || line == "..." {
+			end = i
+			break
+		}
+	}
+	if end < 0 {
+		return nil, errors.New("frontmatter is not closed with ---")
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(strings.Join(lines[1:end], "\n")), &doc); err != nil {
+		// The frontmatter starts on line 2 of the file
+		msg := yamlLineRegex.ReplaceAllStringFunc(err.Error(), func(m string) string {
+			n, _ := strconv.Atoi(strings.TrimPrefix(m, "line "))
+			return fmt.Sprintf("line %d", n+1)
+		})
+		return nil, fmt.Errorf("invalid frontmatter: %s", msg)
+	}
+	if len(doc.Content) == 0 {
+		return []SchemaError{{Message: "frontmatter is empty", Line: 1, Column: 1}}, nil
+	}
+
+	v := &schemaValidator{root: frontmatterSchema, lineOffset: 1}
+	v.validate(frontmatterSchema, doc.Content[0], "")
+	sort.SliceStable(v.errs, func(i, j int) bool {
+		if v.errs[i].Line != v.errs[j].Line {
+			return v.errs[i].Line < v.errs[j].Line
+		}
+		return v.errs[i].Column < v.errs[j].Column
+	})
+	return v.errs, nil
+}
+
+// schemaValidator checks a YAML tree against a schema, collecting errors.
+type schemaValidator struct {
+	root       *schemaNode
+	lineOffset int
+	errs       []SchemaError
+}
+
+func (v *schemaValidator) fail(n *yaml.Node, path, format string, args ...interface{}) {
+	v.errs = append(v.errs, SchemaError{
+		Path:    path,
+		Message: fmt.Sprintf(format, args...),
+		Line:    n.Line + v.lineOffset,
+		Column:  n.Column,
+	})
+}
+
+func (v *schemaValidator) validate(s *schemaNode, n *yaml.Node, path string) {
+	if n.Kind == yaml.AliasNode && n.Alias != nil {
+		n = n.Alias
+	}
+	if s.Ref != "" {
+		s = v.root.Defs[strings.TrimPrefix(s.Ref, "#/$defs/")]
+		if s == nil {
+			return
+		}
+	}
+
+	kind := yamlType(n)
+	if len(s.AnyOf) > 0 {
+		v.validateAnyOf(s.AnyOf, n, path, kind)
+		return
+	}
+
+	if s.Type != "" && s.Type != kind && !(s.Type == "number" && kind == "integer") {
+		v.fail(n, path, "expected %s, got %s", s.Type, kind)
+		return
+	}
+	if len(s.Enum) > 0 && !enumContains(s.Enum, n, kind) {
+		allowed := make([]string, len(s.Enum))
+		for i, e := range s.Enum {
+			allowed[i] = fmt.Sprint(e)
+		}
+		v.fail(n, path, "must be one of %s (got %q)", strings.Join(allowed, ", "), n.Value)
+		return
+	}
+
+	switch n.Kind {
+	case yaml.ScalarNode:
+		v.validateScalar(s, n, path, kind)
+	case yaml.MappingNode:
+		v.validateObject(s, n, path)
+	case yaml.SequenceNode:
+		if s.Items != nil {
+			for i, item := range n.Content {
+				v.validate(s.Items, item, fmt.Sprintf("%s[%d]", path, i))
+			}
+		}
+	}
+}
+
+// validateAnyOf passes when n matches one of the branches. Otherwise it
+// reports the errors of the first branch that accepts n's type, or the
+// types the branches accept.
+func (v *schemaValidator) validateAnyOf(branches []*schemaNode, n *yaml.Node, path, kind string) {
+	var report []SchemaError
+	var expected []string
+	for _, branch := range branches {
+		sub := &schemaValidator{root: v.root, lineOffset: v.lineOffset}
+		sub.validate(branch, n, path)
+		if len(sub.errs) == 0 {
+			return
+		}
+
+		if branch.Ref != "" {
+			branch = v.root.Defs[strings.TrimPrefix(branch.Ref, "#/$defs/")]
+		}
+		switch {
+		case branch.Type != "":
+			expected = append(expected, branch.Type)
+			if branch.Type != kind && !(branch.Type == "number" && kind == "integer") {
+				continue
+			}
+		case len(branch.Enum) > 0:
+			expected = append(expected, "string")
+			if n.Kind != yaml.ScalarNode {
+				continue
+			}
+		}
+		if report == nil {
+			report = sub.errs
+		}
+	}
+
+	if report == nil {
+		v.fail(n, path, "expected %s, got %s", strings.Join(expected, " or "), kind)
+		return
+	}
+	v.errs = append(v.errs, report...)
+}
+
+func (v *schemaValidator) validateScalar(s *schemaNode, n *yaml.Node, path, kind string) {
+	if s.MinLength != nil && kind == "string" && utf8.RuneCountInString(n.Value) < *s.MinLength {
+		if *s.MinLength == 1 {
+			v.fail(n, path, "must not be empty")
+		} else {
+			v.fail(n, path, "must be at least %d characters", *s.MinLength)
+		}
+	}
+	if s.Minimum != nil && (kind == "integer" || kind == "number") {
+		if f, err := strconv.ParseFloat(n.Value, 64); err == nil && f < *s.Minimum {
+			v.fail(n, path, "must be at least %v", *s.Minimum)
+		}
+	}
+	if kind != "string" {
+		return
+	}
+	switch s.Format {
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339, n.Value); err != nil {
+			v.fail(n, path, "must be an RFC 3339 date-time (got %q)", n.Value)
+		}
+	case "uri":
+		if u, err := url.Parse(n.Value); err != nil || u.Scheme == "" || u.Host == "" {
+			v.fail(n, path, "must be an absolute URL (got %q)", n.Value)
+		}
+	}
+}
+
+func (v *schemaValidator) validateObject(s *schemaNode, n *yaml.Node, path string) {
+	keys := make(map[string]bool)
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, value := n.Content[i], n.Content[i+1]
+		keys[key.Value] = true
+		keyPath := key.Value
+		if path != "" {
+			keyPath = path + "." + key.Value
+		}
+
+		switch prop, ok := s.Properties[key.Value]; {
+		case ok:
+			v.validate(prop, value, keyPath)
+		case s.AdditionalProperties == nil:
+		case s.AdditionalProperties.closed:
+			v.fail(key, keyPath, "unknown property")
+		case s.AdditionalProperties.schema != nil:
+			v.validate(s.AdditionalProperties.schema, value, keyPath)
+		}
+	}
+
+	for _, name := range s.Required {
+		if !keys[name] {
+			v.fail(n, path, "missing required property %q", name)
+		}
+	}
+}
+
+// yamlType returns the JSON Schema type of a YAML node.
+func yamlType(n *yaml.Node) string {
+	switch n.Kind {
+	case yaml.MappingNode:
+		return "object"
+	case yaml.SequenceNode:
+		return "array"
+	}
+	switch n.ShortTag() {
+	case "!!int":
+		return "integer"
+	case "!!float":
+		return "number"
+	case "!!bool":
+		return "boolean"
+	case "!!null":
+		return "null"
+	}
+	return "string"
+}
+
+// enumContains reports whether a scalar node is one of the enum values.
+func enumContains(enum []interface{}, n *yaml.Node, kind string) bool {
+	if n.Kind != yaml.ScalarNode {
+		return false
+	}
+	for _, e := range enum {
+		if str, ok := e.(string); ok {
+			if kind == "string" && n.Value == str {
+				return true
+			}
+		} else if kind != "string" && fmt.Sprint(e) == n.Value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,148 @@
+package skill
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// yamlKeys returns the YAML keys of a struct type's fields.
+func yamlKeys(t reflect.Type) []string {
+	var keys []string
+	for i := 0; i < t.NumField(); i++ {
+		tag := strings.Split(t.Field(i).Tag.Get("yaml"), ",")[0]
+		if tag != "" && tag != "-" {
+			keys = append(keys, tag)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func propertyNames(s *schemaNode) []string {
+	var keys []string
+	for key := range s.Properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestFrontmatterSchema_InSync(t *testing.T) {
+	var doc map[string]interface{}
+	if err := json.Unmarshal(FrontmatterSchema, &doc); err != nil {
+		t.Fatalf("schema is not valid JSON: %v", err)
+	}
+
+	types := map[*schemaNode]reflect.Type{
+		frontmatterSchema:                       reflect.TypeOf(Frontmatter{}),
+		frontmatterSchema.Defs["tool"]:          reflect.TypeOf(ToolDefinition{}),
+		frontmatterSchema.Defs["retryStrategy"]: reflect.TypeOf(RetryStrategy{}),
+		frontmatterSchema.Defs["rateLimits"]:    reflect.TypeOf(RateLimitInfo{}),
+	}
+	for node, typ := range types {
+		if node == nil {
+			t.Fatalf("schema has no definition for %s", typ.Name())
+		}
+		if got, want := propertyNames(node), yamlKeys(typ); !reflect.DeepEqual(got, want) {
+			t.Errorf("schema properties for %s are out of sync:\n got %v\nwant %v", typ.Name(), got, want)
+		}
+	}
+}
+
+func TestValidateSchema(t *testing.T) {
+	content := `---
+name: pets
+version: 1.0
+difficulty: expert
+endpoint_count: many
+base_url: api.example.com
+created_at: yesterday
+retry_strategy:
+  max_retries: -1
+  backoff_type: random
+tools:
+  - name: get_pet
+    params: {}
+    method: FETCH
+    parameters:
+      type: text
+      properties:
+        id:
+          type: [string, uuid]
+    location:
+      id: cookie
+license: MIT
+---
+
+# Pets
+`
+	errs, err := ValidateSchema(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, e := range errs {
+		got = append(got, e.Error())
+	}
+	want := []string{
+		`3:10: version: expected string, got number`,
+		`4:13: difficulty: must be one of novice, intermediate, advanced (got "expert")`,
+		`5:17: endpoint_count: expected integer, got string`,
+		`6:11: base_url: must be an absolute URL (got "api.example.com")`,
+		`7:13: created_at: must be an RFC 3339 date-time (got "yesterday")`,
+		`9:16: retry_strategy.max_retries: must be at least 0`,
+		`10:17: retry_strategy.backoff_type: must be one of linear, exponential (got "random")`,
+		`13:5: tools[0].params: unknown property`,
+		`14:13: tools[0].method: must be one of GET, POST, PUT, PATCH, DELETE, HEAD, OPTIONS, TRACE (got "FETCH")`,
+		`16:13: tools[0].parameters.type: must be one of string, number, integer, boolean, object, array, null (got "text")`,
+		`19:26: tools[0].parameters.properties.id.type[1]: must be one of string, number, integer, boolean, object, array, null (got "uuid")`,
+		`21:11: tools[0].location.id: must be one of path, query, header, body, form, multipart (got "cookie")`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected errors:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestValidateSchema_Errors(t *testing.T) {
+	errs, err := ValidateSchema("---\nversion: 1.0.0\n---\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) != 1 || errs[0].Error() != `2:1: missing required property "name"` {
+		t.Errorf("expected a missing name at 2:1, got %v", errs)
+	}
+
+	if _, err := ValidateSchema("# No frontmatter\n"); err == nil {
+		t.Error("expected an error without frontmatter")
+	}
+	_, err = ValidateSchema("---\nname: pets\ntags: a\n  b: c\n---\n")
+	if err == nil || !strings.Contains(err.Error(), "line 4") {
+		t.Errorf("expected a YAML error at line 4 of the file, got %v", err)
+	}
+}
+
+func TestValidateSchema_Golden(t *testing.T) {
+	files, err := filepath.Glob("../../testdata/golden/*.md")
+	if err != nil || len(files) == 0 {
+		t.Fatalf("no golden files: %v", err)
+	}
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		errs, err := ValidateSchema(string(content))
+		if err != nil {
+			t.Errorf("%s: %v", filepath.Base(file), err)
+		}
+		for _, e := range errs {
+			t.Errorf("%s: %s", filepath.Base(file), e)
+		}
+	}
+}
@@ -0,0 +1,301 @@
+package skill
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// EstimateTokens approximates how many LLM tokens text uses, without a
+// tokenizer or network access. Words cost one token per four characters and
+// every other symbol costs one, which tracks BPE tokenizers closely enough on
+// prose, markdown and code for budgeting.
+func EstimateTokens(text string) int {
+	tokens := 0
+	word := 0
+	flush := func() {
+		if word > 0 {
+			tokens += (word + 3) / 4
+			word = 0
+		}
+	}
+
+	for _, r := range text {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			word++
+		case unicode.IsSpace(r):
+			flush()
+		default:
+			flush()
+			tokens++
+		}
+	}
+	flush()
+
+	return tokens
+}
+
+// SectionTokens is the token estimate of one section. Tokens counts the
+// heading and body; Cumulative adds the sections nested under it.
+type SectionTokens struct {
+	Title      string `json:"title"`
+	Level      int    `json:"level"`
+	Tokens     int    `json:"tokens"`
+	Cumulative int    `json:"cumulative"`
+}
+
+// TokenCounts is the token estimate of a rendered skill.
+type TokenCounts struct {
+	Frontmatter int             `json:"frontmatter"`
+	Sections    []SectionTokens `json:"sections"`
+	Total       int             `json:"total"`
+}
+
+// CountTokens estimates the tokens of the rendered skill and of each section.
+func CountTokens(s *Skill) TokenCounts {
+	rendered := Render(s)
+	counts := TokenCounts{Total: EstimateTokens(rendered)}
+
+	normalized, err := Parse(rendered)
+	if err != nil {
+		return counts
+	}
+	for _, sec := range normalized.Sections {
+		counts.Sections = append(counts.Sections, SectionTokens{
+			Title:  sec.Title,
+			Level:  sec.Level,
+			Tokens: EstimateTokens(strings.Repeat("#", sec.Level) + " " + sec.Title + "\n\n" + sec.Content),
+		})
+	}
+
+	// A section's cumulative count runs until the next heading at its level
+	// or above
+	body := 0
+	for i := range counts.Sections {
+		body += counts.Sections[i].Tokens
+		total := counts.Sections[i].Tokens
+		for j := i + 1; j < len(counts.Sections) && counts.Sections[j].Level > counts.Sections[i].Level; j++ {
+			total += counts.Sections[j].Tokens
+		}
+		counts.Sections[i].Cumulative = total
+	}
+	counts.Frontmatter = counts.Total - body
+	if counts.Frontmatter < 0 {
+		counts.Frontmatter = 0
+	}
+
+	return counts
+}
+
+var (
+	detailsRegex    = regexp.MustCompile(`(?s)<details>.*?</details>\n*`)
+	codeBlockRegex  = regexp.MustCompile("(?ms)^```([\\w#+-]*)[^\\n]*\\n(.*?)^```[ \\t]*$\\n?")
+	boilerplateKeys = map[string]bool{"best practices": true}
+	schemaKeys      = map[string]bool{
+		"data models": true, "models": true, "schemas": true, "types": true,
+		"messages": true, "enums": true, "components": true,
+	}
+	exampleLanguages = map[string]bool{
+		"bash": true, "sh": true, "shell": true, "curl": true, "javascript": true, "js": true,
+		"node": true, "node.js": true, "typescript": true, "ts": true, "python": true, "py": true,
+		"go": true, "golang": true, "java": true, "ruby": true, "php": true, "csharp": true,
+		"c#": true, "kotlin": true, "swift": true, "rust": true,
+	}
+)
+
+// longCodeLines is the length above which a code block counts as a dump.
+const longCodeLines = 30
+
+// TrimToBudget progressively drops low-value content until the rendered skill
+// fits in maxTokens: best-practices boilerplate first, then code examples
+// repeated in several languages, then long schema and code dumps. It returns
+// a trimmed copy and a description of each step taken; s itself is not
+// modified. The result can still exceed the budget once nothing is left to
+// trim.
+func TrimToBudget(s *Skill, maxTokens int) (*Skill, []string) {
+	if maxTokens <= 0 || EstimateTokens(Render(s)) <= maxTokens {
+		return s, nil
+	}
+
+	// Work on a re-parsed copy so nested headings are separate sections
+	trimmed, err := Parse(Render(s))
+	if err != nil {
+		return s, nil
+	}
+	trimmed.Frontmatter = s.Frontmatter
+	trimmed.Raw = ""
+
+	var steps []string
+	for _, stage := range []func(*[]*ParsedSection) []string{
+		trimBoilerplate,
+		trimDuplicateExamples,
+		trimSchemaDumps,
+	} {
+		tree := trimmed.Tree()
+		steps = append(steps, stage(&tree)...)
+		trimmed.Sections = FlattenTree(tree)
+		if EstimateTokens(Render(trimmed)) <= maxTokens {
+			break
+		}
+	}
+	trimmed.Content = renderSections(trimmed.Sections)
+
+	return trimmed, steps
+}
+
+// trimBoilerplate removes generic advice sections.
+func trimBoilerplate(tree *[]*ParsedSection) []string {
+	var steps []string
+	walkTree(tree, func(nodes *[]*ParsedSection) {
+		kept := (*nodes)[:0]
+		for _, node := range *nodes {
+			if boilerplateKeys[strings.ToLower(strings.TrimSpace(node.Title))] {
+				steps = append(steps, fmt.Sprintf("removed section %q", node.Title))
+				continue
+			}
+			kept = append(kept, node)
+		}
+		*nodes = kept
+	})
+	return steps
+}
+
+// trimDuplicateExamples keeps one code example per section where the same
+// call is shown in several client languages.
+func trimDuplicateExamples(tree *[]*ParsedSection) []string {
+	dropped := 0
+	walkTree(tree, func(nodes *[]*ParsedSection) {
+		// Sibling sections named after languages, e.g. "### Python", "### Go"
+		kept := (*nodes)[:0]
+		seenLanguage := false
+		for _, node := range *nodes {
+			if isExampleLanguage(node.Title) && len(node.Children) == 0 {
+				if seenLanguage {
+					dropped++
+					continue
+				}
+				seenLanguage = true
+			}
+			kept = append(kept, node)
+		}
+		*nodes = kept
+
+		for _, node := range *nodes {
+			var n int
+			node.Content, n = dropRepeatedExamples(node.Content)
+			dropped += n
+		}
+	})
+
+	if dropped == 0 {
+		return nil
+	}
+	return []string{fmt.Sprintf("dropped %d duplicate code examples", dropped)}
+}
+
+// dropRepeatedExamples keeps the first collapsible example block of content
+// and drops code blocks in a client language other than the first one used.
+func dropRepeatedExamples(content string) (string, int) {
+	dropped := 0
+
+	first := true
+	content = detailsRegex.ReplaceAllStringFunc(content, func(block string) string {
+		if !strings.Contains(block, "```") {
+			return block
+		}
+		if first {
+			first = false
+			return block
+		}
+		dropped++
+		return ""
+	})
+
+	kept := ""
+	content = codeBlockRegex.ReplaceAllStringFunc(content, func(block string) string {
+		lang := strings.ToLower(codeBlockRegex.FindStringSubmatch(block)[1])
+		if !exampleLanguages[lang] {
+			return block
+		}
+		if kept == "" {
+			kept = lang
+		}
+		if lang != kept {
+			dropped++
+			return ""
+		}
+		return block
+	})
+
+	return strings.TrimSpace(content), dropped
+}
+
+// trimSchemaDumps collapses schema sections to a list of their names and cuts
+// long code blocks short.
+func trimSchemaDumps(tree *[]*ParsedSection) []string {
+	var steps []string
+	cut := 0
+	walkTree(tree, func(nodes *[]*ParsedSection) {
+		for _, node := range *nodes {
+			if schemaKeys[strings.ToLower(strings.TrimSpace(node.Title))] && len(node.Children) > 0 {
+				names := make([]string, len(node.Children))
+				for i, child := range node.Children {
+					names[i] = "`" + child.Title + "`"
+				}
+				summary := fmt.Sprintf("Defined: %s.", strings.Join(names, ", "))
+				node.Content = strings.TrimSpace(node.Content + "\n\n" + summary)
+				steps = append(steps, fmt.Sprintf("collapsed %d entries under %q", len(node.Children), node.Title))
+				node.Children = nil
+			}
+
+			node.Content = codeBlockRegex.ReplaceAllStringFunc(node.Content, func(block string) string {
+				m := codeBlockRegex.FindStringSubmatch(block)
+				lines := strings.Split(strings.TrimSuffix(m[2], "\n"), "\n")
+				if len(lines) <= longCodeLines {
+					return block
+				}
+				cut++
+				return fmt.Sprintf("```%s\n%s\n```\n\n_(%d more lines trimmed)_\n", m[1],
+					strings.Join(lines[:longCodeLines/3], "\n"), len(lines)-longCodeLines/3)
+			})
+		}
+	})
+
+	if cut > 0 {
+		steps = append(steps, fmt.Sprintf("shortened %d long code blocks", cut))
+	}
+	return steps
+}
+
+// walkTree calls fn on every sibling list of the tree, parents first, and
+// then on the children of the nodes fn kept.
+func walkTree(nodes *[]*ParsedSection, fn func(*[]*ParsedSection)) {
+	fn(nodes)
+	for _, node := range *nodes {
+		walkTree(&node.Children, fn)
+	}
+}
+
+// isExampleLanguage reports whether a heading names a client language, e.g.
+// "Python" or "JavaScript/Node.js".
+func isExampleLanguage(title string) bool {
+	name := strings.ToLower(strings.TrimSpace(title))
+	if i := strings.IndexAny(name, "/ ("); i > 0 {
+		name = name[:i]
+	}
+	return exampleLanguages[name]
+}
+
+// renderSections renders sections as a markdown body.
+func renderSections(sections []Section) string {
+	var b strings.Builder
+	for _, sec := range sections {
+		b.WriteString(strings.Repeat("#", sec.Level) + " " + sec.Title + "\n\n")
+		if sec.Content != "" {
+			b.WriteString(sec.Content + "\n\n")
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
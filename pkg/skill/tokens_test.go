@@ -0,0 +1,94 @@
+package skill
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEstimateTokens(t *testing.T) {
+	tests := []struct {
+		text string
+		want int
+	}{
+		{"", 0},
+		{"hello", 2},
+		{"hello world", 4},
+		{"GET /users/{id}", 8},
+		{"  \n\t ", 0},
+	}
+	for _, tt := range tests {
+		if got := EstimateTokens(tt.text); got != tt.want {
+			t.Errorf("EstimateTokens(%q) = %d, want %d", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestCountTokens(t *testing.T) {
+	s := NewSkill("Counter", "")
+	s.Sections = []Section{
+		{Title: "Endpoints", Level: 2, Content: "All endpoints."},
+		{Title: "GET /users", Level: 3, Content: "List users."},
+		{Title: "Notes", Level: 2, Content: "Nothing else."},
+	}
+
+	counts := CountTokens(s)
+	if len(counts.Sections) != 3 {
+		t.Fatalf("expected 3 sections, got %d", len(counts.Sections))
+	}
+	endpoints, users := counts.Sections[0], counts.Sections[1]
+	if endpoints.Cumulative != endpoints.Tokens+users.Tokens {
+		t.Errorf("expected cumulative count to include nested sections, got %+v", endpoints)
+	}
+	sum := counts.Frontmatter
+	for _, sec := range counts.Sections {
+		sum += sec.Tokens
+	}
+	if sum != counts.Total || counts.Frontmatter == 0 {
+		t.Errorf("expected frontmatter and sections to add up to %d, got %d", counts.Total, sum)
+	}
+}
+
+func TestTrimToBudget(t *testing.T) {
+	examples := "### Example\n\n" +
+		"<details>\n<summary>cURL</summary>\n\n```bash\ncurl /users\n```\n\n</details>\n\n" +
+		"<details>\n<summary>Python</summary>\n\n```python\nrequests.get('/users')\n```\n\n</details>\n\n" +
+		"```go\nhttp.Get(\"/users\")\n```"
+	schema := "```json\n" + strings.Repeat("{\"field\": \"value\"}\n", 60) + "```"
+
+	s := NewSkill("Big", "")
+	s.Sections = []Section{
+		{Title: "Endpoints", Level: 2, Content: "### GET /users\n\nList users.\n\n" + examples},
+		{Title: "Data Models", Level: 2, Content: "### User\n\n" + schema + "\n\n### Order\n\nAn order."},
+		{Title: "Best Practices", Level: 2, Content: strings.Repeat("Be careful. ", 40)},
+	}
+	original := Render(s)
+
+	if trimmed, steps := TrimToBudget(s, EstimateTokens(original)); trimmed != s || steps != nil {
+		t.Fatal("expected a skill within budget to be returned unchanged")
+	}
+
+	trimmed, steps := TrimToBudget(s, 100)
+	if Render(s) != original {
+		t.Error("expected the input skill to be left untouched")
+	}
+	rendered := Render(trimmed)
+	for _, gone := range []string{"Best Practices", "requests.get", "http.Get", "### User"} {
+		if strings.Contains(rendered, gone) {
+			t.Errorf("expected %q to be trimmed", gone)
+		}
+	}
+	for _, kept := range []string{"curl /users", "Defined: `User`, `Order`.", "List users."} {
+		if !strings.Contains(rendered, kept) {
+			t.Errorf("expected %q to be kept:\n%s", kept, rendered)
+		}
+	}
+	if len(steps) != 3 {
+		t.Errorf("expected 3 trimming steps, got %v", steps)
+	}
+
+	// Stops as soon as the budget is met
+	_, steps = TrimToBudget(s, EstimateTokens(original)-10)
+	if len(steps) != 1 || !strings.Contains(steps[0], "Best Practices") {
+		t.Errorf("expected only boilerplate to be trimmed, got %v", steps)
+	}
+}
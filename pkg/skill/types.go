@@ -1,7 +1,11 @@
 // Package skill provides types and utilities for SKILL.md files.
 package skill
 
-import "time"
+import (
+	"regexp"
+	"strings"
+	"time"
+)
 
 // Skill represents a complete SKILL.md document.
 type Skill struct {
@@ -17,6 +21,27 @@ type ToolDefinition struct {
 	Description string                 `yaml:"description" json:"description"`
 	Parameters  map[string]interface{} `yaml:"parameters,omitempty" json:"parameters,omitempty"`
 	Required    []string               `yaml:"required,omitempty" json:"required,omitempty"`
+	// Method and Path record the HTTP operation behind the tool, relative to
+	// Frontmatter.BaseURL. Path placeholders use the {name} form.
+	Method string `yaml:"method,omitempty" json:"method,omitempty"`
+	Path   string `yaml:"path,omitempty" json:"path,omitempty"`
+	// Location maps each argument to where it is sent: path, query, header,
+	// body (JSON), form (URL-encoded body) or multipart. A form or multipart
+	// argument is either an object of fields or a single field. Arguments
+	// without an entry fall back to path placeholders, "body" and then the
+	// query string.
+	Location map[string]string `yaml:"location,omitempty" json:"location,omitempty"`
+	// ParamNames maps arguments renamed to keep them unique, such as
+	// query_id next to path_id, to the parameter name sent on the wire.
+	// Arguments without an entry are sent under their own name.
+	ParamNames map[string]string `yaml:"param_names,omitempty" json:"param_names,omitempty"`
+	// GraphQL is the operation document of a GraphQL tool. Calls POST it to
+	// the endpoint at the base URL plus Path, with the arguments as its
+	// variables.
+	GraphQL string `yaml:"graphql,omitempty" json:"graphql,omitempty"`
+	// BaseURL overrides Frontmatter.BaseURL for this tool. Merged skills set
+	// it when their sources point at different hosts.
+	BaseURL string `yaml:"base_url,omitempty" json:"base_url,omitempty"`
 }
 
 // RetryStrategy defines retry behavior for API operations.
@@ -66,6 +91,10 @@ type Frontmatter struct {
 	ServiceCount int      `yaml:"service_count,omitempty" json:"service_count,omitempty"`
 	MessageCount int      `yaml:"message_count,omitempty" json:"message_count,omitempty"`
 	Servers      []string `yaml:"servers,omitempty" json:"servers,omitempty"`
+
+	// Extensions holds the keys not listed above, such as license or
+	// allowed-tools, so they survive Parse and Render.
+	Extensions map[string]interface{} `yaml:",inline" json:"extensions,omitempty"`
 }
 
 // Section represents a section within a SKILL.md file.
@@ -85,10 +114,35 @@ type StoredSkill struct {
 	Content      string    `json:"content"`
 	ContentHash  string    `json:"content_hash"`
 	SourceFormat string    `json:"source_format"`
+	Owner        string    `json:"owner,omitempty"`
 	Tags         []string  `json:"tags"`
 	ViewCount    int64     `json:"view_count"`
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
+	// Files holds the skill folder when the skill was published as one,
+	// SKILL.md included. Content is the folder assembled into one file.
+	Files []File `json:"files,omitempty"`
+}
+
+// File is one file of a skill folder, at a slash-separated path relative to
+// the folder.
+type File struct {
+	Path    string `json:"path"`
+	Content string `json:"content"`
+}
+
+// SkillVersion is a snapshot of a stored skill at one revision.
+type SkillVersion struct {
+	ID           int64     `json:"-"`
+	SkillID      string    `json:"skill_id"`
+	Revision     int       `json:"revision"`
+	Version      string    `json:"version"`
+	Name         string    `json:"name"`
+	Description  string    `json:"description"`
+	Content      string    `json:"content,omitempty"`
+	ContentHash  string    `json:"content_hash"`
+	SourceFormat string    `json:"source_format"`
+	CreatedAt    time.Time `json:"created_at"`
 }
 
 // NewSkill creates a new Skill with default values.
@@ -112,3 +166,39 @@ func (s *Skill) AddSection(title string, level int, content string) {
 		Content: content,
 	})
 }
+
+// ParamName returns the parameter name an argument is sent under.
+func (t *ToolDefinition) ParamName(arg string) string {
+	if name, ok := t.ParamNames[arg]; ok {
+		return name
+	}
+	return arg
+}
+
+var (
+	// toolNameRegex matches the tool names MCP clients and the OpenAI API
+	// accept: up to 64 letters, digits, _ and -.
+	toolNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,64}$`)
+	// toolNameInvalidRegex matches runs of characters tool names cannot use.
+	toolNameInvalidRegex = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+)
+
+// ValidToolName reports whether clients accept name as a tool name.
+func ValidToolName(name string) bool {
+	return toolNameRegex.MatchString(name)
+}
+
+// SanitizeToolName converts a path/name to a valid tool name.
+func SanitizeToolName(name string) string {
+	name = strings.ReplaceAll(name, "/", "_")
+	name = strings.ReplaceAll(name, "-", "_")
+	name = strings.ReplaceAll(name, ".", "_")
+	name = strings.ReplaceAll(name, "{", "")
+	name = strings.ReplaceAll(name, "}", "")
+	name = toolNameInvalidRegex.ReplaceAllString(strings.ToLower(name), "_")
+	name = strings.Trim(name, "_")
+	if len(name) > 64 {
+		name = strings.TrimRight(name[:64], "_")
+	}
+	return name
+}